@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	cqlv1alpha1 "github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	cqlv1beta1 "github.com/crossplane/provider-cassandra/apis/cql/v1beta1"
 	cassandrav1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
 )
 
@@ -29,6 +30,7 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		cassandrav1alpha1.SchemeBuilder.AddToScheme,
 		cqlv1alpha1.SchemeBuilder.AddToScheme,
+		cqlv1beta1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -55,6 +55,20 @@ type GrantParameters struct {
 	// +optional
 	RoleSelector *xpv1.Selector `json:"roleSelector,omitempty"`
 
+	// Roles this grant is for, in addition to Role. The same privileges are
+	// granted to each of them.
+	// +optional
+	// +crossplane:generate:reference:type=Role
+	Roles []string `json:"roles,omitempty"`
+
+	// RolesRefs references the role objects this grant is for.
+	// +optional
+	RolesRefs []xpv1.Reference `json:"rolesRefs,omitempty"`
+
+	// RolesSelector selects references to Roles this grant is for.
+	// +optional
+	RolesSelector *xpv1.Selector `json:"rolesSelector,omitempty"`
+
 	// Keyspace this grant is for.
 	// +optional
 	// +crossplane:generate:reference:type=Keyspace
@@ -69,12 +83,54 @@ type GrantParameters struct {
 	// +immutable
 	// +optional
 	KeyspaceSelector *xpv1.Selector `json:"keyspaceSelector,omitempty"`
+
+	// Table this grant is for. The privileges are granted on the table in
+	// Keyspace rather than on the whole keyspace when it is set.
+	// +optional
+	// +crossplane:generate:reference:type=Table
+	Table *string `json:"table,omitempty"`
+
+	// TableRef references the table object this grant is for.
+	// +immutable
+	// +optional
+	TableRef *xpv1.Reference `json:"tableRef,omitempty"`
+
+	// TableSelector selects a reference to a Table this grant is for.
+	// +immutable
+	// +optional
+	TableSelector *xpv1.Selector `json:"tableSelector,omitempty"`
+
+	// Restricted lists privileges that are denied to the roles on the
+	// keyspace or table with RESTRICT, even when they are inherited from other roles.
+	// Restrictions that are not listed are lifted with UNRESTRICT. This
+	// requires DataStax Enterprise.
+	// +optional
+	Restricted []GrantPrivilege `json:"restricted,omitempty"`
+
+	// RevokeUnmanaged revokes every permission of the role on the keyspace
+	// that is not listed in Privileges, including permissions granted outside
+	// of Crossplane.
+	// +optional
+	RevokeUnmanaged *bool `json:"revokeUnmanaged,omitempty"`
 }
 
 // GrantObservation are the observable fields of a Grant.
 type GrantObservation struct {
-	// Privileges represents the applied privileges
+	// Privileges are the permissions every role holds on the keyspace, as
+	// stored in system_auth.role_permissions.
 	Privileges []string `json:"privileges,omitempty"`
+
+	// Restricted are the permissions restricted for every role on the
+	// keyspace. They are only observed when restrictions are set.
+	Restricted []string `json:"restricted,omitempty"`
+
+	// ManagedPrivileges are the observed permissions that were granted by
+	// this Grant. They are revoked when they are removed from the spec.
+	ManagedPrivileges []string `json:"managedPrivileges,omitempty"`
+
+	// Roles are the roles this Grant gave permissions to. Roles that are
+	// removed from the spec have their permissions revoked.
+	Roles []string `json:"roles,omitempty"`
 }
 
 // A GrantSpec defines the desired state of a Grant.
@@ -89,6 +145,8 @@ type GrantStatus struct {
 	AtProvider          GrantObservation `json:"atProvider,omitempty"`
 }
 
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cql-cassandra-crossplane-io-v1alpha1-grant,mutating=false,failurePolicy=fail,groups=cql.cassandra.crossplane.io,resources=grants,versions=v1alpha1,name=grants.cql.cassandra.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
 // +kubebuilder:object:root=true
 
 // A Grant is an example API type.
@@ -97,6 +155,7 @@ type GrantStatus struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
 type Grant struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// IndexParameters are the configurable fields of an Index.
+type IndexParameters struct {
+	// Keyspace the indexed table belongs to.
+	// +optional
+	// +crossplane:generate:reference:type=Keyspace
+	Keyspace *string `json:"keyspace,omitempty"`
+
+	// KeyspaceRef references the keyspace object the indexed table belongs to.
+	// +immutable
+	// +optional
+	KeyspaceRef *xpv1.Reference `json:"keyspaceRef,omitempty"`
+
+	// KeyspaceSelector selects a reference to a Keyspace the indexed table
+	// belongs to.
+	// +immutable
+	// +optional
+	KeyspaceSelector *xpv1.Selector `json:"keyspaceSelector,omitempty"`
+
+	// Table the index is created on.
+	Table string `json:"table"`
+
+	// Column the index is created on.
+	Column string `json:"column"`
+
+	// Using is the index implementation for custom indexes, for example
+	// StorageAttachedIndex, SASIIndex or a fully qualified class name. A
+	// regular secondary index is created when it is not set.
+	// +optional
+	Using *string `json:"using,omitempty"`
+
+	// Options passed to a custom index implementation.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// IndexObservation are the observable fields of an Index.
+type IndexObservation struct {
+	// Kind of the index as reported by system_schema.indexes.
+	Kind string `json:"kind,omitempty"`
+
+	// ClassName of a custom index.
+	ClassName string `json:"className,omitempty"`
+}
+
+// An IndexSpec defines the desired state of an Index.
+type IndexSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       IndexParameters `json:"forProvider"`
+}
+
+// An IndexStatus represents the observed state of an Index.
+type IndexStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          IndexObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cql-cassandra-crossplane-io-v1alpha1-index,mutating=false,failurePolicy=fail,groups=cql.cassandra.crossplane.io,resources=indices,versions=v1alpha1,name=indices.cql.cassandra.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
+// +kubebuilder:object:root=true
+
+// An Index is a secondary or custom index on a table column.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="TABLE",type="string",JSONPath=".spec.forProvider.table"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
+type Index struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IndexSpec   `json:"spec"`
+	Status IndexStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IndexList contains a list of Index
+type IndexList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Index `json:"items"`
+}
+
+// Index type metadata.
+var (
+	IndexKind             = reflect.TypeOf(Index{}).Name()
+	IndexGroupKind        = schema.GroupKind{Group: Group, Kind: IndexKind}.String()
+	IndexKindAPIVersion   = IndexKind + "." + SchemeGroupVersion.String()
+	IndexGroupVersionKind = SchemeGroupVersion.WithKind(IndexKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Index{}, &IndexList{})
+}
@@ -26,6 +26,9 @@ import (
 )
 
 // KeyspaceParameters are the configurable fields of a Keyspace.
+// +kubebuilder:validation:XValidation:rule="!(has(self.replicationFactor) && has(self.datacenters))",message="replicationFactor and datacenters are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.replicationClass) || self.replicationClass != 'NetworkTopologyStrategy' || has(self.datacenters)",message="NetworkTopologyStrategy requires datacenters"
+// +kubebuilder:validation:XValidation:rule="!has(self.datacenters) || (has(self.replicationClass) && self.replicationClass == 'NetworkTopologyStrategy')",message="datacenters require NetworkTopologyStrategy"
 type KeyspaceParameters struct {
 	// ReplicationClass used for keyspace
 	// +kubebuilder:validation:Enum=SimpleStrategy;NetworkTopologyStrategy
@@ -33,23 +36,118 @@ type KeyspaceParameters struct {
 	ReplicationClass *string `json:"replicationClass,omitempty"`
 
 	// ReplicationFactor used for keyspace
+	// +kubebuilder:validation:Minimum=1
 	// +optional
 	ReplicationFactor *int `json:"replicationFactor,omitempty"`
 
+	// Datacenters maps every datacenter of a keyspace using
+	// NetworkTopologyStrategy to its replication factor.
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:XValidation:rule="self.all(dc, self[dc] >= 1)",message="replication factor of every datacenter must be at least 1"
+	// +optional
+	Datacenters map[string]int `json:"datacenters,omitempty"`
+
+	// PartialReplication only manages the datacenters listed in Datacenters.
+	// Replication of any other datacenter is left untouched, so that
+	// datacenters can be added and removed operationally.
+	// +optional
+	PartialReplication *bool `json:"partialReplication,omitempty"`
+
 	// Decided if turn on durable writes
 	// +optional
 	DurableWrites *bool `json:"durableWrites,omitempty"`
+
+	// GraphEngine enables DSE Graph on the keyspace. It is ignored on
+	// clusters that are not running DataStax Enterprise.
+	// +kubebuilder:validation:Enum=Core;Classic
+	// +optional
+	GraphEngine *string `json:"graphEngine,omitempty"`
+
+	// RequireEmptyOnDelete refuses to drop the keyspace while it still
+	// contains tables.
+	// +optional
+	RequireEmptyOnDelete *bool `json:"requireEmptyOnDelete,omitempty"`
+
+	// SkipDrop releases the keyspace from management without dropping it
+	// when the Keyspace is deleted, in the same way as a deletionPolicy of
+	// Orphan. An event is emitted whenever a keyspace is left behind.
+	// +optional
+	SkipDrop *bool `json:"skipDrop,omitempty"`
+
+	// ManageSystemKeyspace must be set to manage the replication of a
+	// replicated system keyspace such as system_auth, system_distributed or
+	// system_traces. System keyspaces are never dropped.
+	// +optional
+	ManageSystemKeyspace *bool `json:"manageSystemKeyspace,omitempty"`
+}
+
+// KeyspaceInitParameters are the fields of a Keyspace that are only set when
+// the keyspace is created. Those that are not also set in forProvider are
+// excluded from drift detection, so that they can be changed operationally
+// once the keyspace exists. When neither sets the replication or durable
+// writes, they are defaulted here when the Keyspace is created: to
+// NetworkTopologyStrategy across the datacenters known to its ProviderConfig,
+// or to SimpleStrategy, and to durable writes.
+// +kubebuilder:validation:XValidation:rule="!(has(self.replicationFactor) && has(self.datacenters))",message="replicationFactor and datacenters are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.replicationClass) || self.replicationClass != 'NetworkTopologyStrategy' || has(self.datacenters)",message="NetworkTopologyStrategy requires datacenters"
+// +kubebuilder:validation:XValidation:rule="!has(self.datacenters) || (has(self.replicationClass) && self.replicationClass == 'NetworkTopologyStrategy')",message="datacenters require NetworkTopologyStrategy"
+type KeyspaceInitParameters struct {
+	// ReplicationClass the keyspace is created with. It is ignored when the
+	// replication is set in forProvider.
+	// +kubebuilder:validation:Enum=SimpleStrategy;NetworkTopologyStrategy
+	// +optional
+	ReplicationClass *string `json:"replicationClass,omitempty"`
+
+	// ReplicationFactor the keyspace is created with. It is ignored when the
+	// replication is set in forProvider.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ReplicationFactor *int `json:"replicationFactor,omitempty"`
+
+	// Datacenters the keyspace is created with, mapped to their replication
+	// factor. It is ignored when the replication is set in forProvider.
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:XValidation:rule="self.all(dc, self[dc] >= 1)",message="replication factor of every datacenter must be at least 1"
+	// +optional
+	Datacenters map[string]int `json:"datacenters,omitempty"`
+
+	// DurableWrites the keyspace is created with. It is ignored when it is
+	// set in forProvider.
+	// +optional
+	DurableWrites *bool `json:"durableWrites,omitempty"`
 }
 
 // KeyspaceObservation are the observable fields of a Keyspace.
 type KeyspaceObservation struct {
-	ObservableField string `json:"observableField,omitempty"`
+	// ReplicationClass is the replication strategy of the keyspace.
+	ReplicationClass string `json:"replicationClass,omitempty"`
+
+	// ReplicationFactor of a keyspace using SimpleStrategy.
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
+
+	// Datacenters maps every datacenter of a keyspace using
+	// NetworkTopologyStrategy to its replication factor.
+	Datacenters map[string]int `json:"datacenters,omitempty"`
+
+	// DurableWrites is true when the commit log is used for the keyspace.
+	DurableWrites bool `json:"durableWrites,omitempty"`
+
+	// GraphEngine of a DSE Graph keyspace.
+	GraphEngine string `json:"graphEngine,omitempty"`
+
+	// TableCount is the number of tables in the keyspace.
+	TableCount int `json:"tableCount,omitempty"`
 }
 
 // A KeyspaceSpec defines the desired state of a Keyspace.
 type KeyspaceSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       KeyspaceParameters `json:"forProvider"`
+
+	// InitProvider holds the fields that are only set when the keyspace is
+	// created.
+	// +optional
+	InitProvider KeyspaceInitParameters `json:"initProvider,omitempty"`
 }
 
 // A KeyspaceStatus represents the observed state of a Keyspace.
@@ -58,14 +156,20 @@ type KeyspaceStatus struct {
 	AtProvider          KeyspaceObservation `json:"atProvider,omitempty"`
 }
 
+// +kubebuilder:webhook:verbs=create,path=/mutate-cql-cassandra-crossplane-io-v1alpha1-keyspace,mutating=true,failurePolicy=fail,groups=cql.cassandra.crossplane.io,resources=keyspaces,versions=v1alpha1,name=default.keyspaces.cql.cassandra.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cql-cassandra-crossplane-io-v1alpha1-keyspace,mutating=false,failurePolicy=fail,groups=cql.cassandra.crossplane.io,resources=keyspaces,versions=v1alpha1,name=keyspaces.cql.cassandra.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
 // +kubebuilder:object:root=true
 
 // A Keyspace is an example API type.
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPLICATION",type="string",JSONPath=".status.atProvider.replicationClass",priority=1
+// +kubebuilder:printcolumn:name="TABLES",type="integer",JSONPath=".status.atProvider.tableCount",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
 type Keyspace struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SchemaExportTargetKind is the kind of object a schema export is written to.
+// +kubebuilder:validation:Enum=ConfigMap;Secret
+type SchemaExportTargetKind string
+
+// Schema export target kinds.
+const (
+	SchemaExportTargetConfigMap SchemaExportTargetKind = "ConfigMap"
+	SchemaExportTargetSecret    SchemaExportTargetKind = "Secret"
+)
+
+// SchemaExportTarget is the ConfigMap or Secret the schema is written to.
+type SchemaExportTarget struct {
+	// Kind of the target object.
+	// +kubebuilder:default=ConfigMap
+	// +optional
+	Kind SchemaExportTargetKind `json:"kind,omitempty"`
+
+	// Name of the target object.
+	Name string `json:"name"`
+
+	// Namespace of the target object.
+	Namespace string `json:"namespace"`
+
+	// Key the schema is stored under.
+	// +kubebuilder:default="schema.cql"
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// KeyspaceSchemaExportParameters are the configurable fields of a
+// KeyspaceSchemaExport.
+type KeyspaceSchemaExportParameters struct {
+	// Keyspace whose schema is exported.
+	// +optional
+	// +crossplane:generate:reference:type=Keyspace
+	Keyspace *string `json:"keyspace,omitempty"`
+
+	// KeyspaceRef references the keyspace object whose schema is exported.
+	// +immutable
+	// +optional
+	KeyspaceRef *xpv1.Reference `json:"keyspaceRef,omitempty"`
+
+	// KeyspaceSelector selects a reference to a Keyspace whose schema is exported.
+	// +immutable
+	// +optional
+	KeyspaceSelector *xpv1.Selector `json:"keyspaceSelector,omitempty"`
+
+	// Target is the ConfigMap or Secret the schema is written to. It is
+	// created and controlled by the export, which refuses to write to a
+	// ConfigMap or Secret it did not create, and deleted with the export.
+	Target SchemaExportTarget `json:"target"`
+
+	// Interval between two exports of the schema.
+	// +kubebuilder:default="24h"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// KeyspaceSchemaExportObservation are the observable fields of a
+// KeyspaceSchemaExport.
+type KeyspaceSchemaExportObservation struct {
+	// LastExportTime is the time the schema was last written to the target.
+	LastExportTime *metav1.Time `json:"lastExportTime,omitempty"`
+
+	// SchemaHash is the SHA-256 of the last exported schema.
+	SchemaHash string `json:"schemaHash,omitempty"`
+}
+
+// A KeyspaceSchemaExportSpec defines the desired state of a KeyspaceSchemaExport.
+type KeyspaceSchemaExportSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       KeyspaceSchemaExportParameters `json:"forProvider"`
+}
+
+// A KeyspaceSchemaExportStatus represents the observed state of a
+// KeyspaceSchemaExport.
+type KeyspaceSchemaExportStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KeyspaceSchemaExportObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A KeyspaceSchemaExport periodically snapshots the DDL of a keyspace into a
+// ConfigMap or Secret.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="KEYSPACE",type="string",JSONPath=".spec.forProvider.keyspace"
+// +kubebuilder:printcolumn:name="LAST-EXPORT",type="date",JSONPath=".status.atProvider.lastExportTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
+type KeyspaceSchemaExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeyspaceSchemaExportSpec   `json:"spec"`
+	Status KeyspaceSchemaExportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeyspaceSchemaExportList contains a list of KeyspaceSchemaExport
+type KeyspaceSchemaExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeyspaceSchemaExport `json:"items"`
+}
+
+// KeyspaceSchemaExport type metadata.
+var (
+	KeyspaceSchemaExportKind             = reflect.TypeOf(KeyspaceSchemaExport{}).Name()
+	KeyspaceSchemaExportGroupKind        = schema.GroupKind{Group: Group, Kind: KeyspaceSchemaExportKind}.String()
+	KeyspaceSchemaExportKindAPIVersion   = KeyspaceSchemaExportKind + "." + SchemeGroupVersion.String()
+	KeyspaceSchemaExportGroupVersionKind = SchemeGroupVersion.WithKind(KeyspaceSchemaExportKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&KeyspaceSchemaExport{}, &KeyspaceSchemaExportList{})
+}
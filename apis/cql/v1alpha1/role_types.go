@@ -33,26 +33,182 @@ type RolePrivilege struct {
 	SuperUser *bool `json:"superUser,omitempty"`
 
 	// Login grants LOGIN when true, allowing the role to login to the server.
+	// It defaults to true when the Role is created, unless the Role sets its
+	// external name, as it may then adopt an existing role whose privileges
+	// are kept.
 	// +optional
 	Login *bool `json:"login,omitempty"`
 }
 
+// PasswordGeneration configures how the password of a role is generated.
+type PasswordGeneration struct {
+	// Length of the generated password.
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=128
+	// +kubebuilder:default=27
+	// +optional
+	Length *int `json:"length,omitempty"`
+
+	// Lowercase includes lowercase letters.
+	// +kubebuilder:default=true
+	// +optional
+	Lowercase *bool `json:"lowercase,omitempty"`
+
+	// Uppercase includes uppercase letters.
+	// +kubebuilder:default=true
+	// +optional
+	Uppercase *bool `json:"uppercase,omitempty"`
+
+	// Digits includes digits.
+	// +kubebuilder:default=true
+	// +optional
+	Digits *bool `json:"digits,omitempty"`
+
+	// Symbols includes punctuation characters other than quotes, backslashes
+	// and backticks.
+	// +optional
+	Symbols *bool `json:"symbols,omitempty"`
+
+	// ExcludeCharacters lists characters that must not appear in the
+	// generated password.
+	// +optional
+	ExcludeCharacters *string `json:"excludeCharacters,omitempty"`
+}
+
 // RoleParameters are the configurable fields of a Role.
+// +kubebuilder:validation:XValidation:rule="!(has(self.passwordSecretRef) && has(self.hashedPasswordSecretRef))",message="passwordSecretRef and hashedPasswordSecretRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.passwordless) && self.passwordless && (has(self.passwordSecretRef) || has(self.hashedPasswordSecretRef)))",message="passwordless roles cannot reference a password"
 type RoleParameters struct {
 	// Privileges to be granted.
 	// +optional
 	Privileges RolePrivilege `json:"privileges,omitempty"`
+
+	// MemberOf lists the roles this role is granted. Memberships that are
+	// not listed are revoked. Memberships are not managed when it is not set.
+	// +optional
+	// +crossplane:generate:reference:type=Role
+	MemberOf []string `json:"memberOf,omitempty"`
+
+	// MemberOfRefs references the role objects this role is granted.
+	// +optional
+	MemberOfRefs []xpv1.Reference `json:"memberOfRefs,omitempty"`
+
+	// MemberOfSelector selects references to Roles this role is granted.
+	// +optional
+	MemberOfSelector *xpv1.Selector `json:"memberOfSelector,omitempty"`
+
+	// PasswordSecretRef references the key of a Secret holding the password
+	// of the role. A password is generated when it is not set. Changes of
+	// the password are detected by comparing it with the password in the
+	// connection secret.
+	// +optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// HashedPasswordSecretRef references the key of a Secret holding the
+	// bcrypt hash of the password of the role, which is set with HASHED
+	// PASSWORD. This requires Cassandra 5 or DataStax Enterprise. The
+	// password is not published to the connection secret.
+	// +optional
+	HashedPasswordSecretRef *xpv1.SecretKeySelector `json:"hashedPasswordSecretRef,omitempty"`
+
+	// Passwordless creates the role without a password, for roles that
+	// authenticate with an external authenticator such as LDAP or Kerberos.
+	// No password is generated or published to the connection secret.
+	// +optional
+	Passwordless *bool `json:"passwordless,omitempty"`
+
+	// RotateAfter is the age after which a generated password is replaced
+	// with a new one and republished to the connection secret. Passwords are
+	// not rotated when it is not set, or when a password secret is referenced.
+	// +optional
+	RotateAfter *metav1.Duration `json:"rotateAfter,omitempty"`
+
+	// Options are custom options passed to the role manager with WITH
+	// OPTIONS, for example by DataStax Enterprise or ScyllaDB.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+
+	// PasswordGeneration configures how passwords are generated when
+	// PasswordSecretRef is not set.
+	// +optional
+	PasswordGeneration *PasswordGeneration `json:"passwordGeneration,omitempty"`
+
+	// ConnectionSecretKeys maps the keys of the connection secret, such as
+	// username, password, endpoint or port, to the keys they are published
+	// under, for example SPRING_CASSANDRA_USERNAME. Keys that are not listed
+	// are published unchanged.
+	// +optional
+	ConnectionSecretKeys map[string]string `json:"connectionSecretKeys,omitempty"`
+
+	// ConnectionKeyspace is the keyspace named by the connection string
+	// published to the connection secret under connectionString. The
+	// connection string names no keyspace when it is not set.
+	// +optional
+	ConnectionKeyspace *string `json:"connectionKeyspace,omitempty"`
+
+	// SkipDrop releases the role from management without dropping it when
+	// the Role is deleted, in the same way as a deletionPolicy of Orphan. An
+	// event is emitted whenever a role is left behind.
+	// +optional
+	SkipDrop *bool `json:"skipDrop,omitempty"`
+
+	// DetectPasswordChanges compares the salted hash of the role stored in
+	// system_auth.roles with the password last set by the provider, so that
+	// passwords changed outside of Crossplane are restored.
+	// +optional
+	DetectPasswordChanges *bool `json:"detectPasswordChanges,omitempty"`
+}
+
+// RolePermissions are the permissions of a role on a resource.
+type RolePermissions struct {
+	// Resource the permissions apply to, for example data/my_keyspace or
+	// roles/my_role.
+	Resource string `json:"resource"`
+
+	// Permissions granted on the resource.
+	Permissions []string `json:"permissions,omitempty"`
 }
 
 // RoleObservation are the observable fields of a Role.
 type RoleObservation struct {
-	ObservableField string `json:"observableField,omitempty"`
+	// PasswordLastRotated is the time the password of the role was last set
+	// by the provider.
+	PasswordLastRotated *metav1.Time `json:"passwordLastRotated,omitempty"`
+
+	// MemberOf lists the roles granted to the role.
+	MemberOf []string `json:"memberOf,omitempty"`
+
+	// Permissions lists the permissions granted directly to the role. Those
+	// inherited from the roles it is a member of are not included.
+	Permissions []RolePermissions `json:"permissions,omitempty"`
+}
+
+// RoleInitParameters are the fields of a Role that are only set when the role
+// is created. They are ignored when a password is set in forProvider.
+// +kubebuilder:validation:XValidation:rule="!(has(self.passwordSecretRef) && has(self.hashedPasswordSecretRef))",message="passwordSecretRef and hashedPasswordSecretRef are mutually exclusive"
+type RoleInitParameters struct {
+	// PasswordSecretRef references the key of a Secret holding the initial
+	// password of the role. Later changes of the password, in the Secret or
+	// in the cluster, are not reconciled, and it is never rotated.
+	// +optional
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// HashedPasswordSecretRef references the key of a Secret holding the
+	// bcrypt hash of the initial password of the role. Later changes of the
+	// password are not reconciled, and it is never rotated.
+	// +optional
+	HashedPasswordSecretRef *xpv1.SecretKeySelector `json:"hashedPasswordSecretRef,omitempty"`
 }
 
 // A RoleSpec defines the desired state of a Role.
 type RoleSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       RoleParameters `json:"forProvider"`
+
+	// InitProvider holds the fields that are only set when the role is
+	// created.
+	// +optional
+	InitProvider RoleInitParameters `json:"initProvider,omitempty"`
 }
 
 // A RoleStatus represents the observed state of a Role.
@@ -61,6 +217,9 @@ type RoleStatus struct {
 	AtProvider          RoleObservation `json:"atProvider,omitempty"`
 }
 
+// +kubebuilder:webhook:verbs=create,path=/mutate-cql-cassandra-crossplane-io-v1alpha1-role,mutating=true,failurePolicy=fail,groups=cql.cassandra.crossplane.io,resources=roles,versions=v1alpha1,name=default.roles.cql.cassandra.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cql-cassandra-crossplane-io-v1alpha1-role,mutating=false,failurePolicy=fail,groups=cql.cassandra.crossplane.io,resources=roles,versions=v1alpha1,name=roles.cql.cassandra.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
 // +kubebuilder:object:root=true
 
 // A Role is an example API type.
@@ -69,6 +228,7 @@ type RoleStatus struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
 type Role struct {
 	metav1.TypeMeta   `json:",inline"`
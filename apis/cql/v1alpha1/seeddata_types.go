@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SeedDataParameters are the configurable fields of a SeedData.
+type SeedDataParameters struct {
+	// Keyspace the table belongs to.
+	// +optional
+	// +crossplane:generate:reference:type=Keyspace
+	Keyspace *string `json:"keyspace,omitempty"`
+
+	// KeyspaceRef references the keyspace object the table belongs to.
+	// +immutable
+	// +optional
+	KeyspaceRef *xpv1.Reference `json:"keyspaceRef,omitempty"`
+
+	// KeyspaceSelector selects a reference to a Keyspace the table belongs to.
+	// +immutable
+	// +optional
+	KeyspaceSelector *xpv1.Selector `json:"keyspaceSelector,omitempty"`
+
+	// Table the rows are written to.
+	// +immutable
+	Table string `json:"table"`
+
+	// PrimaryKey lists the primary key columns of the table. Rows are
+	// identified by these columns when detecting drift.
+	// +immutable
+	// +kubebuilder:validation:MinItems=1
+	PrimaryKey []string `json:"primaryKey"`
+
+	// Rows to insert or update. Every row is a JSON object mapping column
+	// names to values in the format accepted by INSERT JSON. Columns that are
+	// not listed are left untouched.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=500
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Rows []runtime.RawExtension `json:"rows"`
+}
+
+// SeedDataObservation are the observable fields of a SeedData.
+type SeedDataObservation struct {
+	// InSyncRows is the number of rows that match the desired values.
+	InSyncRows int `json:"inSyncRows,omitempty"`
+}
+
+// A SeedDataSpec defines the desired state of a SeedData.
+type SeedDataSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SeedDataParameters `json:"forProvider"`
+}
+
+// A SeedDataStatus represents the observed state of a SeedData.
+type SeedDataStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SeedDataObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SeedData keeps a bounded set of rows, such as reference data, present in
+// a table.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TABLE",type="string",JSONPath=".spec.forProvider.table"
+// +kubebuilder:printcolumn:name="IN-SYNC",type="integer",JSONPath=".status.atProvider.inSyncRows"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
+type SeedData struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SeedDataSpec   `json:"spec"`
+	Status SeedDataStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SeedDataList contains a list of SeedData
+type SeedDataList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SeedData `json:"items"`
+}
+
+// SeedData type metadata.
+var (
+	SeedDataKind             = reflect.TypeOf(SeedData{}).Name()
+	SeedDataGroupKind        = schema.GroupKind{Group: Group, Kind: SeedDataKind}.String()
+	SeedDataKindAPIVersion   = SeedDataKind + "." + SchemeGroupVersion.String()
+	SeedDataGroupVersionKind = SchemeGroupVersion.WithKind(SeedDataKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SeedData{}, &SeedDataList{})
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TableColumn is a column of a table.
+type TableColumn struct {
+	// Name of the column.
+	Name string `json:"name"`
+
+	// Type of the column in CQL syntax, for example text or map<text, int>.
+	Type string `json:"type"`
+}
+
+// ClusteringColumn is a clustering column of a table.
+type ClusteringColumn struct {
+	// Name of the column.
+	Name string `json:"name"`
+
+	// Order of the column within a partition.
+	// +kubebuilder:validation:Enum=ASC;DESC
+	// +kubebuilder:default=ASC
+	// +optional
+	Order string `json:"order,omitempty"`
+}
+
+// TableCompaction configures the compaction strategy of a table.
+type TableCompaction struct {
+	// Class of the compaction strategy, for example
+	// SizeTieredCompactionStrategy, LeveledCompactionStrategy or
+	// TimeWindowCompactionStrategy.
+	Class string `json:"class"`
+
+	// Options of the compaction strategy, for example min_threshold or
+	// compaction_window_size.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// TableCompression configures the compression of a table's SSTables.
+type TableCompression struct {
+	// Enabled turns compression on or off.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Class of the compressor, for example LZ4Compressor, SnappyCompressor or
+	// ZstdCompressor.
+	// +optional
+	Class *string `json:"class,omitempty"`
+
+	// ChunkLengthInKB is the size of the compressed chunks.
+	// +optional
+	ChunkLengthInKB *int `json:"chunkLengthInKB,omitempty"`
+}
+
+// TableCaching configures the caching of a table.
+type TableCaching struct {
+	// Keys cached in the key cache.
+	// +kubebuilder:validation:Enum=ALL;NONE
+	// +optional
+	Keys *string `json:"keys,omitempty"`
+
+	// RowsPerPartition cached in the row cache: ALL, NONE or a number.
+	// +optional
+	RowsPerPartition *string `json:"rowsPerPartition,omitempty"`
+}
+
+// TableCDC configures change data capture on a table.
+type TableCDC struct {
+	// Enabled turns change data capture on or off.
+	Enabled bool `json:"enabled"`
+
+	// Options of Scylla's extended change data capture, for example preimage,
+	// postimage, delta or ttl. When set the table is altered with the options
+	// map syntax instead of cdc = true, which Apache Cassandra does not accept.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// TableParameters are the configurable fields of a Table.
+type TableParameters struct {
+	// Keyspace the table belongs to.
+	// +optional
+	// +crossplane:generate:reference:type=Keyspace
+	Keyspace *string `json:"keyspace,omitempty"`
+
+	// KeyspaceRef references the keyspace object the table belongs to.
+	// +immutable
+	// +optional
+	KeyspaceRef *xpv1.Reference `json:"keyspaceRef,omitempty"`
+
+	// KeyspaceSelector selects a reference to a Keyspace the table belongs to.
+	// +immutable
+	// +optional
+	KeyspaceSelector *xpv1.Selector `json:"keyspaceSelector,omitempty"`
+
+	// Columns of the table.
+	// +immutable
+	// +kubebuilder:validation:MinItems=1
+	Columns []TableColumn `json:"columns"`
+
+	// PartitionKey lists the partition key columns.
+	// +immutable
+	// +kubebuilder:validation:MinItems=1
+	PartitionKey []string `json:"partitionKey"`
+
+	// ClusteringKey lists the clustering columns.
+	// +immutable
+	// +optional
+	ClusteringKey []ClusteringColumn `json:"clusteringKey,omitempty"`
+
+	// Compaction strategy of the table.
+	// +optional
+	Compaction *TableCompaction `json:"compaction,omitempty"`
+
+	// Compression of the table.
+	// +optional
+	Compression *TableCompression `json:"compression,omitempty"`
+
+	// Caching of the table.
+	// +optional
+	Caching *TableCaching `json:"caching,omitempty"`
+
+	// CDC configures change data capture on the table.
+	// +optional
+	CDC *TableCDC `json:"cdc,omitempty"`
+}
+
+// TableObservation are the observable fields of a Table.
+type TableObservation struct {
+	// Compaction options as stored in system_schema.tables.
+	Compaction map[string]string `json:"compaction,omitempty"`
+
+	// Compression options as stored in system_schema.tables.
+	Compression map[string]string `json:"compression,omitempty"`
+
+	// Caching options as stored in system_schema.tables.
+	Caching map[string]string `json:"caching,omitempty"`
+
+	// CDC is the change data capture configuration of the table. On Apache
+	// Cassandra only the enabled key is reported.
+	CDC map[string]string `json:"cdc,omitempty"`
+}
+
+// A TableSpec defines the desired state of a Table.
+type TableSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TableParameters `json:"forProvider"`
+}
+
+// A TableStatus represents the observed state of a Table.
+type TableStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TableObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cql-cassandra-crossplane-io-v1alpha1-table,mutating=false,failurePolicy=fail,groups=cql.cassandra.crossplane.io,resources=tables,versions=v1alpha1,name=tables.cql.cassandra.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
+// +kubebuilder:object:root=true
+
+// A Table is a table in a keyspace.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="KEYSPACE",type="string",JSONPath=".spec.forProvider.keyspace"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
+type Table struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TableSpec   `json:"spec"`
+	Status TableStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TableList contains a list of Table
+type TableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Table `json:"items"`
+}
+
+// Table type metadata.
+var (
+	TableKind             = reflect.TypeOf(Table{}).Name()
+	TableGroupKind        = schema.GroupKind{Group: Group, Kind: TableKind}.String()
+	TableKindAPIVersion   = TableKind + "." + SchemeGroupVersion.String()
+	TableGroupVersionKind = SchemeGroupVersion.WithKind(TableKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Table{}, &TableList{})
+}
@@ -22,9 +22,25 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane-runtime/apis/common/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusteringColumn) DeepCopyInto(out *ClusteringColumn) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusteringColumn.
+func (in *ClusteringColumn) DeepCopy() *ClusteringColumn {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusteringColumn)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Grant) DeepCopyInto(out *Grant) {
 	*out = *in
@@ -92,6 +108,21 @@ func (in *GrantObservation) DeepCopyInto(out *GrantObservation) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Restricted != nil {
+		in, out := &in.Restricted, &out.Restricted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedPrivileges != nil {
+		in, out := &in.ManagedPrivileges, &out.ManagedPrivileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantObservation.
@@ -127,6 +158,23 @@ func (in *GrantParameters) DeepCopyInto(out *GrantParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolesRefs != nil {
+		in, out := &in.RolesRefs, &out.RolesRefs
+		*out = make([]v1.Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RolesSelector != nil {
+		in, out := &in.RolesSelector, &out.RolesSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Keyspace != nil {
 		in, out := &in.Keyspace, &out.Keyspace
 		*out = new(string)
@@ -142,6 +190,31 @@ func (in *GrantParameters) DeepCopyInto(out *GrantParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Table != nil {
+		in, out := &in.Table, &out.Table
+		*out = new(string)
+		**out = **in
+	}
+	if in.TableRef != nil {
+		in, out := &in.TableRef, &out.TableRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TableSelector != nil {
+		in, out := &in.TableSelector, &out.TableSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restricted != nil {
+		in, out := &in.Restricted, &out.Restricted
+		*out = make([]GrantPrivilege, len(*in))
+		copy(*out, *in)
+	}
+	if in.RevokeUnmanaged != nil {
+		in, out := &in.RevokeUnmanaged, &out.RevokeUnmanaged
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantParameters.
@@ -208,7 +281,7 @@ func (in *GrantStatus) DeepCopy() *GrantStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Keyspace) DeepCopyInto(out *Keyspace) {
+func (in *Index) DeepCopyInto(out *Index) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -216,18 +289,18 @@ func (in *Keyspace) DeepCopyInto(out *Keyspace) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keyspace.
-func (in *Keyspace) DeepCopy() *Keyspace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Index.
+func (in *Index) DeepCopy() *Index {
 	if in == nil {
 		return nil
 	}
-	out := new(Keyspace)
+	out := new(Index)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Keyspace) DeepCopyObject() runtime.Object {
+func (in *Index) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -235,31 +308,31 @@ func (in *Keyspace) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceList) DeepCopyInto(out *KeyspaceList) {
+func (in *IndexList) DeepCopyInto(out *IndexList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Keyspace, len(*in))
+		*out = make([]Index, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceList.
-func (in *KeyspaceList) DeepCopy() *KeyspaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexList.
+func (in *IndexList) DeepCopy() *IndexList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyspaceList)
+	out := new(IndexList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeyspaceList) DeepCopyObject() runtime.Object {
+func (in *IndexList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -267,86 +340,98 @@ func (in *KeyspaceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceObservation) DeepCopyInto(out *KeyspaceObservation) {
+func (in *IndexObservation) DeepCopyInto(out *IndexObservation) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceObservation.
-func (in *KeyspaceObservation) DeepCopy() *KeyspaceObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexObservation.
+func (in *IndexObservation) DeepCopy() *IndexObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyspaceObservation)
+	out := new(IndexObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceParameters) DeepCopyInto(out *KeyspaceParameters) {
+func (in *IndexParameters) DeepCopyInto(out *IndexParameters) {
 	*out = *in
-	if in.ReplicationClass != nil {
-		in, out := &in.ReplicationClass, &out.ReplicationClass
+	if in.Keyspace != nil {
+		in, out := &in.Keyspace, &out.Keyspace
 		*out = new(string)
 		**out = **in
 	}
-	if in.ReplicationFactor != nil {
-		in, out := &in.ReplicationFactor, &out.ReplicationFactor
-		*out = new(int)
-		**out = **in
+	if in.KeyspaceRef != nil {
+		in, out := &in.KeyspaceRef, &out.KeyspaceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.DurableWrites != nil {
-		in, out := &in.DurableWrites, &out.DurableWrites
-		*out = new(bool)
+	if in.KeyspaceSelector != nil {
+		in, out := &in.KeyspaceSelector, &out.KeyspaceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Using != nil {
+		in, out := &in.Using, &out.Using
+		*out = new(string)
 		**out = **in
 	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceParameters.
-func (in *KeyspaceParameters) DeepCopy() *KeyspaceParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexParameters.
+func (in *IndexParameters) DeepCopy() *IndexParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyspaceParameters)
+	out := new(IndexParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceSpec) DeepCopyInto(out *KeyspaceSpec) {
+func (in *IndexSpec) DeepCopyInto(out *IndexSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSpec.
-func (in *KeyspaceSpec) DeepCopy() *KeyspaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexSpec.
+func (in *IndexSpec) DeepCopy() *IndexSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyspaceSpec)
+	out := new(IndexSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceStatus) DeepCopyInto(out *KeyspaceStatus) {
+func (in *IndexStatus) DeepCopyInto(out *IndexStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	out.AtProvider = in.AtProvider
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceStatus.
-func (in *KeyspaceStatus) DeepCopy() *KeyspaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStatus.
+func (in *IndexStatus) DeepCopy() *IndexStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyspaceStatus)
+	out := new(IndexStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Role) DeepCopyInto(out *Role) {
+func (in *Keyspace) DeepCopyInto(out *Keyspace) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -354,18 +439,18 @@ func (in *Role) DeepCopyInto(out *Role) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
-func (in *Role) DeepCopy() *Role {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keyspace.
+func (in *Keyspace) DeepCopy() *Keyspace {
 	if in == nil {
 		return nil
 	}
-	out := new(Role)
+	out := new(Keyspace)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Role) DeepCopyObject() runtime.Object {
+func (in *Keyspace) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -373,31 +458,68 @@ func (in *Role) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleList) DeepCopyInto(out *RoleList) {
+func (in *KeyspaceInitParameters) DeepCopyInto(out *KeyspaceInitParameters) {
+	*out = *in
+	if in.ReplicationClass != nil {
+		in, out := &in.ReplicationClass, &out.ReplicationClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int)
+		**out = **in
+	}
+	if in.Datacenters != nil {
+		in, out := &in.Datacenters, &out.Datacenters
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DurableWrites != nil {
+		in, out := &in.DurableWrites, &out.DurableWrites
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceInitParameters.
+func (in *KeyspaceInitParameters) DeepCopy() *KeyspaceInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceList) DeepCopyInto(out *KeyspaceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Role, len(*in))
+		*out = make([]Keyspace, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
-func (in *RoleList) DeepCopy() *RoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceList.
+func (in *KeyspaceList) DeepCopy() *KeyspaceList {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleList)
+	out := new(KeyspaceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RoleList) DeepCopyObject() runtime.Object {
+func (in *KeyspaceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -405,91 +527,1198 @@ func (in *RoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleObservation) DeepCopyInto(out *RoleObservation) {
+func (in *KeyspaceObservation) DeepCopyInto(out *KeyspaceObservation) {
 	*out = *in
+	if in.Datacenters != nil {
+		in, out := &in.Datacenters, &out.Datacenters
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleObservation.
-func (in *RoleObservation) DeepCopy() *RoleObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceObservation.
+func (in *KeyspaceObservation) DeepCopy() *KeyspaceObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleObservation)
+	out := new(KeyspaceObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleParameters) DeepCopyInto(out *RoleParameters) {
+func (in *KeyspaceParameters) DeepCopyInto(out *KeyspaceParameters) {
 	*out = *in
-	in.Privileges.DeepCopyInto(&out.Privileges)
+	if in.ReplicationClass != nil {
+		in, out := &in.ReplicationClass, &out.ReplicationClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int)
+		**out = **in
+	}
+	if in.Datacenters != nil {
+		in, out := &in.Datacenters, &out.Datacenters
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PartialReplication != nil {
+		in, out := &in.PartialReplication, &out.PartialReplication
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DurableWrites != nil {
+		in, out := &in.DurableWrites, &out.DurableWrites
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GraphEngine != nil {
+		in, out := &in.GraphEngine, &out.GraphEngine
+		*out = new(string)
+		**out = **in
+	}
+	if in.RequireEmptyOnDelete != nil {
+		in, out := &in.RequireEmptyOnDelete, &out.RequireEmptyOnDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SkipDrop != nil {
+		in, out := &in.SkipDrop, &out.SkipDrop
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageSystemKeyspace != nil {
+		in, out := &in.ManageSystemKeyspace, &out.ManageSystemKeyspace
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleParameters.
-func (in *RoleParameters) DeepCopy() *RoleParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceParameters.
+func (in *KeyspaceParameters) DeepCopy() *KeyspaceParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleParameters)
+	out := new(KeyspaceParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RolePrivilege) DeepCopyInto(out *RolePrivilege) {
+func (in *KeyspaceSchemaExport) DeepCopyInto(out *KeyspaceSchemaExport) {
 	*out = *in
-	if in.SuperUser != nil {
-		in, out := &in.SuperUser, &out.SuperUser
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Login != nil {
-		in, out := &in.Login, &out.Login
-		*out = new(bool)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolePrivilege.
-func (in *RolePrivilege) DeepCopy() *RolePrivilege {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSchemaExport.
+func (in *KeyspaceSchemaExport) DeepCopy() *KeyspaceSchemaExport {
 	if in == nil {
 		return nil
 	}
-	out := new(RolePrivilege)
+	out := new(KeyspaceSchemaExport)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeyspaceSchemaExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+func (in *KeyspaceSchemaExportList) DeepCopyInto(out *KeyspaceSchemaExportList) {
 	*out = *in
-	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeyspaceSchemaExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
-func (in *RoleSpec) DeepCopy() *RoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSchemaExportList.
+func (in *KeyspaceSchemaExportList) DeepCopy() *KeyspaceSchemaExportList {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleSpec)
+	out := new(KeyspaceSchemaExportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeyspaceSchemaExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+func (in *KeyspaceSchemaExportObservation) DeepCopyInto(out *KeyspaceSchemaExportObservation) {
 	*out = *in
-	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	if in.LastExportTime != nil {
+		in, out := &in.LastExportTime, &out.LastExportTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
-func (in *RoleStatus) DeepCopy() *RoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSchemaExportObservation.
+func (in *KeyspaceSchemaExportObservation) DeepCopy() *KeyspaceSchemaExportObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleStatus)
+	out := new(KeyspaceSchemaExportObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceSchemaExportParameters) DeepCopyInto(out *KeyspaceSchemaExportParameters) {
+	*out = *in
+	if in.Keyspace != nil {
+		in, out := &in.Keyspace, &out.Keyspace
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyspaceRef != nil {
+		in, out := &in.KeyspaceRef, &out.KeyspaceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyspaceSelector != nil {
+		in, out := &in.KeyspaceSelector, &out.KeyspaceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Target = in.Target
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSchemaExportParameters.
+func (in *KeyspaceSchemaExportParameters) DeepCopy() *KeyspaceSchemaExportParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceSchemaExportParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceSchemaExportSpec) DeepCopyInto(out *KeyspaceSchemaExportSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSchemaExportSpec.
+func (in *KeyspaceSchemaExportSpec) DeepCopy() *KeyspaceSchemaExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceSchemaExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceSchemaExportStatus) DeepCopyInto(out *KeyspaceSchemaExportStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSchemaExportStatus.
+func (in *KeyspaceSchemaExportStatus) DeepCopy() *KeyspaceSchemaExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceSchemaExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceSpec) DeepCopyInto(out *KeyspaceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSpec.
+func (in *KeyspaceSpec) DeepCopy() *KeyspaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceStatus) DeepCopyInto(out *KeyspaceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceStatus.
+func (in *KeyspaceStatus) DeepCopy() *KeyspaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Node) DeepCopyInto(out *Node) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
+func (in *Node) DeepCopy() *Node {
+	if in == nil {
+		return nil
+	}
+	out := new(Node)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Node) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeList) DeepCopyInto(out *NodeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Node, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeList.
+func (in *NodeList) DeepCopy() *NodeList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeObservation) DeepCopyInto(out *NodeObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeObservation.
+func (in *NodeObservation) DeepCopy() *NodeObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeParameters) DeepCopyInto(out *NodeParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeParameters.
+func (in *NodeParameters) DeepCopy() *NodeParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.ForProvider = in.ForProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSpec.
+func (in *NodeSpec) DeepCopy() *NodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
+func (in *NodeStatus) DeepCopy() *NodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordGeneration) DeepCopyInto(out *PasswordGeneration) {
+	*out = *in
+	if in.Length != nil {
+		in, out := &in.Length, &out.Length
+		*out = new(int)
+		**out = **in
+	}
+	if in.Lowercase != nil {
+		in, out := &in.Lowercase, &out.Lowercase
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Uppercase != nil {
+		in, out := &in.Uppercase, &out.Uppercase
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Digits != nil {
+		in, out := &in.Digits, &out.Digits
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Symbols != nil {
+		in, out := &in.Symbols, &out.Symbols
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExcludeCharacters != nil {
+		in, out := &in.ExcludeCharacters, &out.ExcludeCharacters
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordGeneration.
+func (in *PasswordGeneration) DeepCopy() *PasswordGeneration {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordGeneration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Role) DeepCopyInto(out *Role) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
+func (in *Role) DeepCopy() *Role {
+	if in == nil {
+		return nil
+	}
+	out := new(Role)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Role) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleInitParameters) DeepCopyInto(out *RoleInitParameters) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.HashedPasswordSecretRef != nil {
+		in, out := &in.HashedPasswordSecretRef, &out.HashedPasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleInitParameters.
+func (in *RoleInitParameters) DeepCopy() *RoleInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleList) DeepCopyInto(out *RoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Role, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
+func (in *RoleList) DeepCopy() *RoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleObservation) DeepCopyInto(out *RoleObservation) {
+	*out = *in
+	if in.PasswordLastRotated != nil {
+		in, out := &in.PasswordLastRotated, &out.PasswordLastRotated
+		*out = (*in).DeepCopy()
+	}
+	if in.MemberOf != nil {
+		in, out := &in.MemberOf, &out.MemberOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]RolePermissions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleObservation.
+func (in *RoleObservation) DeepCopy() *RoleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleParameters) DeepCopyInto(out *RoleParameters) {
+	*out = *in
+	in.Privileges.DeepCopyInto(&out.Privileges)
+	if in.MemberOf != nil {
+		in, out := &in.MemberOf, &out.MemberOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemberOfRefs != nil {
+		in, out := &in.MemberOfRefs, &out.MemberOfRefs
+		*out = make([]v1.Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MemberOfSelector != nil {
+		in, out := &in.MemberOfSelector, &out.MemberOfSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.HashedPasswordSecretRef != nil {
+		in, out := &in.HashedPasswordSecretRef, &out.HashedPasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.Passwordless != nil {
+		in, out := &in.Passwordless, &out.Passwordless
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RotateAfter != nil {
+		in, out := &in.RotateAfter, &out.RotateAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PasswordGeneration != nil {
+		in, out := &in.PasswordGeneration, &out.PasswordGeneration
+		*out = new(PasswordGeneration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionSecretKeys != nil {
+		in, out := &in.ConnectionSecretKeys, &out.ConnectionSecretKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConnectionKeyspace != nil {
+		in, out := &in.ConnectionKeyspace, &out.ConnectionKeyspace
+		*out = new(string)
+		**out = **in
+	}
+	if in.SkipDrop != nil {
+		in, out := &in.SkipDrop, &out.SkipDrop
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DetectPasswordChanges != nil {
+		in, out := &in.DetectPasswordChanges, &out.DetectPasswordChanges
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleParameters.
+func (in *RoleParameters) DeepCopy() *RoleParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolePermissions) DeepCopyInto(out *RolePermissions) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolePermissions.
+func (in *RolePermissions) DeepCopy() *RolePermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(RolePermissions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolePrivilege) DeepCopyInto(out *RolePrivilege) {
+	*out = *in
+	if in.SuperUser != nil {
+		in, out := &in.SuperUser, &out.SuperUser
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolePrivilege.
+func (in *RolePrivilege) DeepCopy() *RolePrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(RolePrivilege)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
+func (in *RoleSpec) DeepCopy() *RoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
+func (in *RoleStatus) DeepCopy() *RoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaExportTarget) DeepCopyInto(out *SchemaExportTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaExportTarget.
+func (in *SchemaExportTarget) DeepCopy() *SchemaExportTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaExportTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedData) DeepCopyInto(out *SeedData) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedData.
+func (in *SeedData) DeepCopy() *SeedData {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SeedData) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedDataList) DeepCopyInto(out *SeedDataList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SeedData, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataList.
+func (in *SeedDataList) DeepCopy() *SeedDataList {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedDataList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SeedDataList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedDataObservation) DeepCopyInto(out *SeedDataObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataObservation.
+func (in *SeedDataObservation) DeepCopy() *SeedDataObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedDataObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedDataParameters) DeepCopyInto(out *SeedDataParameters) {
+	*out = *in
+	if in.Keyspace != nil {
+		in, out := &in.Keyspace, &out.Keyspace
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyspaceRef != nil {
+		in, out := &in.KeyspaceRef, &out.KeyspaceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyspaceSelector != nil {
+		in, out := &in.KeyspaceSelector, &out.KeyspaceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrimaryKey != nil {
+		in, out := &in.PrimaryKey, &out.PrimaryKey
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rows != nil {
+		in, out := &in.Rows, &out.Rows
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataParameters.
+func (in *SeedDataParameters) DeepCopy() *SeedDataParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedDataParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedDataSpec) DeepCopyInto(out *SeedDataSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataSpec.
+func (in *SeedDataSpec) DeepCopy() *SeedDataSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedDataSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedDataStatus) DeepCopyInto(out *SeedDataStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedDataStatus.
+func (in *SeedDataStatus) DeepCopy() *SeedDataStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedDataStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Table) DeepCopyInto(out *Table) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Table.
+func (in *Table) DeepCopy() *Table {
+	if in == nil {
+		return nil
+	}
+	out := new(Table)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Table) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableCDC) DeepCopyInto(out *TableCDC) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableCDC.
+func (in *TableCDC) DeepCopy() *TableCDC {
+	if in == nil {
+		return nil
+	}
+	out := new(TableCDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableCaching) DeepCopyInto(out *TableCaching) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = new(string)
+		**out = **in
+	}
+	if in.RowsPerPartition != nil {
+		in, out := &in.RowsPerPartition, &out.RowsPerPartition
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableCaching.
+func (in *TableCaching) DeepCopy() *TableCaching {
+	if in == nil {
+		return nil
+	}
+	out := new(TableCaching)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableColumn) DeepCopyInto(out *TableColumn) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableColumn.
+func (in *TableColumn) DeepCopy() *TableColumn {
+	if in == nil {
+		return nil
+	}
+	out := new(TableColumn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableCompaction) DeepCopyInto(out *TableCompaction) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableCompaction.
+func (in *TableCompaction) DeepCopy() *TableCompaction {
+	if in == nil {
+		return nil
+	}
+	out := new(TableCompaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableCompression) DeepCopyInto(out *TableCompression) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Class != nil {
+		in, out := &in.Class, &out.Class
+		*out = new(string)
+		**out = **in
+	}
+	if in.ChunkLengthInKB != nil {
+		in, out := &in.ChunkLengthInKB, &out.ChunkLengthInKB
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableCompression.
+func (in *TableCompression) DeepCopy() *TableCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(TableCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableList) DeepCopyInto(out *TableList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Table, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableList.
+func (in *TableList) DeepCopy() *TableList {
+	if in == nil {
+		return nil
+	}
+	out := new(TableList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TableList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableObservation) DeepCopyInto(out *TableObservation) {
+	*out = *in
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Caching != nil {
+		in, out := &in.Caching, &out.Caching
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CDC != nil {
+		in, out := &in.CDC, &out.CDC
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableObservation.
+func (in *TableObservation) DeepCopy() *TableObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TableObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableParameters) DeepCopyInto(out *TableParameters) {
+	*out = *in
+	if in.Keyspace != nil {
+		in, out := &in.Keyspace, &out.Keyspace
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyspaceRef != nil {
+		in, out := &in.KeyspaceRef, &out.KeyspaceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyspaceSelector != nil {
+		in, out := &in.KeyspaceSelector, &out.KeyspaceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]TableColumn, len(*in))
+		copy(*out, *in)
+	}
+	if in.PartitionKey != nil {
+		in, out := &in.PartitionKey, &out.PartitionKey
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusteringKey != nil {
+		in, out := &in.ClusteringKey, &out.ClusteringKey
+		*out = make([]ClusteringColumn, len(*in))
+		copy(*out, *in)
+	}
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(TableCompaction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(TableCompression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Caching != nil {
+		in, out := &in.Caching, &out.Caching
+		*out = new(TableCaching)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CDC != nil {
+		in, out := &in.CDC, &out.CDC
+		*out = new(TableCDC)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableParameters.
+func (in *TableParameters) DeepCopy() *TableParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TableParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableSpec) DeepCopyInto(out *TableSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableSpec.
+func (in *TableSpec) DeepCopy() *TableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TableStatus) DeepCopyInto(out *TableStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableStatus.
+func (in *TableStatus) DeepCopy() *TableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TableStatus)
 	in.DeepCopyInto(out)
 	return out
 }
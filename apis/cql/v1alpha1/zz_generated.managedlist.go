@@ -28,6 +28,15 @@ func (l *GrantList) GetItems() []resource.Managed {
 	return items
 }
 
+// GetItems of this IndexList.
+func (l *IndexList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this KeyspaceList.
 func (l *KeyspaceList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -37,6 +46,24 @@ func (l *KeyspaceList) GetItems() []resource.Managed {
 	return items
 }
 
+// GetItems of this KeyspaceSchemaExportList.
+func (l *KeyspaceSchemaExportList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this NodeList.
+func (l *NodeList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this RoleList.
 func (l *RoleList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -45,3 +72,21 @@ func (l *RoleList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this SeedDataList.
+func (l *SeedDataList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this TableList.
+func (l *TableList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -29,6 +29,7 @@ func (mg *Grant) ResolveReferences(ctx context.Context, c client.Reader) error {
 	r := reference.NewAPIResolver(c, mg)
 
 	var rsp reference.ResolutionResponse
+	var mrsp reference.MultiResolutionResponse
 	var err error
 
 	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
@@ -47,6 +48,168 @@ func (mg *Grant) ResolveReferences(ctx context.Context, c client.Reader) error {
 	mg.Spec.ForProvider.Role = reference.ToPtrValue(rsp.ResolvedValue)
 	mg.Spec.ForProvider.RoleRef = rsp.ResolvedReference
 
+	mrsp, err = r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.ForProvider.Roles,
+		Extract:       reference.ExternalName(),
+		References:    mg.Spec.ForProvider.RolesRefs,
+		Selector:      mg.Spec.ForProvider.RolesSelector,
+		To: reference.To{
+			List:    &RoleList{},
+			Managed: &Role{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Roles")
+	}
+	mg.Spec.ForProvider.Roles = mrsp.ResolvedValues
+	mg.Spec.ForProvider.RolesRefs = mrsp.ResolvedReferences
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Keyspace),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.KeyspaceRef,
+		Selector:     mg.Spec.ForProvider.KeyspaceSelector,
+		To: reference.To{
+			List:    &KeyspaceList{},
+			Managed: &Keyspace{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Keyspace")
+	}
+	mg.Spec.ForProvider.Keyspace = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.KeyspaceRef = rsp.ResolvedReference
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Table),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.TableRef,
+		Selector:     mg.Spec.ForProvider.TableSelector,
+		To: reference.To{
+			List:    &TableList{},
+			Managed: &Table{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Table")
+	}
+	mg.Spec.ForProvider.Table = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.TableRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Index.
+func (mg *Index) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var rsp reference.ResolutionResponse
+	var err error
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Keyspace),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.KeyspaceRef,
+		Selector:     mg.Spec.ForProvider.KeyspaceSelector,
+		To: reference.To{
+			List:    &KeyspaceList{},
+			Managed: &Keyspace{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Keyspace")
+	}
+	mg.Spec.ForProvider.Keyspace = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.KeyspaceRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this KeyspaceSchemaExport.
+func (mg *KeyspaceSchemaExport) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var rsp reference.ResolutionResponse
+	var err error
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Keyspace),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.KeyspaceRef,
+		Selector:     mg.Spec.ForProvider.KeyspaceSelector,
+		To: reference.To{
+			List:    &KeyspaceList{},
+			Managed: &Keyspace{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Keyspace")
+	}
+	mg.Spec.ForProvider.Keyspace = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.KeyspaceRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Role.
+func (mg *Role) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var mrsp reference.MultiResolutionResponse
+	var err error
+
+	mrsp, err = r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.ForProvider.MemberOf,
+		Extract:       reference.ExternalName(),
+		References:    mg.Spec.ForProvider.MemberOfRefs,
+		Selector:      mg.Spec.ForProvider.MemberOfSelector,
+		To: reference.To{
+			List:    &RoleList{},
+			Managed: &Role{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.MemberOf")
+	}
+	mg.Spec.ForProvider.MemberOf = mrsp.ResolvedValues
+	mg.Spec.ForProvider.MemberOfRefs = mrsp.ResolvedReferences
+
+	return nil
+}
+
+// ResolveReferences of this SeedData.
+func (mg *SeedData) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var rsp reference.ResolutionResponse
+	var err error
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Keyspace),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.KeyspaceRef,
+		Selector:     mg.Spec.ForProvider.KeyspaceSelector,
+		To: reference.To{
+			List:    &KeyspaceList{},
+			Managed: &Keyspace{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Keyspace")
+	}
+	mg.Spec.ForProvider.Keyspace = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.KeyspaceRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Table.
+func (mg *Table) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var rsp reference.ResolutionResponse
+	var err error
+
 	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
 		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Keyspace),
 		Extract:      reference.ExternalName(),
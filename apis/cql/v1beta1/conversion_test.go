@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+)
+
+const (
+	strategySimple          = "SimpleStrategy"
+	strategyNetworkTopology = "NetworkTopologyStrategy"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestKeyspaceRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		k      *Keyspace
+	}{
+		"NetworkTopology": {
+			reason: "A keyspace replicated per datacenter should survive conversion to v1alpha1 and back.",
+			k: &Keyspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec: KeyspaceSpec{
+					ForProvider: KeyspaceParameters{
+						Replication:   &Replication{Strategy: strategyNetworkTopology, Datacenters: map[string]int{"dc1": 3}, Partial: ptr(true)},
+						DurableWrites: ptr(true),
+						SkipDrop:      ptr(true),
+					},
+					InitProvider: KeyspaceInitParameters{
+						DurableWrites: ptr(false),
+					},
+				},
+				Status: KeyspaceStatus{
+					AtProvider: KeyspaceObservation{
+						Replication: ReplicationObservation{Strategy: strategyNetworkTopology, Datacenters: map[string]int{"dc1": 3, "dc2": 1}},
+						TableCount:  2,
+					},
+				},
+			},
+		},
+		"InitialReplication": {
+			reason: "A keyspace whose replication is only set when it is created should survive conversion to v1alpha1 and back.",
+			k: &Keyspace{
+				Spec: KeyspaceSpec{
+					InitProvider: KeyspaceInitParameters{
+						Replication: &InitReplication{Strategy: strategySimple, Factor: ptr(3)},
+					},
+				},
+			},
+		},
+		"InitialDatacenters": {
+			reason: "A keyspace created with the replication of its datacenters, whose replication is then only partially managed, should survive conversion to v1alpha1 and back.",
+			k: &Keyspace{
+				Spec: KeyspaceSpec{
+					ForProvider: KeyspaceParameters{
+						Replication: &Replication{Strategy: strategyNetworkTopology, Datacenters: map[string]int{"dc1": 3}, Partial: ptr(true)},
+					},
+					InitProvider: KeyspaceInitParameters{
+						Replication: &InitReplication{Strategy: strategyNetworkTopology, Datacenters: map[string]int{"dc1": 3, "dc2": 2}},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hub := &v1alpha1.Keyspace{}
+			if err := tc.k.ConvertTo(hub); err != nil {
+				t.Fatalf("\n%s\nConvertTo(...): unexpected error: %v", tc.reason, err)
+			}
+			got := &Keyspace{}
+			if err := got.ConvertFrom(hub); err != nil {
+				t.Fatalf("\n%s\nConvertFrom(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.k, got); diff != "" {
+				t.Errorf("\n%s\nConvertFrom(ConvertTo(...)): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestKeyspaceHubRoundTrip(t *testing.T) {
+	hub := &v1alpha1.Keyspace{
+		Spec: v1alpha1.KeyspaceSpec{
+			ForProvider: v1alpha1.KeyspaceParameters{ReplicationFactor: ptr(2)},
+		},
+	}
+	k := &Keyspace{}
+	if err := k.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom(...): unexpected error: %v", err)
+	}
+	got := &v1alpha1.Keyspace{}
+	if err := k.ConvertTo(got); err != nil {
+		t.Fatalf("ConvertTo(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(hub, got); diff != "" {
+		t.Errorf("ConvertTo(ConvertFrom(...)): an unset replication class should stay unset: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestRoleRoundTrip(t *testing.T) {
+	ref := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "pwd", Namespace: "default"}, Key: "password"}
+
+	cases := map[string]struct {
+		reason string
+		r      *Role
+	}{
+		"GeneratedPassword": {
+			reason: "A role with a generated, rotated password should survive conversion to v1alpha1 and back.",
+			r: &Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec: RoleSpec{
+					ForProvider: RoleParameters{
+						Privileges: RolePrivilege{Login: ptr(true)},
+						MemberOf:   []string{"readers"},
+						Password: &RolePassword{
+							RotateAfter: &metav1.Duration{},
+							Generation:  &PasswordGeneration{Length: ptr(32), Symbols: ptr(true)},
+						},
+					},
+				},
+				Status: RoleStatus{
+					AtProvider: RoleObservation{
+						Permissions: []RolePermissions{{Resource: "data/example", Permissions: []string{"SELECT"}}},
+					},
+				},
+			},
+		},
+		"InitialPassword": {
+			reason: "A role with an initial password should survive conversion to v1alpha1 and back.",
+			r: &Role{
+				Spec: RoleSpec{
+					InitProvider: RoleInitParameters{Password: &RoleInitPassword{SecretRef: ref}},
+				},
+			},
+		},
+		"ConnectionSecret": {
+			reason: "A role with custom connection secret keys and a connection keyspace should survive conversion to v1alpha1 and back.",
+			r: &Role{
+				Spec: RoleSpec{
+					ForProvider: RoleParameters{
+						ConnectionSecretKeys: map[string]string{"username": "CASSANDRA_USERNAME"},
+						ConnectionKeyspace:   ptr("example_keyspace"),
+					},
+				},
+			},
+		},
+		"NoPassword": {
+			reason: "A role without password settings should not be given any.",
+			r:      &Role{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hub := &v1alpha1.Role{}
+			if err := tc.r.ConvertTo(hub); err != nil {
+				t.Fatalf("\n%s\nConvertTo(...): unexpected error: %v", tc.reason, err)
+			}
+			got := &Role{}
+			if err := got.ConvertFrom(hub); err != nil {
+				t.Fatalf("\n%s\nConvertFrom(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.r, got); diff != "" {
+				t.Errorf("\n%s\nConvertFrom(ConvertTo(...)): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGrantRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		g      *Grant
+	}{
+		"Table": {
+			reason: "A grant on a table to several roles should survive conversion to v1alpha1 and back.",
+			g: &Grant{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec: GrantSpec{
+					ForProvider: GrantParameters{
+						Privileges:      []GrantPrivilege{"SELECT", "MODIFY"},
+						Role:            ptr("reader"),
+						Roles:           []string{"writer"},
+						RolesRefs:       []xpv1.Reference{{Name: "auditor"}},
+						Keyspace:        ptr("example_keyspace"),
+						Table:           ptr("example_table"),
+						Restricted:      []GrantPrivilege{"DROP"},
+						RevokeUnmanaged: ptr(true),
+					},
+				},
+				Status: GrantStatus{
+					AtProvider: GrantObservation{
+						Privileges:        []string{"MODIFY", "SELECT"},
+						ManagedPrivileges: []string{"MODIFY", "SELECT"},
+					},
+				},
+			},
+		},
+		"Keyspace": {
+			reason: "A grant on a referenced keyspace should survive conversion to v1alpha1 and back.",
+			g: &Grant{
+				Spec: GrantSpec{
+					ForProvider: GrantParameters{
+						Privileges:  []GrantPrivilege{"ALL_PERMISSIONS"},
+						RoleRef:     &xpv1.Reference{Name: "reader"},
+						KeyspaceRef: &xpv1.Reference{Name: "example"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hub := &v1alpha1.Grant{}
+			if err := tc.g.ConvertTo(hub); err != nil {
+				t.Fatalf("\n%s\nConvertTo(...): unexpected error: %v", tc.reason, err)
+			}
+			got := &Grant{}
+			if err := got.ConvertFrom(hub); err != nil {
+				t.Fatalf("\n%s\nConvertFrom(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.g, got); diff != "" {
+				t.Errorf("\n%s\nConvertFrom(ConvertTo(...)): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+)
+
+const errNotGrant = "hub is not a v1alpha1 Grant"
+
+// ConvertTo converts the Grant to v1alpha1.
+func (g *Grant) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.Grant)
+	if !ok {
+		return errors.New(errNotGrant)
+	}
+	dst.ObjectMeta = g.ObjectMeta
+	dst.Spec.ResourceSpec = g.Spec.ResourceSpec
+	dst.Status.ResourceStatus = g.Status.ResourceStatus
+
+	p := g.Spec.ForProvider
+	dst.Spec.ForProvider = v1alpha1.GrantParameters{
+		Role:             p.Role,
+		RoleRef:          p.RoleRef,
+		RoleSelector:     p.RoleSelector,
+		Roles:            p.Roles,
+		RolesRefs:        p.RolesRefs,
+		RolesSelector:    p.RolesSelector,
+		Keyspace:         p.Keyspace,
+		KeyspaceRef:      p.KeyspaceRef,
+		KeyspaceSelector: p.KeyspaceSelector,
+		Table:            p.Table,
+		TableRef:         p.TableRef,
+		TableSelector:    p.TableSelector,
+		RevokeUnmanaged:  p.RevokeUnmanaged,
+	}
+	for _, pr := range p.Privileges {
+		dst.Spec.ForProvider.Privileges = append(dst.Spec.ForProvider.Privileges, v1alpha1.GrantPrivilege(pr))
+	}
+	for _, pr := range p.Restricted {
+		dst.Spec.ForProvider.Restricted = append(dst.Spec.ForProvider.Restricted, v1alpha1.GrantPrivilege(pr))
+	}
+
+	dst.Status.AtProvider = v1alpha1.GrantObservation(g.Status.AtProvider)
+	return nil
+}
+
+// ConvertFrom converts a v1alpha1 Grant to this version.
+func (g *Grant) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.Grant)
+	if !ok {
+		return errors.New(errNotGrant)
+	}
+	g.ObjectMeta = src.ObjectMeta
+	g.Spec.ResourceSpec = src.Spec.ResourceSpec
+	g.Status.ResourceStatus = src.Status.ResourceStatus
+
+	p := src.Spec.ForProvider
+	g.Spec.ForProvider = GrantParameters{
+		Role:             p.Role,
+		RoleRef:          p.RoleRef,
+		RoleSelector:     p.RoleSelector,
+		Roles:            p.Roles,
+		RolesRefs:        p.RolesRefs,
+		RolesSelector:    p.RolesSelector,
+		Keyspace:         p.Keyspace,
+		KeyspaceRef:      p.KeyspaceRef,
+		KeyspaceSelector: p.KeyspaceSelector,
+		Table:            p.Table,
+		TableRef:         p.TableRef,
+		TableSelector:    p.TableSelector,
+		RevokeUnmanaged:  p.RevokeUnmanaged,
+	}
+	for _, pr := range p.Privileges {
+		g.Spec.ForProvider.Privileges = append(g.Spec.ForProvider.Privileges, GrantPrivilege(pr))
+	}
+	for _, pr := range p.Restricted {
+		g.Spec.ForProvider.Restricted = append(g.Spec.ForProvider.Restricted, GrantPrivilege(pr))
+	}
+
+	g.Status.AtProvider = GrantObservation(src.Status.AtProvider)
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// GrantPrivilege is a permission that is granted on a keyspace or table.
+// +kubebuilder:validation:Enum=ALL_PERMISSIONS;ALTER;AUTHORIZE;CREATE;DESCRIBE;DROP;EXECUTE;MODIFY;SELECT
+type GrantPrivilege string
+
+// GrantParameters are the configurable fields of a Grant.
+// +kubebuilder:validation:XValidation:rule="has(self.role) || has(self.roleRef) || has(self.roleSelector) || has(self.roles) || has(self.rolesRefs) || has(self.rolesSelector)",message="a grant needs at least one role"
+// +kubebuilder:validation:XValidation:rule="has(self.keyspace) || has(self.keyspaceRef) || has(self.keyspaceSelector)",message="a grant needs a keyspace"
+type GrantParameters struct {
+	// Privileges to be granted.
+	// +kubebuilder:validation:MinItems=1
+	Privileges []GrantPrivilege `json:"privileges"`
+
+	// Role this grant is for.
+	// +optional
+	// +crossplane:generate:reference:type=Role
+	Role *string `json:"role,omitempty"`
+
+	// RoleRef references the role object this grant is for.
+	// +immutable
+	// +optional
+	RoleRef *xpv1.Reference `json:"roleRef,omitempty"`
+
+	// RoleSelector selects a reference to a Role this grant is for.
+	// +immutable
+	// +optional
+	RoleSelector *xpv1.Selector `json:"roleSelector,omitempty"`
+
+	// Roles this grant is for, in addition to Role. The same privileges are
+	// granted to each of them.
+	// +optional
+	// +crossplane:generate:reference:type=Role
+	Roles []string `json:"roles,omitempty"`
+
+	// RolesRefs references the role objects this grant is for.
+	// +optional
+	RolesRefs []xpv1.Reference `json:"rolesRefs,omitempty"`
+
+	// RolesSelector selects references to Roles this grant is for.
+	// +optional
+	RolesSelector *xpv1.Selector `json:"rolesSelector,omitempty"`
+
+	// Keyspace this grant is for.
+	// +optional
+	// +crossplane:generate:reference:type=Keyspace
+	Keyspace *string `json:"keyspace,omitempty"`
+
+	// KeyspaceRef references the keyspace object this grant is for.
+	// +immutable
+	// +optional
+	KeyspaceRef *xpv1.Reference `json:"keyspaceRef,omitempty"`
+
+	// KeyspaceSelector selects a reference to a Keyspace this grant is for.
+	// +immutable
+	// +optional
+	KeyspaceSelector *xpv1.Selector `json:"keyspaceSelector,omitempty"`
+
+	// Table this grant is for. The privileges are granted on the table in
+	// Keyspace rather than on the whole keyspace when it is set.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/crossplane/provider-cassandra/apis/cql/v1alpha1.Table
+	Table *string `json:"table,omitempty"`
+
+	// TableRef references the table object this grant is for.
+	// +immutable
+	// +optional
+	TableRef *xpv1.Reference `json:"tableRef,omitempty"`
+
+	// TableSelector selects a reference to a Table this grant is for.
+	// +immutable
+	// +optional
+	TableSelector *xpv1.Selector `json:"tableSelector,omitempty"`
+
+	// Restricted lists privileges that are denied to the roles on the
+	// keyspace or table with RESTRICT, even when they are inherited from
+	// other roles. Restrictions that are not listed are lifted with
+	// UNRESTRICT. This requires DataStax Enterprise.
+	// +optional
+	Restricted []GrantPrivilege `json:"restricted,omitempty"`
+
+	// RevokeUnmanaged revokes every permission of the roles on the keyspace
+	// or table that is not listed in Privileges, including permissions
+	// granted outside of Crossplane.
+	// +optional
+	RevokeUnmanaged *bool `json:"revokeUnmanaged,omitempty"`
+}
+
+// GrantObservation are the observable fields of a Grant.
+type GrantObservation struct {
+	// Privileges are the permissions every role holds on the keyspace or
+	// table, as stored in system_auth.role_permissions.
+	Privileges []string `json:"privileges,omitempty"`
+
+	// Restricted are the permissions restricted for every role on the
+	// keyspace or table. They are only observed when restrictions are set.
+	Restricted []string `json:"restricted,omitempty"`
+
+	// ManagedPrivileges are the observed permissions that were granted by
+	// this Grant. They are revoked when they are removed from the spec.
+	ManagedPrivileges []string `json:"managedPrivileges,omitempty"`
+
+	// Roles are the roles this Grant gave permissions to. Roles that are
+	// removed from the spec have their permissions revoked.
+	Roles []string `json:"roles,omitempty"`
+}
+
+// A GrantSpec defines the desired state of a Grant.
+type GrantSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       GrantParameters `json:"forProvider"`
+}
+
+// A GrantStatus represents the observed state of a Grant.
+type GrantStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          GrantObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Grant grants privileges on a Cassandra keyspace or table to roles.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
+type Grant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrantSpec   `json:"spec"`
+	Status GrantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrantList contains a list of Grant
+type GrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Grant `json:"items"`
+}
+
+// Grant type metadata.
+var (
+	GrantKind             = reflect.TypeOf(Grant{}).Name()
+	GrantGroupKind        = schema.GroupKind{Group: Group, Kind: GrantKind}.String()
+	GrantKindAPIVersion   = GrantKind + "." + SchemeGroupVersion.String()
+	GrantGroupVersionKind = SchemeGroupVersion.WithKind(GrantKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Grant{}, &GrantList{})
+}
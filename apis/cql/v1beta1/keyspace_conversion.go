@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+)
+
+const errNotKeyspace = "hub is not a v1alpha1 Keyspace"
+
+// ConvertTo converts the Keyspace to v1alpha1.
+func (k *Keyspace) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.Keyspace)
+	if !ok {
+		return errors.New(errNotKeyspace)
+	}
+	dst.ObjectMeta = k.ObjectMeta
+	dst.Spec.ResourceSpec = k.Spec.ResourceSpec
+	dst.Status.ResourceStatus = k.Status.ResourceStatus
+
+	p := k.Spec.ForProvider
+	dst.Spec.ForProvider = v1alpha1.KeyspaceParameters{
+		DurableWrites:        p.DurableWrites,
+		GraphEngine:          p.GraphEngine,
+		RequireEmptyOnDelete: p.RequireEmptyOnDelete,
+		SkipDrop:             p.SkipDrop,
+		ManageSystemKeyspace: p.ManageSystemKeyspace,
+	}
+	if r := p.Replication; r != nil {
+		dst.Spec.ForProvider.ReplicationClass = strategy(r.Strategy)
+		dst.Spec.ForProvider.ReplicationFactor = r.Factor
+		dst.Spec.ForProvider.Datacenters = r.Datacenters
+		dst.Spec.ForProvider.PartialReplication = r.Partial
+	}
+
+	i := k.Spec.InitProvider
+	dst.Spec.InitProvider = v1alpha1.KeyspaceInitParameters{DurableWrites: i.DurableWrites}
+	if r := i.Replication; r != nil {
+		dst.Spec.InitProvider.ReplicationClass = strategy(r.Strategy)
+		dst.Spec.InitProvider.ReplicationFactor = r.Factor
+		dst.Spec.InitProvider.Datacenters = r.Datacenters
+	}
+
+	o := k.Status.AtProvider
+	dst.Status.AtProvider = v1alpha1.KeyspaceObservation{
+		ReplicationClass:  o.Replication.Strategy,
+		ReplicationFactor: o.Replication.Factor,
+		Datacenters:       o.Replication.Datacenters,
+		DurableWrites:     o.DurableWrites,
+		GraphEngine:       o.GraphEngine,
+		TableCount:        o.TableCount,
+	}
+	return nil
+}
+
+// ConvertFrom converts a v1alpha1 Keyspace to this version.
+func (k *Keyspace) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.Keyspace)
+	if !ok {
+		return errors.New(errNotKeyspace)
+	}
+	k.ObjectMeta = src.ObjectMeta
+	k.Spec.ResourceSpec = src.Spec.ResourceSpec
+	k.Status.ResourceStatus = src.Status.ResourceStatus
+
+	p := src.Spec.ForProvider
+	k.Spec.ForProvider = KeyspaceParameters{
+		Replication:          replication(p.ReplicationClass, p.ReplicationFactor, p.Datacenters, p.PartialReplication),
+		DurableWrites:        p.DurableWrites,
+		GraphEngine:          p.GraphEngine,
+		RequireEmptyOnDelete: p.RequireEmptyOnDelete,
+		SkipDrop:             p.SkipDrop,
+		ManageSystemKeyspace: p.ManageSystemKeyspace,
+	}
+
+	i := src.Spec.InitProvider
+	k.Spec.InitProvider = KeyspaceInitParameters{
+		Replication:   initReplication(i.ReplicationClass, i.ReplicationFactor, i.Datacenters),
+		DurableWrites: i.DurableWrites,
+	}
+
+	o := src.Status.AtProvider
+	k.Status.AtProvider = KeyspaceObservation{
+		Replication: ReplicationObservation{
+			Strategy:    o.ReplicationClass,
+			Factor:      o.ReplicationFactor,
+			Datacenters: o.Datacenters,
+		},
+		DurableWrites: o.DurableWrites,
+		GraphEngine:   o.GraphEngine,
+		TableCount:    o.TableCount,
+	}
+	return nil
+}
+
+// strategy returns the replication class of a strategy, which is unset when
+// the strategy is.
+func strategy(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// replication returns the structured replication of the flat replication
+// settings of v1alpha1, or nil when none is set. The strategy is left unset
+// when the replication class is, so that it is not set on the way back.
+func replication(class *string, factor *int, dcs map[string]int, partial *bool) *Replication {
+	if class == nil && factor == nil && len(dcs) == 0 && partial == nil {
+		return nil
+	}
+	r := &Replication{Factor: factor, Datacenters: dcs, Partial: partial}
+	if class != nil {
+		r.Strategy = *class
+	}
+	return r
+}
+
+// initReplication returns the structured replication a keyspace is created
+// with, or nil when none is set.
+func initReplication(class *string, factor *int, dcs map[string]int) *InitReplication {
+	r := replication(class, factor, dcs, nil)
+	if r == nil {
+		return nil
+	}
+	return &InitReplication{Strategy: r.Strategy, Factor: r.Factor, Datacenters: r.Datacenters}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Replication configures how the data of a keyspace is replicated.
+// +kubebuilder:validation:XValidation:rule="!has(self.datacenters) || (has(self.strategy) && self.strategy == 'NetworkTopologyStrategy')",message="SimpleStrategy takes a factor rather than datacenters"
+// +kubebuilder:validation:XValidation:rule="!has(self.strategy) || self.strategy != 'NetworkTopologyStrategy' || (has(self.datacenters) && !has(self.factor))",message="NetworkTopologyStrategy takes datacenters rather than a factor"
+// +kubebuilder:validation:XValidation:rule="!has(self.partial) || !self.partial || (has(self.strategy) && self.strategy == 'NetworkTopologyStrategy')",message="only the replication of NetworkTopologyStrategy can be partial"
+type Replication struct {
+	// Strategy places the replicas of the keyspace. Defaults to
+	// SimpleStrategy.
+	// +kubebuilder:validation:Enum=SimpleStrategy;NetworkTopologyStrategy
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// Factor is the number of replicas of a keyspace using SimpleStrategy.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Factor *int `json:"factor,omitempty"`
+
+	// Datacenters maps every datacenter of a keyspace using
+	// NetworkTopologyStrategy to its replication factor.
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:XValidation:rule="self.all(dc, self[dc] >= 1)",message="replication factor of every datacenter must be at least 1"
+	// +optional
+	Datacenters map[string]int `json:"datacenters,omitempty"`
+
+	// Partial only manages the datacenters listed in Datacenters. Replication
+	// of any other datacenter is left untouched, so that datacenters can be
+	// added and removed operationally.
+	// +optional
+	Partial *bool `json:"partial,omitempty"`
+}
+
+// InitReplication configures how the data of a keyspace is replicated when it
+// is created. Unlike Replication it cannot be partial, as a keyspace is
+// created with the replication of every datacenter it lists.
+// +kubebuilder:validation:XValidation:rule="!has(self.datacenters) || (has(self.strategy) && self.strategy == 'NetworkTopologyStrategy')",message="SimpleStrategy takes a factor rather than datacenters"
+// +kubebuilder:validation:XValidation:rule="!has(self.strategy) || self.strategy != 'NetworkTopologyStrategy' || (has(self.datacenters) && !has(self.factor))",message="NetworkTopologyStrategy takes datacenters rather than a factor"
+type InitReplication struct {
+	// Strategy places the replicas of the keyspace. Defaults to
+	// SimpleStrategy.
+	// +kubebuilder:validation:Enum=SimpleStrategy;NetworkTopologyStrategy
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// Factor is the number of replicas of a keyspace using SimpleStrategy.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Factor *int `json:"factor,omitempty"`
+
+	// Datacenters maps every datacenter of a keyspace using
+	// NetworkTopologyStrategy to its replication factor.
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:XValidation:rule="self.all(dc, self[dc] >= 1)",message="replication factor of every datacenter must be at least 1"
+	// +optional
+	Datacenters map[string]int `json:"datacenters,omitempty"`
+}
+
+// KeyspaceParameters are the configurable fields of a Keyspace.
+type KeyspaceParameters struct {
+	// Replication of the keyspace. It is required unless it is set in
+	// initProvider, or the keyspace is only observed.
+	// +optional
+	Replication *Replication `json:"replication,omitempty"`
+
+	// DurableWrites uses the commit log for updates of the keyspace.
+	// Defaults to true.
+	// +optional
+	DurableWrites *bool `json:"durableWrites,omitempty"`
+
+	// GraphEngine enables DSE Graph on the keyspace. It is ignored on
+	// clusters that are not running DataStax Enterprise.
+	// +kubebuilder:validation:Enum=Core;Classic
+	// +optional
+	GraphEngine *string `json:"graphEngine,omitempty"`
+
+	// RequireEmptyOnDelete refuses to drop the keyspace while it still
+	// contains tables.
+	// +optional
+	RequireEmptyOnDelete *bool `json:"requireEmptyOnDelete,omitempty"`
+
+	// SkipDrop releases the keyspace from management without dropping it
+	// when the Keyspace is deleted, in the same way as a deletionPolicy of
+	// Orphan. An event is emitted whenever a keyspace is left behind.
+	// +optional
+	SkipDrop *bool `json:"skipDrop,omitempty"`
+
+	// ManageSystemKeyspace must be set to manage the replication of a
+	// replicated system keyspace such as system_auth, system_distributed or
+	// system_traces. System keyspaces are never dropped.
+	// +optional
+	ManageSystemKeyspace *bool `json:"manageSystemKeyspace,omitempty"`
+}
+
+// KeyspaceInitParameters are the fields of a Keyspace that are only set when
+// the keyspace is created. Those that are not also set in forProvider are
+// excluded from drift detection, so that they can be changed operationally
+// once the keyspace exists.
+type KeyspaceInitParameters struct {
+	// Replication the keyspace is created with. It is ignored when the
+	// replication is set in forProvider.
+	// +optional
+	Replication *InitReplication `json:"replication,omitempty"`
+
+	// DurableWrites the keyspace is created with. It is ignored when it is
+	// set in forProvider.
+	// +optional
+	DurableWrites *bool `json:"durableWrites,omitempty"`
+}
+
+// ReplicationObservation is the observed replication of a keyspace.
+type ReplicationObservation struct {
+	// Strategy that places the replicas of the keyspace.
+	Strategy string `json:"strategy,omitempty"`
+
+	// Factor of a keyspace using SimpleStrategy.
+	Factor int `json:"factor,omitempty"`
+
+	// Datacenters maps every datacenter of a keyspace using
+	// NetworkTopologyStrategy to its replication factor.
+	Datacenters map[string]int `json:"datacenters,omitempty"`
+}
+
+// KeyspaceObservation are the observable fields of a Keyspace.
+type KeyspaceObservation struct {
+	// Replication of the keyspace.
+	Replication ReplicationObservation `json:"replication,omitempty"`
+
+	// DurableWrites is true when the commit log is used for the keyspace.
+	DurableWrites bool `json:"durableWrites,omitempty"`
+
+	// GraphEngine of a DSE Graph keyspace.
+	GraphEngine string `json:"graphEngine,omitempty"`
+
+	// TableCount is the number of tables in the keyspace.
+	TableCount int `json:"tableCount,omitempty"`
+}
+
+// A KeyspaceSpec defines the desired state of a Keyspace.
+// +kubebuilder:validation:XValidation:rule="(has(self.managementPolicies) && !('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies)) || has(self.forProvider.replication) || (has(self.initProvider) && has(self.initProvider.replication))",message="spec.forProvider.replication is required"
+type KeyspaceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       KeyspaceParameters `json:"forProvider"`
+
+	// InitProvider holds the fields that are only set when the keyspace is
+	// created.
+	// +optional
+	InitProvider KeyspaceInitParameters `json:"initProvider,omitempty"`
+}
+
+// A KeyspaceStatus represents the observed state of a Keyspace.
+type KeyspaceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KeyspaceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Keyspace is a Cassandra keyspace.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="REPLICATION",type="string",JSONPath=".status.atProvider.replication.strategy",priority=1
+// +kubebuilder:printcolumn:name="TABLES",type="integer",JSONPath=".status.atProvider.tableCount",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
+type Keyspace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeyspaceSpec   `json:"spec"`
+	Status KeyspaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeyspaceList contains a list of Keyspace
+type KeyspaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Keyspace `json:"items"`
+}
+
+// Keyspace type metadata.
+var (
+	KeyspaceKind             = reflect.TypeOf(Keyspace{}).Name()
+	KeyspaceGroupKind        = schema.GroupKind{Group: Group, Kind: KeyspaceKind}.String()
+	KeyspaceKindAPIVersion   = KeyspaceKind + "." + SchemeGroupVersion.String()
+	KeyspaceGroupVersionKind = SchemeGroupVersion.WithKind(KeyspaceKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Keyspace{}, &KeyspaceList{})
+}
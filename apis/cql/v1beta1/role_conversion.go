@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+)
+
+const errNotRole = "hub is not a v1alpha1 Role"
+
+// ConvertTo converts the Role to v1alpha1.
+func (r *Role) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1alpha1.Role)
+	if !ok {
+		return errors.New(errNotRole)
+	}
+	dst.ObjectMeta = r.ObjectMeta
+	dst.Spec.ResourceSpec = r.Spec.ResourceSpec
+	dst.Status.ResourceStatus = r.Status.ResourceStatus
+
+	p := r.Spec.ForProvider
+	dst.Spec.ForProvider = v1alpha1.RoleParameters{
+		Privileges:           v1alpha1.RolePrivilege(p.Privileges),
+		MemberOf:             p.MemberOf,
+		MemberOfRefs:         p.MemberOfRefs,
+		MemberOfSelector:     p.MemberOfSelector,
+		Options:              p.Options,
+		ConnectionSecretKeys: p.ConnectionSecretKeys,
+		ConnectionKeyspace:   p.ConnectionKeyspace,
+		SkipDrop:             p.SkipDrop,
+	}
+	if pw := p.Password; pw != nil {
+		dst.Spec.ForProvider.PasswordSecretRef = pw.SecretRef
+		dst.Spec.ForProvider.HashedPasswordSecretRef = pw.HashedSecretRef
+		dst.Spec.ForProvider.Passwordless = pw.Disabled
+		dst.Spec.ForProvider.RotateAfter = pw.RotateAfter
+		dst.Spec.ForProvider.PasswordGeneration = (*v1alpha1.PasswordGeneration)(pw.Generation)
+		dst.Spec.ForProvider.DetectPasswordChanges = pw.DetectChanges
+	}
+
+	dst.Spec.InitProvider = v1alpha1.RoleInitParameters{}
+	if pw := r.Spec.InitProvider.Password; pw != nil {
+		dst.Spec.InitProvider.PasswordSecretRef = pw.SecretRef
+		dst.Spec.InitProvider.HashedPasswordSecretRef = pw.HashedSecretRef
+	}
+
+	o := r.Status.AtProvider
+	dst.Status.AtProvider = v1alpha1.RoleObservation{
+		PasswordLastRotated: o.PasswordLastRotated,
+		MemberOf:            o.MemberOf,
+	}
+	for _, p := range o.Permissions {
+		dst.Status.AtProvider.Permissions = append(dst.Status.AtProvider.Permissions, v1alpha1.RolePermissions(p))
+	}
+	return nil
+}
+
+// ConvertFrom converts a v1alpha1 Role to this version.
+func (r *Role) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.Role)
+	if !ok {
+		return errors.New(errNotRole)
+	}
+	r.ObjectMeta = src.ObjectMeta
+	r.Spec.ResourceSpec = src.Spec.ResourceSpec
+	r.Status.ResourceStatus = src.Status.ResourceStatus
+
+	p := src.Spec.ForProvider
+	r.Spec.ForProvider = RoleParameters{
+		Privileges:           RolePrivilege(p.Privileges),
+		MemberOf:             p.MemberOf,
+		MemberOfRefs:         p.MemberOfRefs,
+		MemberOfSelector:     p.MemberOfSelector,
+		Options:              p.Options,
+		ConnectionSecretKeys: p.ConnectionSecretKeys,
+		ConnectionKeyspace:   p.ConnectionKeyspace,
+		SkipDrop:             p.SkipDrop,
+	}
+	pw := RolePassword{
+		SecretRef:       p.PasswordSecretRef,
+		HashedSecretRef: p.HashedPasswordSecretRef,
+		Disabled:        p.Passwordless,
+		RotateAfter:     p.RotateAfter,
+		Generation:      (*PasswordGeneration)(p.PasswordGeneration),
+		DetectChanges:   p.DetectPasswordChanges,
+	}
+	if pw != (RolePassword{}) {
+		r.Spec.ForProvider.Password = &pw
+	}
+
+	r.Spec.InitProvider = RoleInitParameters{}
+	if i := src.Spec.InitProvider; i.PasswordSecretRef != nil || i.HashedPasswordSecretRef != nil {
+		r.Spec.InitProvider.Password = &RoleInitPassword{SecretRef: i.PasswordSecretRef, HashedSecretRef: i.HashedPasswordSecretRef}
+	}
+
+	o := src.Status.AtProvider
+	r.Status.AtProvider = RoleObservation{
+		PasswordLastRotated: o.PasswordLastRotated,
+		MemberOf:            o.MemberOf,
+	}
+	for _, p := range o.Permissions {
+		r.Status.AtProvider.Permissions = append(r.Status.AtProvider.Permissions, RolePermissions(p))
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RolePrivilege is the Cassandra identifier to add or remove a permission
+// on a role.
+type RolePrivilege struct {
+	// SuperUser grants SUPERUSER privilege when true.
+	// +optional
+	SuperUser *bool `json:"superUser,omitempty"`
+
+	// Login grants LOGIN when true, allowing the role to login to the server.
+	// +optional
+	Login *bool `json:"login,omitempty"`
+}
+
+// PasswordGeneration configures how the password of a role is generated.
+type PasswordGeneration struct {
+	// Length of the generated password.
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=128
+	// +kubebuilder:default=27
+	// +optional
+	Length *int `json:"length,omitempty"`
+
+	// Lowercase includes lowercase letters.
+	// +kubebuilder:default=true
+	// +optional
+	Lowercase *bool `json:"lowercase,omitempty"`
+
+	// Uppercase includes uppercase letters.
+	// +kubebuilder:default=true
+	// +optional
+	Uppercase *bool `json:"uppercase,omitempty"`
+
+	// Digits includes digits.
+	// +kubebuilder:default=true
+	// +optional
+	Digits *bool `json:"digits,omitempty"`
+
+	// Symbols includes punctuation characters other than quotes, backslashes
+	// and backticks.
+	// +optional
+	Symbols *bool `json:"symbols,omitempty"`
+
+	// ExcludeCharacters lists characters that must not appear in the
+	// generated password.
+	// +optional
+	ExcludeCharacters *string `json:"excludeCharacters,omitempty"`
+}
+
+// RolePassword configures how the password of a role is set. A password is
+// generated and published to the connection secret when no secret is
+// referenced.
+// +kubebuilder:validation:XValidation:rule="!(has(self.secretRef) && has(self.hashedSecretRef))",message="secretRef and hashedSecretRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.disabled) && self.disabled && (has(self.secretRef) || has(self.hashedSecretRef)))",message="roles without a password cannot reference one"
+type RolePassword struct {
+	// SecretRef references the key of a Secret holding the password of the
+	// role. Changes of the password are detected by comparing it with the
+	// password in the connection secret.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// HashedSecretRef references the key of a Secret holding the bcrypt
+	// hash of the password of the role, which is set with HASHED PASSWORD.
+	// This requires Cassandra 5 or DataStax Enterprise. The password is not
+	// published to the connection secret.
+	// +optional
+	HashedSecretRef *xpv1.SecretKeySelector `json:"hashedSecretRef,omitempty"`
+
+	// Disabled creates the role without a password, for roles that
+	// authenticate with an external authenticator such as LDAP or Kerberos.
+	// No password is generated or published to the connection secret.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+
+	// RotateAfter is the age after which a generated password is replaced
+	// with a new one and republished to the connection secret.
+	// +optional
+	RotateAfter *metav1.Duration `json:"rotateAfter,omitempty"`
+
+	// Generation configures how passwords are generated.
+	// +optional
+	Generation *PasswordGeneration `json:"generation,omitempty"`
+
+	// DetectChanges compares the salted hash of the role stored in
+	// system_auth.roles with the password last set by the provider, so that
+	// passwords changed outside of Crossplane are restored.
+	// +optional
+	DetectChanges *bool `json:"detectChanges,omitempty"`
+}
+
+// RoleParameters are the configurable fields of a Role.
+type RoleParameters struct {
+	// Privileges to be granted.
+	// +optional
+	Privileges RolePrivilege `json:"privileges,omitempty"`
+
+	// MemberOf lists the roles this role is granted. Memberships that are
+	// not listed are revoked. Memberships are not managed when it is not set.
+	// +optional
+	// +crossplane:generate:reference:type=Role
+	MemberOf []string `json:"memberOf,omitempty"`
+
+	// MemberOfRefs references the role objects this role is granted.
+	// +optional
+	MemberOfRefs []xpv1.Reference `json:"memberOfRefs,omitempty"`
+
+	// MemberOfSelector selects references to Roles this role is granted.
+	// +optional
+	MemberOfSelector *xpv1.Selector `json:"memberOfSelector,omitempty"`
+
+	// Password of the role.
+	// +optional
+	Password *RolePassword `json:"password,omitempty"`
+
+	// Options are custom options passed to the role manager with WITH
+	// OPTIONS, for example by DataStax Enterprise or ScyllaDB.
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+
+	// ConnectionSecretKeys maps the keys of the connection secret, such as
+	// username, password, endpoint or port, to the keys they are published
+	// under, for example SPRING_CASSANDRA_USERNAME. Keys that are not listed
+	// are published unchanged.
+	// +optional
+	ConnectionSecretKeys map[string]string `json:"connectionSecretKeys,omitempty"`
+
+	// ConnectionKeyspace is the keyspace named by the connection string
+	// published to the connection secret under connectionString. The
+	// connection string names no keyspace when it is not set.
+	// +optional
+	ConnectionKeyspace *string `json:"connectionKeyspace,omitempty"`
+
+	// SkipDrop releases the role from management without dropping it when
+	// the Role is deleted, in the same way as a deletionPolicy of Orphan. An
+	// event is emitted whenever a role is left behind.
+	// +optional
+	SkipDrop *bool `json:"skipDrop,omitempty"`
+}
+
+// RoleInitPassword is the password a role is created with.
+// +kubebuilder:validation:XValidation:rule="!(has(self.secretRef) && has(self.hashedSecretRef))",message="secretRef and hashedSecretRef are mutually exclusive"
+type RoleInitPassword struct {
+	// SecretRef references the key of a Secret holding the initial password
+	// of the role.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// HashedSecretRef references the key of a Secret holding the bcrypt
+	// hash of the initial password of the role.
+	// +optional
+	HashedSecretRef *xpv1.SecretKeySelector `json:"hashedSecretRef,omitempty"`
+}
+
+// RoleInitParameters are the fields of a Role that are only set when the role
+// is created.
+type RoleInitParameters struct {
+	// Password the role is created with. It is ignored when a password
+	// secret is referenced in forProvider, or the password is disabled.
+	// Later changes of the password are not reconciled, and it is never
+	// rotated.
+	// +optional
+	Password *RoleInitPassword `json:"password,omitempty"`
+}
+
+// RolePermissions are the permissions of a role on a resource.
+type RolePermissions struct {
+	// Resource the permissions apply to, for example data/my_keyspace or
+	// roles/my_role.
+	Resource string `json:"resource"`
+
+	// Permissions granted on the resource.
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// RoleObservation are the observable fields of a Role.
+type RoleObservation struct {
+	// PasswordLastRotated is the time the password of the role was last set
+	// by the provider.
+	PasswordLastRotated *metav1.Time `json:"passwordLastRotated,omitempty"`
+
+	// MemberOf lists the roles granted to the role.
+	MemberOf []string `json:"memberOf,omitempty"`
+
+	// Permissions lists the permissions granted directly to the role. Those
+	// inherited from the roles it is a member of are not included.
+	Permissions []RolePermissions `json:"permissions,omitempty"`
+}
+
+// A RoleSpec defines the desired state of a Role.
+type RoleSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RoleParameters `json:"forProvider"`
+
+	// InitProvider holds the fields that are only set when the role is
+	// created.
+	// +optional
+	InitProvider RoleInitParameters `json:"initProvider,omitempty"`
+}
+
+// A RoleStatus represents the observed state of a Role.
+type RoleStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RoleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Role is a Cassandra role.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cassandra}
+type Role struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleSpec   `json:"spec"`
+	Status RoleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RoleList contains a list of Role
+type RoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Role `json:"items"`
+}
+
+// Role type metadata.
+var (
+	RoleKind             = reflect.TypeOf(Role{}).Name()
+	RoleGroupKind        = schema.GroupKind{Group: Group, Kind: RoleKind}.String()
+	RoleKindAPIVersion   = RoleKind + "." + SchemeGroupVersion.String()
+	RoleGroupVersionKind = SchemeGroupVersion.WithKind(RoleKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Role{}, &RoleList{})
+}
@@ -19,36 +19,766 @@ package v1alpha1
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// A Dialect is the flavour of CQL spoken by a cluster.
+type Dialect string
+
+// Dialects of CQL.
+const (
+	// DialectCassandra is spoken by Apache Cassandra and compatible
+	// databases such as Scylla and DataStax Enterprise.
+	DialectCassandra Dialect = "Cassandra"
+
+	// DialectAmazonKeyspaces is spoken by Amazon Keyspaces, which has no
+	// system_auth keyspace, no secondary indexes, and creates tables
+	// asynchronously.
+	DialectAmazonKeyspaces Dialect = "AmazonKeyspaces"
+)
+
+// A ConflictPolicy is what a managed resource does when the object it is for
+// already exists without having been created by it.
+type ConflictPolicy string
+
+// Policies for objects that already exist.
+const (
+	// ConflictPolicyAdopt adopts the existing object as it is.
+	ConflictPolicyAdopt ConflictPolicy = "Adopt"
+
+	// ConflictPolicyFail fails to create or observe the existing object,
+	// unless the managed resource is annotated to adopt it.
+	ConflictPolicyFail ConflictPolicy = "Fail"
+)
+
+// An IdentifierCase is how the names of keyspaces, tables and indexes are
+// cased before they are quoted.
+type IdentifierCase string
+
+// Casings of identifiers.
+const (
+	// IdentifierCasePreserve uses names as they are written, so that
+	// MyKeyspace and mykeyspace name different keyspaces.
+	IdentifierCasePreserve IdentifierCase = "Preserve"
+
+	// IdentifierCaseLower lowercases names, as Cassandra does with the
+	// identifiers it is sent unquoted, so that MyKeyspace names the keyspace
+	// created by CREATE KEYSPACE MyKeyspace.
+	IdentifierCaseLower IdentifierCase = "Lower"
+)
+
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
-	// Credentials required to authenticate to this provider.
+	// Credentials required to authenticate to this provider. The referenced
+	// secret key holds a JSON document of the username and password. When it
+	// does not, the secret must instead hold username and password keys, as
+	// produced by cass-operator and K8ssandra. The credentials may also hold
+	// the endpoint (or host) and port of the cluster, which is deprecated in
+	// favor of ContactPoints and Port.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// Dialect of CQL spoken by the cluster. AmazonKeyspaces rejects Roles,
+	// Grants and Indexes, which Amazon Keyspaces does not support, waits for
+	// tables to become active, and defaults Consistency to LOCAL_QUORUM.
+	// +kubebuilder:validation:Enum=Cassandra;AmazonKeyspaces
+	// +kubebuilder:default=Cassandra
+	// +optional
+	Dialect Dialect `json:"dialect,omitempty"`
+
+	// ContactPoints are the hosts the provider initially connects to, which
+	// it discovers the rest of the cluster from. They take precedence over
+	// the endpoint in the credentials.
+	// +optional
+	ContactPoints []string `json:"contactPoints,omitempty"`
+
+	// ServiceRef references a Kubernetes Service whose ready endpoints are
+	// used as contact points when ContactPoints are not set. The endpoints
+	// are resolved each time the provider connects.
+	// +optional
+	ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
+
+	// SRVRecord is the name of a DNS SRV record, for example
+	// _cql._tcp.cassandra.example.org, whose targets are used as contact
+	// points when neither ContactPoints nor ServiceRef are set. The record
+	// is resolved each time the provider connects.
+	// +optional
+	SRVRecord *string `json:"srvRecord,omitempty"`
+
+	// Port of the native transport of the contact points. Defaults to 9042
+	// when ContactPoints are set.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int `json:"port,omitempty"`
+
+	// AddressTranslation translates the addresses nodes broadcast to the
+	// addresses the provider dials, for clusters behind NAT, VPN gateways or
+	// kubectl port-forward.
+	// +optional
+	AddressTranslation *AddressTranslation `json:"addressTranslation,omitempty"`
+
+	// LocalDatacenter is the datacenter the provider runs in. Queries are
+	// routed to the nodes of the local datacenter, and only to nodes of
+	// remote datacenters when none of them are available.
+	// +optional
+	LocalDatacenter *string `json:"localDatacenter,omitempty"`
+
+	// TLS configures connections to the cluster to use TLS.
+	// +optional
+	TLS *TLS `json:"tls,omitempty"`
+
+	// Tracing enables CQL tracing of the statements and queries of the
+	// provider, whose trace IDs are logged so that the sessions recorded in
+	// the system_traces keyspace of the cluster can be found. Tracing adds
+	// load to the cluster, so it is best enabled only while debugging.
+	// +optional
+	Tracing bool `json:"tracing,omitempty"`
+
+	// Scylla configures connections to ScyllaDB clusters.
+	// +optional
+	Scylla *Scylla `json:"scylla,omitempty"`
+
+	// Compression compresses the frames exchanged with the cluster, which
+	// reduces the traffic between the provider and the cluster at the cost
+	// of some CPU. Frames are not compressed by default.
+	// +kubebuilder:validation:Enum=LZ4;Snappy
+	// +optional
+	Compression *string `json:"compression,omitempty"`
+
+	// ProtocolVersion pins the version of the native protocol used to talk to
+	// the cluster. It is negotiated with the cluster when it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +optional
+	ProtocolVersion *int `json:"protocolVersion,omitempty"`
+
+	// CQLVersion is the version of CQL requested from the cluster, for
+	// example 3.0.0. Defaults to the version requested by the driver.
+	// +kubebuilder:validation:Pattern=`^[0-9]+\.[0-9]+\.[0-9]+$`
+	// +optional
+	CQLVersion *string `json:"cqlVersion,omitempty"`
+
+	// ConnectTimeout is how long to wait for a connection to a node to be
+	// established. Defaults to 11s.
+	// +optional
+	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"`
+
+	// RequestTimeout is how long to wait for a node to respond to a query or
+	// statement. Defaults to 11s.
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+
+	// OperationTimeout is how long a statement or query may take in all,
+	// including its retries, speculative executions and the pages of its
+	// results, so that an unresponsive node cannot stall a reconcile.
+	// Defaults to 30s.
+	// +optional
+	OperationTimeout *metav1.Duration `json:"operationTimeout,omitempty"`
+
+	// AsynchronousSchemaChanges returns from creating keyspaces, tables and
+	// indexes without waiting for the nodes of the cluster to agree on the
+	// schema, which may take long on large clusters. Their Ready condition is
+	// instead gated on observing that they exist and that the schema has
+	// settled. Defaults to true for the AmazonKeyspaces dialect.
+	// +optional
+	AsynchronousSchemaChanges *bool `json:"asynchronousSchemaChanges,omitempty"`
+
+	// ConflictPolicy is what the Keyspaces, Tables, Indexes and Roles of the
+	// ProviderConfig do when their object already exists without having been
+	// created by them. Adopt adopts it as it is. Fail creates objects without
+	// IF NOT EXISTS, and fails on objects that already exist unless their
+	// managed resource is annotated with cassandra.crossplane.io/adopt set to
+	// true.
+	// +kubebuilder:validation:Enum=Adopt;Fail
+	// +kubebuilder:default=Adopt
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// IdentifierCase is how the names of the keyspaces, tables and indexes of
+	// the ProviderConfig, and the keyspaces and tables they refer to, are
+	// cased. Preserve uses them as they are written. Lower lowercases them,
+	// as Cassandra does with unquoted identifiers, so that objects created
+	// from CQL without quotes are found. Column and role names are always
+	// used as they are written.
+	// +kubebuilder:validation:Enum=Preserve;Lower
+	// +kubebuilder:default=Preserve
+	// +optional
+	IdentifierCase IdentifierCase `json:"identifierCase,omitempty"`
+
+	// PageSize is how many rows the queries used to observe resources fetch
+	// at a time. Smaller pages make each request cheaper for the cluster,
+	// at the cost of more round trips. Defaults to 5000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PageSize *int `json:"pageSize,omitempty"`
+
+	// Keepalive is the TCP keepalive period of the connections to nodes.
+	// Keepalives are not sent when it is not set.
+	// +optional
+	Keepalive *metav1.Duration `json:"keepalive,omitempty"`
+
+	// Reconnection configures how the provider reconnects to nodes that are
+	// down, for example during rolling restarts.
+	// +optional
+	Reconnection *Reconnection `json:"reconnection,omitempty"`
+
+	// Retry configures how the queries used to observe resources are retried
+	// when they fail, for example because they timed out. Statements are not
+	// retried, as one that failed may still have been applied. Queries are
+	// not retried when it is not set.
+	// +optional
+	Retry *Retry `json:"retry,omitempty"`
+
+	// SpeculativeExecution sends the queries used to observe resources to
+	// further nodes when the first does not respond in time, so that a slow
+	// node does not stall reconciles. Queries are sent to one node at a time
+	// when it is not set.
+	// +optional
+	SpeculativeExecution *SpeculativeExecution `json:"speculativeExecution,omitempty"`
+
+	// CircuitBreaker stops the managed resources of the ProviderConfig from
+	// sending statements and queries to the cluster for a while once too
+	// many of them failed in a row because the cluster could not be reached
+	// or could not serve them. Their Reachable condition is false meanwhile.
+	// +optional
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+
+	// RateLimit caps how many statements and queries per second the managed
+	// resources of the ProviderConfig send to the cluster, so that applying
+	// many of them at once cannot overload a small cluster. Statements and
+	// queries wait for their turn. They are not limited when it is not set.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// Consistency is the consistency level of the statements that change
+	// the cluster. Defaults to ALL.
+	// +kubebuilder:validation:Enum=ONE;TWO;THREE;QUORUM;ALL;LOCAL_QUORUM;EACH_QUORUM;LOCAL_ONE
+	// +optional
+	Consistency *string `json:"consistency,omitempty"`
+
+	// SerialConsistency is the consistency level of the paxos phase of
+	// lightweight transactions. Defaults to SERIAL.
+	// +kubebuilder:validation:Enum=SERIAL;LOCAL_SERIAL
+	// +optional
+	SerialConsistency *string `json:"serialConsistency,omitempty"`
+
+	// ReadConsistency is the consistency level of the queries used to
+	// observe resources, for example in system_auth and system_schema.
+	// Defaults to LOCAL_QUORUM.
+	// +kubebuilder:validation:Enum=ONE;TWO;THREE;QUORUM;ALL;LOCAL_QUORUM;EACH_QUORUM;LOCAL_ONE
+	// +optional
+	ReadConsistency *string `json:"readConsistency,omitempty"`
+
+	// ProtectedRoles lists roles that are never dropped when a Role is
+	// deleted. The role the provider authenticates as is always protected.
+	// +kubebuilder:default={"cassandra"}
+	// +optional
+	ProtectedRoles []string `json:"protectedRoles,omitempty"`
+}
+
+// TLS configures TLS connections to the cluster.
+// +kubebuilder:validation:XValidation:rule="has(self.clientCertSecretRef) == has(self.clientKeySecretRef)",message="clientCertSecretRef and clientKeySecretRef must be set together"
+type TLS struct {
+	// Enabled connects to the cluster over TLS.
+	Enabled bool `json:"enabled"`
+
+	// CASecretRef references a secret key holding the PEM encoded bundle of
+	// certificate authorities that signed the certificates of the cluster.
+	// The system certificate authorities are trusted when it is not set.
+	// +optional
+	CASecretRef *xpv1.SecretKeySelector `json:"caSecretRef,omitempty"`
+
+	// ClientCertSecretRef references a secret key holding the PEM encoded
+	// client certificate, for clusters that require client authentication.
+	// +optional
+	ClientCertSecretRef *xpv1.SecretKeySelector `json:"clientCertSecretRef,omitempty"`
+
+	// ClientKeySecretRef references a secret key holding the PEM encoded
+	// private key of the client certificate.
+	// +optional
+	ClientKeySecretRef *xpv1.SecretKeySelector `json:"clientKeySecretRef,omitempty"`
+
+	// InsecureSkipVerify disables verification of the certificates presented
+	// by the cluster. It should only be used against lab clusters.
+	// +optional
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+
+	// DisableHostnameVerification verifies that the certificates presented by
+	// the cluster are signed by a trusted certificate authority, but not that
+	// they were issued for the host connected to. This allows connecting to
+	// nodes by IP address when their certificates only name their hosts.
+	// +optional
+	DisableHostnameVerification *bool `json:"disableHostnameVerification,omitempty"`
 }
 
+// Scylla configures connections to ScyllaDB clusters.
+type Scylla struct {
+	// ShardAwarePort is the shard-aware port of the native transport of the
+	// nodes, usually 19042, or 19142 over TLS. Scylla routes connections to
+	// it to the shard their local port maps to, so the provider spreads its
+	// connections across the shards of each node rather than opening them
+	// all to the shard that accepts them. Every node, including the contact
+	// points, is dialed on it.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	ShardAwarePort *int `json:"shardAwarePort,omitempty"`
+
+	// ConnectionsPerHost is the number of connections opened to each node.
+	// Set it to the number of shards of the nodes for the provider to
+	// connect to each of them. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ConnectionsPerHost *int `json:"connectionsPerHost,omitempty"`
+
+	// TokenAwareRouting routes queries to the replicas of the data they
+	// read, rather than to any node. Defaults to true.
+	// +optional
+	TokenAwareRouting *bool `json:"tokenAwareRouting,omitempty"`
+}
+
+// CredentialsSourceRole reads the credentials from the connection secret of a
+// Role managed by this provider.
+const CredentialsSourceRole xpv1.CredentialsSource = "Role"
+
+// CredentialsSourceCassandraDatacenter connects to a datacenter provisioned by
+// cass-operator, for example through K8ssandra, as its superuser.
+const CredentialsSourceCassandraDatacenter xpv1.CredentialsSource = "CassandraDatacenter"
+
+// CredentialsSourceExternalSecretStore reads the credentials from a secret of
+// an external secret store, such as Vault, configured by a StoreConfig.
+const CredentialsSourceExternalSecretStore xpv1.CredentialsSource = "ExternalSecretStore"
+
 // ProviderCredentials required to authenticate.
+// +kubebuilder:validation:XValidation:rule="self.source != 'Role' || has(self.roleRef)",message="roleRef must be set when the source is Role"
+// +kubebuilder:validation:XValidation:rule="self.source != 'CassandraDatacenter' || has(self.cassandraDatacenterRef)",message="cassandraDatacenterRef must be set when the source is CassandraDatacenter"
+// +kubebuilder:validation:XValidation:rule="self.source != 'ExternalSecretStore' || has(self.externalSecretStoreRef)",message="externalSecretStoreRef must be set when the source is ExternalSecretStore"
+// +kubebuilder:validation:XValidation:rule="self.source != 'Filesystem' || has(self.fs)",message="fs must be set when the source is Filesystem"
+// +kubebuilder:validation:XValidation:rule="self.source != 'Environment' || has(self.env) || has(self.keys)",message="env or keys must be set when the source is Environment"
 type ProviderCredentials struct {
-	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=Secret
+	// Source of the provider credentials. Role reads them from the
+	// connection secret of the Role referenced by RoleRef, which allows a
+	// ProviderConfig to use a role created through another ProviderConfig.
+	// CassandraDatacenter connects to the datacenter referenced by
+	// CassandraDatacenterRef through its service, as its superuser.
+	// Filesystem reads them from the file at the path of the fs selector, or
+	// from a file per key when the path is a directory, such as a secret
+	// mounted by the Secrets Store CSI driver. Environment reads them from
+	// the environment variable of the env selector, or from a variable per
+	// key when keys are set. ExternalSecretStore reads them from the
+	// secret referenced by ExternalSecretStoreRef as separate keys.
+	// +kubebuilder:validation:Enum=Secret;Environment;Filesystem;Role;CassandraDatacenter;ExternalSecretStore
 	Source xpv1.CredentialsSource `json:"source"`
 
+	// CassandraDatacenterRef references the datacenter provisioned by
+	// cass-operator to connect to when the source is CassandraDatacenter.
+	// +optional
+	CassandraDatacenterRef *CassandraDatacenterReference `json:"cassandraDatacenterRef,omitempty"`
+
+	// ExternalSecretStoreRef references the secret of an external secret
+	// store that holds the credentials when the source is
+	// ExternalSecretStore.
+	// +optional
+	ExternalSecretStoreRef *ExternalSecretStoreReference `json:"externalSecretStoreRef,omitempty"`
+
+	// RoleRef references the Role whose connection secret holds the
+	// credentials when the source is Role.
+	// +optional
+	RoleRef *xpv1.Reference `json:"roleRef,omitempty"`
+
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// Keys maps the credentials to the keys of the referenced secret that
+	// hold them. The key of the secretRef is ignored when it is set. When
+	// the source is Filesystem they name the files of the directory, and
+	// when it is Environment the environment variables, that hold them.
+	// +optional
+	Keys *CredentialKeys `json:"keys,omitempty"`
+}
+
+// Retry configures how queries are retried, with an exponential backoff.
+type Retry struct {
+	// MaxRetries is how many times a query is retried. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	// MinBackoff is the backoff before the first retry, which doubles with
+	// every retry. Defaults to 100ms.
+	// +optional
+	MinBackoff *metav1.Duration `json:"minBackoff,omitempty"`
+
+	// MaxBackoff is the longest backoff between retries. Defaults to 10s.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// SpeculativeExecution configures when queries are sent to further nodes.
+type SpeculativeExecution struct {
+	// Attempts is how many further nodes a query is sent to. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Attempts *int `json:"attempts,omitempty"`
+
+	// Delay is how long to wait for a node to respond before the query is
+	// sent to the next one. Defaults to 100ms.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+}
+
+// RateLimit configures how many statements and queries are sent per second.
+type RateLimit struct {
+	// Statements is how many statements that change the schema, the access
+	// control or the data of the cluster may be executed per second. They are
+	// not limited when it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Statements *int `json:"statements,omitempty"`
+
+	// Queries is how many queries that read the cluster, for example to
+	// observe managed resources, may be performed per second. They are not
+	// limited when it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Queries *int `json:"queries,omitempty"`
+
+	// Burst is how many statements, and separately how many queries, may be
+	// sent at once before they are spread out at their rate. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int `json:"burst,omitempty"`
+}
+
+// CircuitBreaker configures when the cluster is given time to recover.
+type CircuitBreaker struct {
+	// Failures is how many statements and queries must fail in a row for
+	// the breaker to open. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Failures *int `json:"failures,omitempty"`
+
+	// CoolDown is how long the breaker stays open, during which statements
+	// and queries fail without being sent. Defaults to 30s.
+	// +optional
+	CoolDown *metav1.Duration `json:"coolDown,omitempty"`
+}
+
+// A ReconnectionPolicy is how the interval between reconnection attempts
+// grows.
+type ReconnectionPolicy string
+
+// Reconnection policies.
+const (
+	// ReconnectionConstant waits the initial interval between attempts.
+	ReconnectionConstant ReconnectionPolicy = "Constant"
+
+	// ReconnectionExponential doubles the interval between attempts, from
+	// the initial interval up to the maximum interval.
+	ReconnectionExponential ReconnectionPolicy = "Exponential"
+)
+
+// Reconnection configures how the provider reconnects to nodes.
+type Reconnection struct {
+	// Interval between attempts to reconnect to nodes that are known to be
+	// down. Defaults to 60s.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Policy is how the interval between the attempts to reconnect to a
+	// node that went down grows.
+	// +kubebuilder:validation:Enum=Constant;Exponential
+	// +kubebuilder:default=Constant
+	// +optional
+	Policy ReconnectionPolicy `json:"policy,omitempty"`
+
+	// MaxRetries is how many times the provider attempts to reconnect to a
+	// node that went down before it considers it down. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	// InitialInterval is the interval before the first attempt to reconnect
+	// to a node that went down. Defaults to 1s.
+	// +optional
+	InitialInterval *metav1.Duration `json:"initialInterval,omitempty"`
+
+	// MaxInterval is the longest interval between attempts to reconnect to
+	// a node when the policy is Exponential. Defaults to 60s.
+	// +optional
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
+}
+
+// An AddressTranslationMode is how addresses are translated.
+type AddressTranslationMode string
+
+// Address translation modes.
+const (
+	// AddressTranslationStatic translates addresses as mapped.
+	AddressTranslationStatic AddressTranslationMode = "Static"
+
+	// AddressTranslationContactPoint dials the first contact point for
+	// every node, and does not discover peers.
+	AddressTranslationContactPoint AddressTranslationMode = "ContactPoint"
+)
+
+// AddressTranslation configures how the addresses nodes broadcast are
+// translated.
+// +kubebuilder:validation:XValidation:rule="self.mode != 'Static' || has(self.mappings)",message="mappings must be set when the mode is Static"
+type AddressTranslation struct {
+	// Mode of the translation. Static translates addresses as mapped, and
+	// ContactPoint dials the first contact point for every node.
+	// +kubebuilder:validation:Enum=Static;ContactPoint
+	Mode AddressTranslationMode `json:"mode"`
+
+	// Mappings of broadcast addresses, either an IP or an IP and port, to
+	// the address and optional port to dial instead. Addresses that are not
+	// mapped are dialed unchanged.
+	// +optional
+	Mappings map[string]string `json:"mappings,omitempty"`
+}
+
+// A ServiceReference references a port of a Kubernetes Service.
+type ServiceReference struct {
+	// Name of the Service.
+	Name string `json:"name"`
+
+	// Namespace of the Service.
+	Namespace string `json:"namespace"`
+
+	// PortName is the name of the port of the native transport. The first
+	// port of the Service is used when it is not set.
+	// +optional
+	PortName string `json:"portName,omitempty"`
+}
+
+// A CassandraDatacenterReference references a CassandraDatacenter provisioned
+// by cass-operator.
+type CassandraDatacenterReference struct {
+	// Name of the CassandraDatacenter.
+	Name string `json:"name"`
+
+	// Namespace of the CassandraDatacenter.
+	Namespace string `json:"namespace"`
+
+	// ClusterName is the name of the cluster the datacenter belongs to, as
+	// set by spec.clusterName of the CassandraDatacenter.
+	ClusterName string `json:"clusterName"`
+
+	// SuperuserSecretName is the name of the secret holding the credentials
+	// of the superuser, as set by spec.superuserSecretName of the
+	// CassandraDatacenter. Defaults to the secret generated by cass-operator.
+	// +optional
+	SuperuserSecretName string `json:"superuserSecretName,omitempty"`
+}
+
+// An ExternalSecretStoreReference references a secret of an external secret
+// store.
+type ExternalSecretStoreReference struct {
+	// StoreConfigRef references the StoreConfig that configures the store.
+	StoreConfigRef xpv1.Reference `json:"storeConfigRef"`
+
+	// Name of the secret.
+	Name string `json:"name"`
+
+	// Scope of the secret, for example its parent path in Vault. Defaults to
+	// the default scope of the StoreConfig.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+}
+
+// CredentialKeys are the keys of a secret that hold the credentials.
+type CredentialKeys struct {
+	// Username is the key holding the username. Defaults to username.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Password is the key holding the password. Defaults to password.
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// Endpoint is the key holding the endpoint of the cluster. Defaults to
+	// endpoint.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Port is the key holding the port of the cluster. Defaults to port.
+	// +optional
+	Port string `json:"port,omitempty"`
+}
+
+// TypeHealthy ProviderConfigs can connect to their cluster.
+const TypeHealthy xpv1.ConditionType = "Healthy"
+
+// Reasons a ProviderConfig is or is not healthy.
+const (
+	ReasonHealthy   xpv1.ConditionReason = "ConnectionSucceeded"
+	ReasonUnhealthy xpv1.ConditionReason = "ConnectionFailed"
+)
+
+// Healthy returns a condition that indicates the ProviderConfig can connect
+// to its cluster.
+func Healthy() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthy,
+	}
+}
+
+// Unhealthy returns a condition that indicates the ProviderConfig cannot
+// connect to its cluster.
+func Unhealthy(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnhealthy,
+		Message:            err.Error(),
+	}
+}
+
+// TypeReachable managed resources can reach the cluster of their
+// ProviderConfig.
+const TypeReachable xpv1.ConditionType = "Reachable"
+
+// Reasons a managed resource can or cannot reach its cluster.
+const (
+	ReasonReachable   xpv1.ConditionReason = "ClusterReachable"
+	ReasonUnreachable xpv1.ConditionReason = "ClusterUnreachable"
+)
+
+// Reachable returns a condition that indicates a managed resource can reach
+// the cluster of its ProviderConfig.
+func Reachable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReachable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReachable,
+	}
+}
+
+// Unreachable returns a condition that indicates a managed resource cannot
+// reach the cluster of its ProviderConfig.
+func Unreachable(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReachable,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnreachable,
+		Message:            err.Error(),
+	}
+}
+
+// TypeAccepted managed resources had their last statements and queries
+// accepted by the cluster of their ProviderConfig.
+const TypeAccepted xpv1.ConditionType = "Accepted"
+
+// Reasons the statements and queries of a managed resource were or were not
+// accepted by its cluster.
+const (
+	ReasonAccepted         xpv1.ConditionReason = "StatementsAccepted"
+	ReasonUnavailable      xpv1.ConditionReason = "ReplicasUnavailable"
+	ReasonTimedOut         xpv1.ConditionReason = "TimedOut"
+	ReasonOverloaded       xpv1.ConditionReason = "ClusterOverloaded"
+	ReasonUnauthenticated  xpv1.ConditionReason = "Unauthenticated"
+	ReasonUnauthorized     xpv1.ConditionReason = "Unauthorized"
+	ReasonAlreadyExists    xpv1.ConditionReason = "AlreadyExists"
+	ReasonInvalidStatement xpv1.ConditionReason = "InvalidStatement"
+)
+
+// Accepted returns a condition that indicates the cluster of a managed
+// resource accepted its statements and queries.
+func Accepted() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAccepted,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAccepted,
+	}
+}
+
+// Rejected returns a condition that indicates the cluster of a managed
+// resource rejected a statement or query for the supplied reason.
+func Rejected(reason xpv1.ConditionReason, message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAccepted,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// TypeInSync managed resources matched their external resource when they were
+// last observed.
+const TypeInSync xpv1.ConditionType = "InSync"
+
+// Reasons a managed resource does or does not match its external resource.
+const (
+	ReasonInSync  xpv1.ConditionReason = "InSync"
+	ReasonDrifted xpv1.ConditionReason = "Drifted"
+)
+
+// InSync returns a condition that indicates a managed resource matches its
+// external resource.
+func InSync() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInSync,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInSync,
+	}
+}
+
+// Drifted returns a condition that indicates how the external resource of a
+// managed resource differs from it.
+func Drifted(diff string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInSync,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDrifted,
+		Message:            diff,
+	}
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// Cluster is the metadata of the cluster, as last discovered when
+	// checking whether the ProviderConfig can connect to it.
+	// +optional
+	Cluster *ClusterObservation `json:"cluster,omitempty"`
+}
+
+// ClusterObservation is the observed metadata of a cluster.
+type ClusterObservation struct {
+	// Name of the cluster.
+	Name string `json:"name,omitempty"`
+
+	// ReleaseVersion is the version of Cassandra the node the provider
+	// connected to runs, for example 5.0.2.
+	ReleaseVersion string `json:"releaseVersion,omitempty"`
+
+	// Partitioner of the cluster.
+	Partitioner string `json:"partitioner,omitempty"`
+
+	// Datacenters of the cluster.
+	Datacenters []string `json:"datacenters,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 
 // A ProviderConfig configures a Cassandra provider.
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="HEALTHY",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster
@@ -18,9 +18,11 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
 	"os"
 	"path/filepath"
-	"time"
+	"strconv"
 
 	"gopkg.in/alecthomas/kingpin.v2"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,9 +30,12 @@ import (
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/certificates"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -39,25 +44,48 @@ import (
 
 	"github.com/crossplane/provider-cassandra/apis"
 	"github.com/crossplane/provider-cassandra/apis/v1alpha1"
+	cassandraclient "github.com/crossplane/provider-cassandra/internal/clients/cassandra"
 	cassandra "github.com/crossplane/provider-cassandra/internal/controller"
+	"github.com/crossplane/provider-cassandra/internal/controller/config"
 	"github.com/crossplane/provider-cassandra/internal/features"
 )
 
+// tlsServerCertsDir is where Crossplane mounts the certificate of the webhook
+// server of providers.
+const tlsServerCertsDir = "/tls/server"
+
 func main() {
 	var (
 		app            = kingpin.New(filepath.Base(os.Args[0]), "Cassandra support for Crossplane.").DefaultEnvars()
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		leaderElection = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
 
+		leaseDuration    = app.Flag("leader-election-lease-duration", "How long replicas that are not the leader wait before acquiring leadership once it is no longer renewed.").Default("60s").Envar("LEADER_ELECTION_LEASE_DURATION").Duration()
+		renewDeadline    = app.Flag("leader-election-renew-deadline", "How long the leader retries renewing leadership before giving it up. Must be shorter than the lease duration.").Default("50s").Envar("LEADER_ELECTION_RENEW_DEADLINE").Duration()
+		retryPeriod      = app.Flag("leader-election-retry-period", "How long replicas wait between attempts to acquire or renew leadership.").Default("2s").Envar("LEADER_ELECTION_RETRY_PERIOD").Duration()
+		leaderElectionNS = app.Flag("leader-election-namespace", "Namespace of the lease used for leader election. Defaults to the namespace the provider runs in.").Envar("LEADER_ELECTION_NAMESPACE").String()
+
 		syncInterval     = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		pollInterval     = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
 		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
 
 		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
+		essTLSCertsPath            = app.Flag("ess-tls-cert-dir", "The directory that contains the CA certificate, client certificate and key used to connect to External Secret Store plugins over mTLS.").Envar("ESS_TLS_CERTS_DIR").String()
+		certsDir                   = app.Flag("certs-dir", "The directory that contains the server key and certificate of the webhooks.").Default(tlsServerCertsDir).Envar("TLS_SERVER_CERTS_DIR").String()
 		enableManagementPolicies   = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("false").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
+
+		healthProbeAddress = app.Flag("health-probe-bind-address", "The address the /healthz and /readyz probe endpoints bind to.").Default(":8081").Envar("HEALTH_PROBE_BIND_ADDRESS").String()
+		pprofPort          = app.Flag("pprof-port", "Serve pprof profiles on this port of localhost, for example 6060. They are not served when it is 0.").Default("0").Envar("PPROF_PORT").Int()
+		readyzCluster      = app.Flag("readyz-cluster", "Report the provider as not ready unless it can reach at least one of the clusters of its ProviderConfigs.").Default("false").Envar("READYZ_CLUSTER").Bool()
+
+		traceQueries = app.Flag("trace-queries", "Enable CQL tracing of all statements and queries, and log their trace IDs.").Default("false").Envar("TRACE_QUERIES").Bool()
+		logQueries   = app.Flag("log-queries", "Log every executed statement and query, with its duration, node and result. Sensitive values are redacted.").Default("false").Envar("LOG_QUERIES").Bool()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
+	if *leaderElection && *renewDeadline >= *leaseDuration {
+		kingpin.Fatalf("--leader-election-renew-deadline must be shorter than --leader-election-lease-duration")
+	}
 
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-cassandra"))
@@ -68,6 +96,15 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
+	// Trace IDs are logged for ProviderConfigs that enable tracing, or for all
+	// of them with --trace-queries.
+	cassandraclient.TraceLogger = log.WithValues("component", "tracing")
+	cassandraclient.TraceAll = *traceQueries
+	if *logQueries {
+		cassandraclient.QueryLogger = log.WithValues("component", "query-log")
+	}
+	log.Debug("Using CQL driver", "driver", cassandraclient.DefaultDriver.Name())
+
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
@@ -84,15 +121,44 @@ func main() {
 		// renewal deadlines being exceeded when under high load - i.e.
 		// hundreds of reconciles per second and ~200rps to the API
 		// server. Switching to Leases only and longer leases appears to
-		// alleviate this.
+		// alleviate this. They may be shortened with flags so that replicas
+		// fail over faster.
 		LeaderElection:             *leaderElection,
 		LeaderElectionID:           "crossplane-leader-election-provider-cassandra",
+		LeaderElectionNamespace:    *leaderElectionNS,
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
-		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
-		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+		LeaseDuration:              leaseDuration,
+		RenewDeadline:              renewDeadline,
+		RetryPeriod:                retryPeriod,
+
+		// Profiles are only served on localhost, so that they are reached
+		// by port-forwarding to the provider rather than exposed.
+		PprofBindAddress: pprofAddress(*pprofPort),
+
+		// Kubernetes restarts a provider whose /healthz probe fails, and
+		// stops routing webhook requests to one whose /readyz probe fails.
+		HealthProbeBindAddress: *healthProbeAddress,
+
+		// The webhook server converts resources between API versions,
+		// validates their CQL identifiers and fills their defaults.
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: *certsDir,
+			TLSOpts: []func(*tls.Config){
+				func(t *tls.Config) {
+					t.MinVersion = tls.VersionTLS13
+				},
+			},
+		}),
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Cassandra APIs to scheme")
+	kingpin.FatalIfError(mgr.AddHealthzCheck("ping", healthz.Ping), "Cannot add health check")
+	kingpin.FatalIfError(mgr.AddReadyzCheck("ping", healthz.Ping), "Cannot add readiness check")
+	if *readyzCluster {
+		// The Healthy conditions of ProviderConfigs are read from the API
+		// server, so that replicas that are not the leader are ready too.
+		kingpin.FatalIfError(mgr.AddReadyzCheck("cluster", config.ClusterReachable(mgr.GetAPIReader())), "Cannot add cluster readiness check")
+	}
 
 	o := controller.Options{
 		Logger:                  log,
@@ -106,6 +172,15 @@ func main() {
 		o.Features.Enable(features.EnableAlphaExternalSecretStores)
 		log.Info("Alpha feature enabled", "flag", features.EnableAlphaExternalSecretStores)
 
+		// Connection details are written to secret store plugins, such as
+		// one for Vault, over mTLS with the certificates Crossplane mounts.
+		o.ESSOptions = &controller.ESSOptions{}
+		if *essTLSCertsPath != "" {
+			tcfg, err := certificates.LoadMTLSConfig(filepath.Join(*essTLSCertsPath, "ca.crt"), filepath.Join(*essTLSCertsPath, "tls.crt"), filepath.Join(*essTLSCertsPath, "tls.key"), false)
+			kingpin.FatalIfError(err, "Cannot load External Secret Store TLS config")
+			o.ESSOptions.TLSConfig = tcfg
+		}
+
 		// Ensure default store config exists.
 		kingpin.FatalIfError(resource.Ignore(kerrors.IsAlreadyExists, mgr.GetClient().Create(context.Background(), &v1alpha1.StoreConfig{
 			ObjectMeta: metav1.ObjectMeta{
@@ -129,3 +204,12 @@ func main() {
 	kingpin.FatalIfError(cassandra.Setup(mgr, o), "Cannot setup Cassandra controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
+
+// pprofAddress returns the localhost address pprof profiles are served on, or
+// an empty address that disables them if the port is 0.
+func pprofAddress(port int) string {
+	if port == 0 {
+		return ""
+	}
+	return net.JoinHostPort("localhost", strconv.Itoa(port))
+}
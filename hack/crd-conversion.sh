@@ -0,0 +1,30 @@
+#!/usr/bin/env bash
+
+# Copyright 2022 The Crossplane Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Enables the conversion webhook of the supplied CRDs, which controller-gen
+# cannot do. Crossplane fills in the client config of the webhook when it
+# installs the provider.
+set -euo pipefail
+
+for crd in "$@"; do
+  sed -i.bak 's/^spec:$/spec:\
+  conversion:\
+    strategy: Webhook\
+    webhook:\
+      conversionReviewVersions:\
+      - v1/' "${crd}"
+  rm -f "${crd}.bak"
+done
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+// AnnotationKeyAdopted records when a managed resource adopted an object that
+// existed in the cluster before it, rather than creating it.
+const AnnotationKeyAdopted = "cassandra.crossplane.io/adopted"
+
+// AnnotationKeyAdopt allows a managed resource whose ProviderConfig fails on
+// conflicts to adopt an object that already exists, when it is set to true.
+const AnnotationKeyAdopt = "cassandra.crossplane.io/adopt"
+
+const errConflict = "%s already exists and was not created by this managed resource; annotate it with %s: \"true\" to adopt it"
+
+// Adopt reports whether a managed resource whose object exists is observing it
+// for the first time without having created it, in which case it marks the
+// managed resource as having adopted the object. The spec of an adopted managed
+// resource is late initialized from the object, so that adopting an object
+// does not change it.
+func Adopt(mg resource.Managed) bool {
+	if !adopting(mg) {
+		return false
+	}
+	meta.AddAnnotations(mg, map[string]string{AnnotationKeyAdopted: time.Now().UTC().Format(time.RFC3339)})
+	return true
+}
+
+// Adopted reports whether a managed resource adopted its object.
+func Adopted(mg resource.Managed) bool {
+	_, ok := mg.GetAnnotations()[AnnotationKeyAdopted]
+	return ok
+}
+
+// adopting reports whether a managed resource whose object exists neither
+// created nor adopted it yet.
+func adopting(mg resource.Managed) bool {
+	return !Adopted(mg) && meta.GetExternalCreatePending(mg).IsZero() && meta.GetExternalCreateSucceeded(mg).IsZero()
+}
+
+// Conflict returns an error if a managed resource whose object exists would
+// adopt it while the supplied policy fails on conflicts, unless the managed
+// resource is annotated to adopt it.
+func Conflict(mg resource.Managed, p apisv1alpha1.ConflictPolicy) error {
+	if p != apisv1alpha1.ConflictPolicyFail || !adopting(mg) || mg.GetAnnotations()[AnnotationKeyAdopt] == "true" {
+		return nil
+	}
+	return errors.Errorf(errConflict, meta.GetExternalName(mg), AnnotationKeyAdopt)
+}
+
+// IfNotExists returns the IF NOT EXISTS clause of the statements that create
+// objects under the supplied policy, followed by a space. Objects are created
+// without it when the policy fails on conflicts, so that creating an object
+// that already exists fails.
+func IfNotExists(p apisv1alpha1.ConflictPolicy) string {
+	if p == apisv1alpha1.ConflictPolicyFail {
+		return ""
+	}
+	return "IF NOT EXISTS "
+}
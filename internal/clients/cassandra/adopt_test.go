@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+func TestAdopt(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		want        bool
+		adopted     bool
+	}{
+		"Existing": {
+			reason:  "An object the managed resource did not create should be adopted.",
+			want:    true,
+			adopted: true,
+		},
+		"Created": {
+			reason: "An object the managed resource created should not be adopted.",
+			annotations: map[string]string{
+				meta.AnnotationKeyExternalCreatePending:   time.Now().Format(time.RFC3339),
+				meta.AnnotationKeyExternalCreateSucceeded: time.Now().Format(time.RFC3339),
+			},
+		},
+		"Pending": {
+			reason: "An object the managed resource may have created should not be adopted.",
+			annotations: map[string]string{
+				meta.AnnotationKeyExternalCreatePending: time.Now().Format(time.RFC3339),
+			},
+		},
+		"Adopted": {
+			reason: "An object should only be adopted once.",
+			annotations: map[string]string{
+				AnnotationKeyAdopted: time.Now().Format(time.RFC3339),
+			},
+			adopted: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if got := Adopt(mg); got != tc.want {
+				t.Errorf("\n%s\nAdopt(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+			if got := Adopted(mg); got != tc.adopted {
+				t.Errorf("\n%s\nAdopted(...): want %t, got %t\n", tc.reason, tc.adopted, got)
+			}
+		})
+	}
+}
+
+func TestConflict(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		policy      apisv1alpha1.ConflictPolicy
+		annotations map[string]string
+		wantErr     bool
+	}{
+		"Adopt": {
+			reason: "An existing object should be adopted by default.",
+		},
+		"Fail": {
+			reason:      "An existing object should not be adopted when the policy fails on conflicts.",
+			policy:      apisv1alpha1.ConflictPolicyFail,
+			annotations: map[string]string{meta.AnnotationKeyExternalName: "existing"},
+			wantErr:     true,
+		},
+		"Annotated": {
+			reason: "An existing object should be adopted when the managed resource is annotated to adopt it.",
+			policy: apisv1alpha1.ConflictPolicyFail,
+			annotations: map[string]string{
+				AnnotationKeyAdopt: "true",
+			},
+		},
+		"Created": {
+			reason: "An object the managed resource created does not conflict.",
+			policy: apisv1alpha1.ConflictPolicyFail,
+			annotations: map[string]string{
+				meta.AnnotationKeyExternalCreateSucceeded: time.Now().Format(time.RFC3339),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			err := Conflict(mg, tc.policy)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\nConflict(...): want error %t, got %v\n", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithCircuitBreaker stops sending statements and queries to the cluster for
+// the cool-down once the supplied number of them failed in a row because the
+// cluster could not be reached or could not serve them. They fail with an
+// UnreachableError meanwhile. The breaker is shared by the clients that share
+// a session through a Pool, which are those of a ProviderConfig.
+func WithCircuitBreaker(failures int, coolDown time.Duration) Option {
+	return func(cfg *config) {
+		cfg.breaker = &breaker{threshold: failures, coolDown: coolDown}
+	}
+}
+
+// A breaker counts the consecutive failures of a cluster, and opens once
+// they reach the threshold. Once the cool-down elapses, statements and queries
+// are sent again, and the breaker opens again on the first of them that fails.
+type breaker struct {
+	threshold int
+	coolDown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	until    time.Time
+}
+
+// allow returns an error if the breaker is open. A nil breaker is never open.
+func (b *breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if time.Now().Before(b.until) {
+		return UnreachableError{err: fmt.Errorf("circuit breaker is open after %d consecutive failures, until %s", b.failures, b.until.UTC().Format(time.RFC3339))}
+	}
+	return nil
+}
+
+// record records the result of a statement or query. Errors that are not
+// caused by the cluster, such as invalid statements, show that it is serving
+// and so reset the count of failures.
+func (b *breaker) record(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !isClusterFailure(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.until = time.Now().Add(b.coolDown)
+	}
+}
+
+// isClusterFailure reports whether an error is caused by the cluster being
+// unreachable or unable to serve a statement or query.
+func isClusterFailure(err error) bool {
+	if IsUnreachable(err) {
+		return true
+	}
+	switch result(err) {
+	case resultUnavailable, resultReadTimeout, resultWriteTimeout, resultOverloaded, resultTimeout:
+		return true
+	}
+	return false
+}
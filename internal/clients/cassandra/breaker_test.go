@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestBreaker(t *testing.T) {
+	unavailable := fmt.Errorf("failed to execute query: %w", &gocql.RequestErrUnavailable{})
+	b := &breaker{threshold: 2, coolDown: time.Hour}
+
+	b.record(unavailable)
+	b.record(errors.New("line 1:0 no viable alternative at input 'SELEC'"))
+	b.record(unavailable)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow(): want closed breaker after failures that are not consecutive, got %v", err)
+	}
+
+	b.record(UnreachableError{err: gocql.ErrNoConnections})
+	if err := b.allow(); !IsUnreachable(err) {
+		t.Fatalf("allow(): want unreachable error once open, got %v", err)
+	}
+
+	b.until = time.Now()
+	if err := b.allow(); err != nil {
+		t.Errorf("allow(): want closed breaker after the cool-down, got %v", err)
+	}
+
+	var none *breaker
+	none.record(unavailable)
+	if err := none.allow(); err != nil {
+		t.Errorf("allow(): want nil breaker to never open, got %v", err)
+	}
+}
@@ -3,7 +3,8 @@ package cassandra
 import (
 	"context"
 
-	"github.com/gocql/gocql"
+	"fmt"
+	"reflect"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
@@ -11,10 +12,11 @@ import (
 
 type MockDB struct {
 	ExecFunc                 func(ctx context.Context, query string, args ...interface{}) error
-	QueryFunc                func(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error)
-	ScanFunc                 func(iter *gocql.Iter, dest ...interface{}) bool
+	ExecSensitiveFunc        func(ctx context.Context, query string, sensitive []string, args ...interface{}) error
+	QueryFunc                func(ctx context.Context, query string, args ...interface{}) (Iterator, error)
 	CloseFunc                func()
 	GetConnectionDetailsFunc func(username, password string) managed.ConnectionDetails
+	IsHostUpFunc             func(hostID string) bool
 }
 
 // Exec executes a CQL statement.
@@ -25,20 +27,25 @@ func (m *MockDB) Exec(ctx context.Context, query string, args ...interface{}) er
 	return nil
 }
 
-// Query performs a query and returns an iterator for the results.
-func (m *MockDB) Query(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error) {
-	if m.QueryFunc != nil {
-		return m.QueryFunc(ctx, query, args...)
+// ExecSensitive executes a CQL statement that embeds sensitive values. It
+// calls ExecFunc and redacts its error unless ExecSensitiveFunc is set.
+func (m *MockDB) ExecSensitive(ctx context.Context, query string, sensitive []string, args ...interface{}) error {
+	if m.ExecSensitiveFunc != nil {
+		return m.ExecSensitiveFunc(ctx, query, sensitive, args...)
+	}
+	if m.ExecFunc != nil {
+		return Redact(m.ExecFunc(ctx, query, args...), sensitive...)
 	}
-	return nil, nil
+	return nil
 }
 
-// Scan performs scanning of an iterator.
-func (m *MockDB) Scan(iter *gocql.Iter, dest ...interface{}) bool {
-	if m.ScanFunc != nil {
-		return m.ScanFunc(iter, dest...)
+// Query performs a query and returns an iterator for the results, which has
+// no rows unless QueryFunc is set.
+func (m *MockDB) Query(ctx context.Context, query string, args ...interface{}) (Iterator, error) {
+	if m.QueryFunc != nil {
+		return m.QueryFunc(ctx, query, args...)
 	}
-	return false
+	return &MockIterator{}, nil
 }
 
 // Close closes the Cassandra session.
@@ -58,3 +65,66 @@ func (m *MockDB) GetConnectionDetails(username, password string) managed.Connect
 		xpv1.ResourceCredentialsSecretPasswordKey: []byte(password),
 	}
 }
+
+// IsHostUp reports whether the host with the given ID is reachable.
+func (m *MockDB) IsHostUp(hostID string) bool {
+	if m.IsHostUpFunc != nil {
+		return m.IsHostUpFunc(hostID)
+	}
+	return false
+}
+
+// A MockIterator iterates over a fixed set of rows.
+type MockIterator struct {
+	// Columns are the names of the columns of the rows, which MapScan keys
+	// them by.
+	Columns []string
+
+	// Rows are the values of the columns of each row. A nil value leaves its
+	// destination unchanged.
+	Rows [][]interface{}
+
+	// Err is the error returned when the iterator is closed.
+	Err error
+}
+
+// Scan scans the next row into the destinations, which must be pointers to
+// the types of the values of the row.
+func (i *MockIterator) Scan(dest ...interface{}) bool {
+	if len(i.Rows) == 0 {
+		return false
+	}
+	row := i.Rows[0]
+	i.Rows = i.Rows[1:]
+	for n, d := range dest {
+		if n >= len(row) || row[n] == nil {
+			continue
+		}
+		v := reflect.ValueOf(d).Elem()
+		if !reflect.TypeOf(row[n]).AssignableTo(v.Type()) {
+			panic(fmt.Sprintf("cannot scan %T into %T", row[n], d))
+		}
+		v.Set(reflect.ValueOf(row[n]))
+	}
+	return true
+}
+
+// MapScan scans the next row into a map keyed by column name.
+func (i *MockIterator) MapScan(m map[string]interface{}) bool {
+	if len(i.Rows) == 0 {
+		return false
+	}
+	row := i.Rows[0]
+	i.Rows = i.Rows[1:]
+	for n, c := range i.Columns {
+		if n < len(row) {
+			m[c] = row[n]
+		}
+	}
+	return true
+}
+
+// Close returns Err.
+func (i *MockIterator) Close() error {
+	return i.Err
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gocql/gocql"
+)
+
+// An ErrorClass tells what caused a statement or query to fail.
+type ErrorClass int
+
+// Classes of errors.
+const (
+	// ErrorUnknown errors could not be classified.
+	ErrorUnknown ErrorClass = iota
+
+	// ErrorUnreachable errors are returned when the cluster cannot be
+	// reached.
+	ErrorUnreachable
+
+	// ErrorUnavailable errors are returned when not enough replicas are
+	// alive to achieve the consistency level.
+	ErrorUnavailable
+
+	// ErrorTimeout errors are returned when the cluster or its replicas did
+	// not respond in time.
+	ErrorTimeout
+
+	// ErrorOverloaded errors are returned by nodes too busy to serve.
+	ErrorOverloaded
+
+	// ErrorUnauthenticated errors are returned when the credentials are
+	// rejected.
+	ErrorUnauthenticated
+
+	// ErrorUnauthorized errors are returned when the role lacks a permission
+	// the statement or query requires.
+	ErrorUnauthorized
+
+	// ErrorAlreadyExists errors are returned when creating something that
+	// exists.
+	ErrorAlreadyExists
+
+	// ErrorInvalid errors are returned for statements and queries the
+	// cluster cannot run as written, such as those with syntax errors.
+	ErrorInvalid
+)
+
+// Classify returns the class of an error returned by a client.
+func Classify(err error) ErrorClass {
+	var (
+		unavailable  *gocql.RequestErrUnavailable
+		readTimeout  *gocql.RequestErrReadTimeout
+		writeTimeout *gocql.RequestErrWriteTimeout
+		exists       *gocql.RequestErrAlreadyExists
+		reqErr       gocql.RequestError
+	)
+	switch {
+	case err == nil:
+		return ErrorUnknown
+	case IsUnreachable(err):
+		return ErrorUnreachable
+	case errors.As(err, &unavailable):
+		return ErrorUnavailable
+	case errors.As(err, &readTimeout), errors.As(err, &writeTimeout):
+		return ErrorTimeout
+	case errors.As(err, &exists):
+		return ErrorAlreadyExists
+	case errors.Is(err, gocql.ErrTimeoutNoResponse), errors.Is(err, context.DeadlineExceeded):
+		return ErrorTimeout
+	case !errors.As(err, &reqErr):
+		return ErrorUnknown
+	}
+	switch reqErr.Code() {
+	case gocql.ErrCodeUnavailable:
+		return ErrorUnavailable
+	case gocql.ErrCodeReadTimeout, gocql.ErrCodeWriteTimeout:
+		return ErrorTimeout
+	case gocql.ErrCodeOverloaded:
+		return ErrorOverloaded
+	case gocql.ErrCodeCredentials:
+		return ErrorUnauthenticated
+	case gocql.ErrCodeUnauthorized:
+		return ErrorUnauthorized
+	case gocql.ErrCodeAlreadyExists:
+		return ErrorAlreadyExists
+	case gocql.ErrCodeSyntax, gocql.ErrCodeInvalid, gocql.ErrCodeConfig:
+		return ErrorInvalid
+	}
+	return ErrorUnknown
+}
+
+// Retryable reports whether retrying may succeed. Errors of other classes
+// persist until the spec of the managed resource, the permissions of the
+// role of its ProviderConfig or the cluster are changed.
+func (c ErrorClass) Retryable() bool {
+	switch c {
+	case ErrorUnauthenticated, ErrorUnauthorized, ErrorAlreadyExists, ErrorInvalid:
+		return false
+	}
+	return true
+}
+
+// classifiedRetryPolicy retries queries as its policy decides, unless they
+// failed with an error that retrying cannot resolve.
+type classifiedRetryPolicy struct {
+	gocql.RetryPolicy
+}
+
+// GetRetryType rethrows errors that are not retryable.
+func (p classifiedRetryPolicy) GetRetryType(err error) gocql.RetryType {
+	if !Classify(err).Retryable() {
+		return gocql.Rethrow
+	}
+	return p.RetryPolicy.GetRetryType(err)
+}
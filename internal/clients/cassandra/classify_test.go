@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+// requestError is an error returned by a cluster with a code.
+type requestError struct {
+	gocql.RequestError
+	code int
+}
+
+func (e requestError) Code() int { return e.code }
+
+func (e requestError) Error() string { return "rejected" }
+
+func TestClassify(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   ErrorClass
+	}{
+		"Unreachable": {
+			reason: "Clients that cannot connect should report the cluster as unreachable.",
+			err:    UnreachableError{err: errors.New("boom")},
+			want:   ErrorUnreachable,
+		},
+		"Unavailable": {
+			reason: "Too few live replicas should be told apart from other errors, however wrapped.",
+			err:    fmt.Errorf("failed to execute query: %w", &gocql.RequestErrUnavailable{}),
+			want:   ErrorUnavailable,
+		},
+		"WriteTimeout": {
+			reason: "Replicas that did not acknowledge a write in time should be reported as a timeout.",
+			err:    &gocql.RequestErrWriteTimeout{},
+			want:   ErrorTimeout,
+		},
+		"DeadlineExceeded": {
+			reason: "Statements that exceeded the operation timeout should be reported as a timeout.",
+			err:    context.DeadlineExceeded,
+			want:   ErrorTimeout,
+		},
+		"Unauthorized": {
+			reason: "Missing permissions should be told apart from outages.",
+			err:    fmt.Errorf("failed to execute query: %w", requestError{code: gocql.ErrCodeUnauthorized}),
+			want:   ErrorUnauthorized,
+		},
+		"AlreadyExists": {
+			reason: "Creating something that exists should be reported as such.",
+			err:    &gocql.RequestErrAlreadyExists{},
+			want:   ErrorAlreadyExists,
+		},
+		"Syntax": {
+			reason: "Statements with syntax errors should be reported as invalid.",
+			err:    requestError{code: gocql.ErrCodeSyntax},
+			want:   ErrorInvalid,
+		},
+		"Other": {
+			reason: "Errors not returned by the cluster cannot be classified.",
+			err:    errors.New("boom"),
+			want:   ErrorUnknown,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Classify(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nClassify(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// A Defaulter fills the parameters of a resource of type T that it does not
+// set with their defaults on admission, so that the defaults are visible in
+// the stored spec.
+type Defaulter[T client.Object] func(ctx context.Context, o T) error
+
+// Default fills the defaults of a resource that is being created. Existing
+// resources are never defaulted, so that the settings of the resources they
+// manage do not change under them when defaults do.
+func (d Defaulter[T]) Default(ctx context.Context, obj runtime.Object) error {
+	o, ok := obj.(T)
+	if !ok {
+		return errors.Errorf("unexpected resource %T", obj)
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+	return d(ctx, o)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestDefaulter(t *testing.T) {
+	d := Defaulter[*metav1.PartialObjectMetadata](func(_ context.Context, o *metav1.PartialObjectMetadata) error {
+		o.SetLabels(map[string]string{"defaulted": "true"})
+		return nil
+	})
+
+	cases := map[string]struct {
+		reason string
+		op     admissionv1.Operation
+		want   map[string]string
+	}{
+		"Create": {
+			reason: "Resources that are created should be defaulted.",
+			op:     admissionv1.Create,
+			want:   map[string]string{"defaulted": "true"},
+		},
+		"Update": {
+			reason: "Resources that are updated should not be defaulted.",
+			op:     admissionv1.Update,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: tc.op}})
+			o := &metav1.PartialObjectMetadata{}
+			if err := d.Default(ctx, o); err != nil {
+				t.Fatalf("\n%s\nDefault(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, o.GetLabels()); diff != "" {
+				t.Errorf("\n%s\nDefault(...): -want labels, +got labels:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
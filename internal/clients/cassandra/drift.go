@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Drift lists how the observed state of an external resource differs from
+// its desired state, one field per entry, for example
+// "replication_factor: want 3, got 2".
+type Drift []string
+
+// Field records that a field differs.
+func (d *Drift) Field(name string, want, got any) {
+	*d = append(*d, fmt.Sprintf("%s: want %v, got %v", name, want, got))
+}
+
+// String returns the entries of the drift separated by semicolons.
+func (d Drift) String() string {
+	return strings.Join(d, "; ")
+}
+
+// Value returns the value a pointer points to, or "unset" if it is nil, to be
+// recorded in a Drift.
+func Value[T any](p *T) any {
+	if p == nil {
+		return "unset"
+	}
+	return *p
+}
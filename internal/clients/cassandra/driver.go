@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import "github.com/gocql/gocql"
+
+// A Driver connects clients to their cluster. Drivers share the API of the
+// upstream gocql driver, as forks such as scylladb/gocql do.
+type Driver interface {
+	// Name returns the name of the driver.
+	Name() string
+
+	// ShardAware reports whether the driver connects to each shard of
+	// ScyllaDB nodes itself, in which case clients do not dial the shard-aware
+	// port of nodes on their own.
+	ShardAware() bool
+
+	// CreateSession connects to a cluster.
+	CreateSession(cluster *gocql.ClusterConfig) (*gocql.Session, error)
+}
+
+// WithDriver connects the client with the supplied driver rather than
+// DefaultDriver.
+func WithDriver(d Driver) Option {
+	return func(cfg *config) {
+		cfg.driver = d
+	}
+}
+
+// DefaultDriver is the upstream gocql driver.
+var DefaultDriver Driver = gocqlDriver{}
+
+type gocqlDriver struct{}
+
+func (gocqlDriver) Name() string { return "gocql" }
+
+func (gocqlDriver) ShardAware() bool { return false }
+
+func (gocqlDriver) CreateSession(cluster *gocql.ClusterConfig) (*gocql.Session, error) {
+	return cluster.CreateSession()
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gocql/gocql"
+)
+
+// A flightGroup coalesces identical queries that are in flight, so that the
+// reconciles of many managed resources observing the same rows at once, such
+// as the grants of a role, query them only once.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// A flight is a query in flight. The rows of its first page are buffered so
+// that each of the callers waiting for it can scan them.
+type flight struct {
+	done chan struct{}
+
+	columns []string
+	rows    [][]rawValue
+	state   []byte
+	err     error
+}
+
+// do returns an iterator over the first page of rows of the query of the
+// supplied key. The query is fetched unless an identical one is already in
+// flight, in which case its rows are returned once it lands. Queries are not
+// cached once they land, but a caller that joins a query in flight is given
+// the rows it read, which may have been read before the caller called.
+func (g *flightGroup) do(ctx context.Context, key string, fetch func(f *flight)) (*bufferedIterator, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = map[string]*flight{}
+	}
+	f, ok := g.flights[key]
+	if !ok {
+		f = &flight{done: make(chan struct{})}
+		g.flights[key] = f
+		go func() {
+			fetch(f)
+			g.mu.Lock()
+			delete(g.flights, key)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return &bufferedIterator{columns: f.columns, rows: f.rows, state: f.state, err: f.err}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// A pageIterator iterates over a single page of the rows of a query, such as
+// the iterator of a query the driver does not page automatically.
+type pageIterator interface {
+	Iterator
+
+	// RowData returns the columns of the rows, and values they can be
+	// scanned into.
+	RowData() (gocql.RowData, error)
+
+	// PageState returns the paging state of the page that follows this one.
+	// It is empty when this page is the last one.
+	PageState() []byte
+}
+
+// buffer reads the rows of the page of the iterator into the flight, along
+// with the state the page that follows it is fetched from, if any. The
+// iterator must not page automatically, so that no more than a page of rows
+// is buffered.
+func (f *flight) buffer(iter pageIterator) {
+	rd, err := iter.RowData()
+	if err != nil {
+		f.err = iter.Close()
+		return
+	}
+	f.columns = rd.Columns
+	for {
+		row := make([]rawValue, len(rd.Columns))
+		dest := make([]interface{}, len(row))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if !iter.Scan(dest...) {
+			break
+		}
+		f.rows = append(f.rows, row)
+	}
+	f.state = iter.PageState()
+	f.err = iter.Close()
+}
+
+// A rawValue is the serialized value of a column, which is unmarshalled into
+// the destinations it is scanned into as the driver would.
+type rawValue struct {
+	info gocql.TypeInfo
+	data []byte
+}
+
+// UnmarshalCQL captures the serialized value. Null values have nil data.
+func (v *rawValue) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	v.info = info
+	if data != nil {
+		v.data = append([]byte{}, data...)
+	}
+	return nil
+}
+
+// A bufferedIterator iterates over the buffered rows of a flight.
+type bufferedIterator struct {
+	columns []string
+	rows    [][]rawValue
+	err     error
+
+	// state is the paging state of the page that follows the buffered rows.
+	// It is empty when they are the last page of the query.
+	state []byte
+}
+
+// Scan scans the next row into the destinations, one per column.
+func (i *bufferedIterator) Scan(dest ...interface{}) bool {
+	if i.err != nil || len(i.rows) == 0 {
+		return false
+	}
+	row := i.rows[0]
+	if len(dest) != len(row) {
+		// Rows are scanned into one destination per column, as the driver
+		// scans them.
+		i.err = fmt.Errorf("cannot scan %d columns into %d destinations", len(row), len(dest))
+		return false
+	}
+	i.rows = i.rows[1:]
+	for n, d := range dest {
+		if err := gocql.Unmarshal(row[n].info, row[n].data, d); err != nil {
+			i.err = err
+			return false
+		}
+	}
+	return true
+}
+
+// MapScan scans the next row into a map keyed by column name, with the values
+// of the columns of the types the driver would scan them into.
+func (i *bufferedIterator) MapScan(m map[string]interface{}) bool {
+	if i.err != nil || len(i.rows) == 0 {
+		return false
+	}
+	row := i.rows[0]
+	i.rows = i.rows[1:]
+	for n, c := range i.columns {
+		v, err := row[n].info.NewWithError()
+		if err == nil {
+			err = gocql.Unmarshal(row[n].info, row[n].data, v)
+		}
+		if err != nil {
+			i.err = err
+			return false
+		}
+		m[c] = reflect.ValueOf(v).Elem().Interface()
+	}
+	return true
+}
+
+// Close returns the error of the query, or of scanning its rows.
+func (i *bufferedIterator) Close() error {
+	return i.err
+}
+
+// A pagingIterator iterates over the rows of a query a page at a time. Its
+// first page is that of a flight, and the pages that follow are fetched from
+// their paging state as the iterator is scanned, so that no more than a page
+// of rows is held at once.
+type pagingIterator struct {
+	page  Iterator
+	state []byte
+	err   error
+
+	// next fetches the page of the supplied paging state. It returns the
+	// page, the state of the page that follows it and a function that
+	// releases the resources of the page once it is closed.
+	next func(state []byte) (Iterator, []byte, context.CancelFunc)
+
+	// release releases the resources of the current page.
+	release context.CancelFunc
+}
+
+// advance closes the current page and moves on to the page that follows it.
+// It returns false once there are no more pages or an error occurred.
+func (i *pagingIterator) advance() bool {
+	err := i.page.Close()
+	if i.release != nil {
+		i.release()
+		i.release = nil
+	}
+	if err != nil {
+		i.err = err
+		return false
+	}
+	if len(i.state) == 0 {
+		return false
+	}
+	i.page, i.state, i.release = i.next(i.state)
+	return true
+}
+
+// Scan scans the next row into the destinations, one per column.
+func (i *pagingIterator) Scan(dest ...interface{}) bool {
+	for i.err == nil {
+		if i.page.Scan(dest...) {
+			return true
+		}
+		if !i.advance() {
+			return false
+		}
+	}
+	return false
+}
+
+// MapScan scans the next row into a map keyed by column name.
+func (i *pagingIterator) MapScan(m map[string]interface{}) bool {
+	for i.err == nil {
+		if i.page.MapScan(m) {
+			return true
+		}
+		if !i.advance() {
+			return false
+		}
+	}
+	return false
+}
+
+// Close closes the current page and returns the error of the query, or of
+// scanning its rows.
+func (i *pagingIterator) Close() error {
+	if i.err != nil {
+		return i.err
+	}
+	err := i.page.Close()
+	if i.release != nil {
+		i.release()
+		i.release = nil
+	}
+	return err
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+// raw returns the serialized value of a column of the supplied type.
+func raw(t *testing.T, typ gocql.Type, v interface{}) rawValue {
+	t.Helper()
+	info := gocql.NewNativeType(4, typ, "")
+	data, err := gocql.Marshal(info, v)
+	if err != nil {
+		t.Fatalf("Marshal(%v): %v", v, err)
+	}
+	return rawValue{info: info, data: data}
+}
+
+func TestFlightGroupCoalesces(t *testing.T) {
+	g := &flightGroup{}
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	row := []rawValue{raw(t, gocql.TypeVarchar, "example_role")}
+
+	fetch := func(f *flight) {
+		fetches.Add(1)
+		<-release
+		f.columns, f.rows = []string{"role"}, [][]rawValue{row}
+	}
+
+	// The first call is in flight until it is released, so the calls that
+	// follow it join it rather than fetching again.
+	var wg sync.WaitGroup
+	got := make([]string, 3)
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			iter, err := g.do(context.Background(), "key", fetch)
+			if err != nil {
+				t.Errorf("do(...): unexpected error: %v", err)
+				return
+			}
+			iter.Scan(&got[i])
+		}(i)
+	}
+	eventually(t, func() bool { return fetches.Load() == 1 }, "the query was never fetched")
+	eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return len(g.flights) == 1
+	}, "the query was not in flight")
+	close(release)
+	wg.Wait()
+
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("do(...): want the query fetched once, got %d", n)
+	}
+	if diff := cmp.Diff([]string{"example_role", "example_role", "example_role"}, got); diff != "" {
+		t.Errorf("do(...): -want rows, +got rows:\n%s\n", diff)
+	}
+
+	// Queries are not cached once they land.
+	if _, err := g.do(context.Background(), "key", func(f *flight) { fetches.Add(1) }); err != nil {
+		t.Fatalf("do(...): unexpected error: %v", err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("do(...): want a landed query fetched again, got %d fetches", n)
+	}
+}
+
+func TestBufferedIterator(t *testing.T) {
+	rows := [][]rawValue{
+		{raw(t, gocql.TypeVarchar, "example_role"), raw(t, gocql.TypeBoolean, true)},
+		{raw(t, gocql.TypeVarchar, "other_role"), {info: gocql.NewNativeType(4, gocql.TypeBoolean, "")}},
+	}
+
+	iter := &bufferedIterator{columns: []string{"role", "can_login"}, rows: rows}
+	var role string
+	var canLogin *bool
+	if !iter.Scan(&role, &canLogin) {
+		t.Fatal("Scan(...): want a row")
+	}
+	if role != "example_role" || canLogin == nil || !*canLogin {
+		t.Errorf("Scan(...): want example_role that can log in, got %q, %v", role, canLogin)
+	}
+
+	m := map[string]interface{}{}
+	if !iter.MapScan(m) {
+		t.Fatal("MapScan(...): want a row")
+	}
+	if diff := cmp.Diff(map[string]interface{}{"role": "other_role", "can_login": false}, m); diff != "" {
+		t.Errorf("MapScan(...): -want, +got:\n%s\n", diff)
+	}
+
+	if iter.Scan(&role) {
+		t.Error("Scan(...): want no more rows")
+	}
+	if err := iter.Close(); err != nil {
+		t.Errorf("Close(): unexpected error: %v", err)
+	}
+}
+
+func TestBufferedIteratorColumns(t *testing.T) {
+	row := []rawValue{raw(t, gocql.TypeVarchar, "example_role"), raw(t, gocql.TypeBoolean, true)}
+
+	cases := map[string]struct {
+		reason string
+		dest   []interface{}
+	}{
+		"TooFew": {
+			reason: "A row should not be scanned into fewer destinations than it has columns.",
+			dest:   []interface{}{new(string)},
+		},
+		"TooMany": {
+			reason: "A row should not be scanned into more destinations than it has columns.",
+			dest:   []interface{}{new(string), new(bool), new(string)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			iter := &bufferedIterator{columns: []string{"role", "can_login"}, rows: [][]rawValue{row}}
+			if iter.Scan(tc.dest...) {
+				t.Errorf("\n%s\nScan(...): want no row", tc.reason)
+			}
+			if err := iter.Close(); err == nil {
+				t.Errorf("\n%s\nClose(): want an error", tc.reason)
+			}
+		})
+	}
+}
+
+func TestPagingIterator(t *testing.T) {
+	pages := map[string]*MockIterator{
+		"second": {Rows: [][]interface{}{{"analyst"}}},
+		"third":  {Rows: [][]interface{}{{"auditor"}}},
+	}
+	states := map[string][]byte{"second": []byte("third")}
+	var fetched []string
+	var released int
+	first := &bufferedIterator{rows: [][]rawValue{{raw(t, gocql.TypeVarchar, "reader")}}, state: []byte("second")}
+
+	iter := &pagingIterator{page: first, state: first.state, next: func(state []byte) (Iterator, []byte, context.CancelFunc) {
+		fetched = append(fetched, string(state))
+		return pages[string(state)], states[string(state)], func() { released++ }
+	}}
+
+	// Only the first page is held until the iterator is scanned past it.
+	var roles []string
+	var role string
+	if !iter.Scan(&role) {
+		t.Fatal("Scan(...): want a row")
+	}
+	roles = append(roles, role)
+	if len(fetched) != 0 {
+		t.Errorf("Scan(...): want no page fetched while the first is scanned, got %v", fetched)
+	}
+	for iter.Scan(&role) {
+		roles = append(roles, role)
+	}
+	if err := iter.Close(); err != nil {
+		t.Errorf("Close(): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"reader", "analyst", "auditor"}, roles); diff != "" {
+		t.Errorf("Scan(...): -want rows, +got rows:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"second", "third"}, fetched); diff != "" {
+		t.Errorf("Scan(...): -want pages, +got pages:\n%s\n", diff)
+	}
+	if released != 2 {
+		t.Errorf("Close(): want every fetched page released, got %d of 2", released)
+	}
+}
+
+func TestPagingIteratorError(t *testing.T) {
+	errBoom := errors.New("boom")
+	first := &bufferedIterator{state: []byte("second")}
+	iter := &pagingIterator{page: first, state: first.state, next: func(state []byte) (Iterator, []byte, context.CancelFunc) {
+		return &MockIterator{Err: errBoom}, []byte("third"), func() {}
+	}}
+
+	var role string
+	if iter.Scan(&role) {
+		t.Error("Scan(...): want no rows")
+	}
+	if err := iter.Close(); !errors.Is(err, errBoom) {
+		t.Errorf("Close(): want %v, got %v", errBoom, err)
+	}
+}
+
+// A fakePage is a page of the rows of a query, as returned by a driver that
+// does not page queries automatically.
+type fakePage struct {
+	*bufferedIterator
+}
+
+func (p fakePage) RowData() (gocql.RowData, error) {
+	values := make([]interface{}, len(p.columns))
+	for i := range values {
+		values[i] = new(string)
+	}
+	return gocql.RowData{Columns: p.columns, Values: values}, nil
+}
+
+func (p fakePage) PageState() []byte {
+	return p.state
+}
+
+func TestPaged(t *testing.T) {
+	pages := map[string][]string{"": {"reader", "writer"}, "second": {"analyst"}, "third": {"auditor"}}
+	next := map[string]string{"": "second", "second": "third"}
+	var fetched []string
+	page := func(_ context.Context, state []byte) pageIterator {
+		fetched = append(fetched, string(state))
+		var rows [][]rawValue
+		for _, r := range pages[string(state)] {
+			rows = append(rows, []rawValue{raw(t, gocql.TypeVarchar, r)})
+		}
+		return fakePage{&bufferedIterator{columns: []string{"role"}, rows: rows, state: []byte(next[string(state)])}}
+	}
+
+	c := CassandraDB{flights: &flightGroup{}}
+	iter, err := c.paged(context.Background(), "key", page)
+	if err != nil {
+		t.Fatalf("paged(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{""}, fetched); diff != "" {
+		t.Errorf("paged(...): want only the first page fetched before scanning, -want, +got:\n%s\n", diff)
+	}
+
+	var roles []string
+	var role string
+	for iter.Scan(&role) {
+		roles = append(roles, role)
+	}
+	if err := iter.Close(); err != nil {
+		t.Errorf("Close(): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"reader", "writer", "analyst", "auditor"}, roles); diff != "" {
+		t.Errorf("Scan(...): -want rows, +got rows:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"", "second", "third"}, fetched); diff != "" {
+		t.Errorf("Scan(...): -want pages, +got pages:\n%s\n", diff)
+	}
+}
+
+func TestPageQuery(t *testing.T) {
+	// The driver has no accessor for whether it pages a query
+	// automatically, so its fields are read.
+	field := func(q *gocql.Query, name string) reflect.Value {
+		return reflect.ValueOf(q).Elem().FieldByName(name)
+	}
+
+	for name, state := range map[string][]byte{"FirstPage": nil, "NextPage": []byte("second")} {
+		t.Run(name, func(t *testing.T) {
+			q := CassandraDB{}.pageQuery(&gocql.Session{}, "SELECT role FROM system_auth.roles", nil, state)
+			if !field(q, "disableAutoPage").Bool() {
+				t.Error("pageQuery(...): want the query not to be paged automatically")
+			}
+			if diff := cmp.Diff(state, field(q, "pageState").Bytes()); diff != "" {
+				t.Errorf("pageQuery(...): -want paging state, +got:\n%s\n", diff)
+			}
+		})
+	}
+}
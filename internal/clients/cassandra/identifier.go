@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+// Limits of the identifiers the provider creates.
+const (
+	// MaxNameLength is the longest keyspace, table or index name Cassandra
+	// accepts, as it is part of the directory the data is stored in.
+	MaxNameLength = 48
+
+	// MaxRoleNameLength is the longest role name the provider creates.
+	MaxRoleNameLength = 256
+)
+
+// name matches the keyspace, table and index names Cassandra accepts, quoted
+// or not.
+var name = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// reserved are the CQL keywords that cannot be used as unquoted identifiers.
+// They are rejected even though the provider quotes every identifier, so that
+// the resources it creates can be used from any client.
+var reserved = map[string]bool{
+	"ADD": true, "ALLOW": true, "ALTER": true, "AND": true, "APPLY": true,
+	"ASC": true, "AUTHORIZE": true, "BATCH": true, "BEGIN": true, "BY": true,
+	"COLUMNFAMILY": true, "CREATE": true, "DELETE": true, "DESC": true,
+	"DESCRIBE": true, "DROP": true, "ENTRIES": true, "EXECUTE": true,
+	"FROM": true, "FULL": true, "GRANT": true, "IF": true, "IN": true,
+	"INDEX": true, "INFINITY": true, "INSERT": true, "INTO": true, "IS": true,
+	"KEYSPACE": true, "LIMIT": true, "MATERIALIZED": true, "MODIFY": true,
+	"NAN": true, "NORECURSIVE": true, "NOT": true, "NULL": true, "OF": true,
+	"ON": true, "OR": true, "ORDER": true, "PRIMARY": true, "RENAME": true,
+	"REPLACE": true, "REVOKE": true, "SCHEMA": true, "SELECT": true,
+	"SET": true, "TABLE": true, "TO": true, "TOKEN": true, "TRUNCATE": true,
+	"UNLOGGED": true, "UPDATE": true, "USE": true, "USING": true, "VIEW": true,
+	"WHERE": true, "WITH": true,
+}
+
+// ValidateName returns the errors of a keyspace, table or index name at the
+// supplied path.
+func ValidateName(path *field.Path, n string) field.ErrorList {
+	errs := field.ErrorList{}
+	switch {
+	case n == "":
+		errs = append(errs, field.Required(path, "must not be empty"))
+	case len(n) > MaxNameLength:
+		errs = append(errs, field.TooLong(path, n, MaxNameLength))
+	case !name.MatchString(n):
+		errs = append(errs, field.Invalid(path, n, "must only contain alphanumeric characters and underscores"))
+	case reserved[strings.ToUpper(n)]:
+		errs = append(errs, field.Invalid(path, n, "must not be a reserved CQL keyword"))
+	}
+	return errs
+}
+
+// ValidateRoleName returns the errors of a role name at the supplied path.
+// Role names may contain any printable character, as they are always quoted.
+func ValidateRoleName(path *field.Path, n string) field.ErrorList {
+	errs := field.ErrorList{}
+	switch {
+	case n == "":
+		errs = append(errs, field.Required(path, "must not be empty"))
+	case len(n) > MaxRoleNameLength:
+		errs = append(errs, field.TooLong(path, n, MaxRoleNameLength))
+	case !utf8.ValidString(n) || strings.IndexFunc(n, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0:
+		errs = append(errs, field.Invalid(path, n, "must only contain printable UTF-8 characters"))
+	case reserved[strings.ToUpper(n)]:
+		errs = append(errs, field.Invalid(path, n, "must not be a reserved CQL keyword"))
+	}
+	return errs
+}
+
+// ValidateExternalName returns the errors of the name the resource is known
+// by in the cluster: its external name, or its name when it has none yet, as
+// the name is then used as its external name.
+func ValidateExternalName(o metav1.Object, validate func(*field.Path, string) field.ErrorList) field.ErrorList {
+	if n := meta.GetExternalName(o); n != "" {
+		return validate(field.NewPath("metadata", "annotations").Key(meta.AnnotationKeyExternalName), n)
+	}
+	return validate(field.NewPath("metadata", "name"), o.GetName())
+}
+
+// NormalizeIdentifier returns the name of a keyspace, table or index as it is
+// stored by Cassandra under the supplied casing: lowercased, as an unquoted
+// identifier is, under IdentifierCaseLower, and as it is written otherwise.
+func NormalizeIdentifier(c apisv1alpha1.IdentifierCase, id string) string {
+	if c == apisv1alpha1.IdentifierCaseLower {
+		return strings.ToLower(id)
+	}
+	return id
+}
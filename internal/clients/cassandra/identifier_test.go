@@ -0,0 +1,292 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+func TestValidateName(t *testing.T) {
+	path := field.NewPath("spec", "forProvider", "keyspace")
+
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   field.ErrorList
+	}{
+		"Valid": {
+			reason: "Names of alphanumeric characters and underscores are valid.",
+			name:   "my_keyspace_2",
+			want:   field.ErrorList{},
+		},
+		"Empty": {
+			reason: "Empty names are invalid.",
+			name:   "",
+			want:   field.ErrorList{field.Required(path, "must not be empty")},
+		},
+		"TooLong": {
+			reason: "Names longer than Cassandra accepts are invalid.",
+			name:   strings.Repeat("a", MaxNameLength+1),
+			want:   field.ErrorList{field.TooLong(path, strings.Repeat("a", MaxNameLength+1), MaxNameLength)},
+		},
+		"Hyphen": {
+			reason: "Names with characters other than alphanumeric characters and underscores are invalid.",
+			name:   "my-keyspace",
+			want:   field.ErrorList{field.Invalid(path, "my-keyspace", "must only contain alphanumeric characters and underscores")},
+		},
+		"Quote": {
+			reason: "Names with quotes are invalid.",
+			name:   `ks"; DROP KEYSPACE x`,
+			want:   field.ErrorList{field.Invalid(path, `ks"; DROP KEYSPACE x`, "must only contain alphanumeric characters and underscores")},
+		},
+		"Reserved": {
+			reason: "Reserved keywords are invalid in any case.",
+			name:   "Table",
+			want:   field.ErrorList{field.Invalid(path, "Table", "must not be a reserved CQL keyword")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateName(path, tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nValidateName(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateRoleName(t *testing.T) {
+	path := field.NewPath("spec", "forProvider", "role")
+
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   field.ErrorList
+	}{
+		"Valid": {
+			reason: "Role names may contain any printable character.",
+			name:   "app-user@example.com \"ops\"",
+			want:   field.ErrorList{},
+		},
+		"Empty": {
+			reason: "Empty role names are invalid.",
+			name:   "",
+			want:   field.ErrorList{field.Required(path, "must not be empty")},
+		},
+		"TooLong": {
+			reason: "Overly long role names are invalid.",
+			name:   strings.Repeat("r", MaxRoleNameLength+1),
+			want:   field.ErrorList{field.TooLong(path, strings.Repeat("r", MaxRoleNameLength+1), MaxRoleNameLength)},
+		},
+		"ControlCharacter": {
+			reason: "Role names with control characters are invalid.",
+			name:   "app\nuser",
+			want:   field.ErrorList{field.Invalid(path, "app\nuser", "must only contain printable UTF-8 characters")},
+		},
+		"InvalidUTF8": {
+			reason: "Role names that are not valid UTF-8 are invalid.",
+			name:   "app\xffuser",
+			want:   field.ErrorList{field.Invalid(path, "app\xffuser", "must only contain printable UTF-8 characters")},
+		},
+		"Reserved": {
+			reason: "Reserved keywords are invalid role names.",
+			name:   "grant",
+			want:   field.ErrorList{field.Invalid(path, "grant", "must not be a reserved CQL keyword")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateRoleName(path, tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nValidateRoleName(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateExternalName(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      metav1.Object
+		want   field.ErrorList
+	}{
+		"ExternalName": {
+			reason: "The external name of a resource should be validated when it is set.",
+			o: func() metav1.Object {
+				o := &metav1.ObjectMeta{Name: "valid_name"}
+				meta.SetExternalName(o, "invalid-name")
+				return o
+			}(),
+			want: field.ErrorList{field.Invalid(field.NewPath("metadata", "annotations").Key(meta.AnnotationKeyExternalName), "invalid-name", "must only contain alphanumeric characters and underscores")},
+		},
+		"Name": {
+			reason: "The name of a resource should be validated when it has no external name, as it becomes its external name.",
+			o:      &metav1.ObjectMeta{Name: "invalid-name"},
+			want:   field.ErrorList{field.Invalid(field.NewPath("metadata", "name"), "invalid-name", "must only contain alphanumeric characters and underscores")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateExternalName(tc.o, ValidateName)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nValidateExternalName(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIdentifierValidatorUpdate(t *testing.T) {
+	v := IdentifierValidator[*metav1.PartialObjectMetadata](func(o *metav1.PartialObjectMetadata) field.ErrorList {
+		return ValidateExternalName(o, ValidateName)
+	})
+	named := func(name, ext string, deleting bool) *metav1.PartialObjectMetadata {
+		o := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if ext != "" {
+			meta.SetExternalName(o, ext)
+		}
+		if deleting {
+			now := metav1.Now()
+			o.SetDeletionTimestamp(&now)
+		}
+		return o
+	}
+
+	cases := map[string]struct {
+		reason string
+		old    *metav1.PartialObjectMetadata
+		obj    *metav1.PartialObjectMetadata
+		want   bool
+	}{
+		"Valid": {
+			reason: "Updates of resources with valid identifiers should be admitted.",
+			old:    named("valid_name", "", false),
+			obj:    named("valid_name", "valid_name", false),
+		},
+		"Changed": {
+			reason: "Updates that change an identifier to an invalid one should be rejected as invalid.",
+			old:    named("valid_name", "valid_name", false),
+			obj:    named("valid_name", "invalid-name", false),
+			want:   true,
+		},
+		"Unchanged": {
+			reason: "Updates of resources whose identifiers were already invalid should be admitted.",
+			old:    named("invalid-name", "invalid-name", false),
+			obj:    named("invalid-name", "invalid-name", false),
+		},
+		"ExternalNameSet": {
+			reason: "Setting the invalid name of a resource as its external name should be admitted.",
+			old:    named("invalid-name", "", false),
+			obj:    named("invalid-name", "invalid-name", false),
+		},
+		"Deleting": {
+			reason: "Updates of resources that are being deleted should be admitted.",
+			old:    named("valid_name", "valid_name", false),
+			obj:    named("valid_name", "invalid-name", true),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := v.ValidateUpdate(context.Background(), tc.old, tc.obj)
+			if diff := cmp.Diff(tc.want, kerrors.IsInvalid(err)); diff != "" {
+				t.Errorf("\n%s\nValidateUpdate(...): -want invalid, +got invalid:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeIdentifier(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		c      apisv1alpha1.IdentifierCase
+		want   string
+	}{
+		"Preserve": {
+			reason: "Should use the identifier as it is written",
+			c:      apisv1alpha1.IdentifierCasePreserve,
+			want:   "MyKeyspace",
+		},
+		"Unset": {
+			reason: "Should use the identifier as it is written when no casing is set",
+			want:   "MyKeyspace",
+		},
+		"Lower": {
+			reason: "Should lowercase the identifier as Cassandra does when it is unquoted",
+			c:      apisv1alpha1.IdentifierCaseLower,
+			want:   "mykeyspace",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := NormalizeIdentifier(tc.c, "MyKeyspace"); got != tc.want {
+				t.Errorf("\n%s\nNormalizeIdentifier(...): want %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIdentifierValidator(t *testing.T) {
+	v := IdentifierValidator[*metav1.PartialObjectMetadata](func(o *metav1.PartialObjectMetadata) field.ErrorList {
+		return ValidateName(field.NewPath("metadata", "name"), o.GetName())
+	})
+
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   bool
+	}{
+		"Valid": {
+			reason: "Resources with valid identifiers should be admitted.",
+			name:   "valid_name",
+		},
+		"Invalid": {
+			reason: "Resources with invalid identifiers should be rejected as invalid.",
+			name:   "invalid-name",
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: tc.name}}
+			_, err := v.ValidateCreate(context.Background(), o)
+			if diff := cmp.Diff(tc.want, kerrors.IsInvalid(err)); diff != "" {
+				t.Errorf("\n%s\nValidateCreate(...): -want invalid, +got invalid:\n%s\n", tc.reason, diff)
+			}
+			_, err = v.ValidateDelete(context.Background(), o)
+			if diff := cmp.Diff(nil, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"encoding/binary"
+
+	"github.com/pierrec/lz4/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// lz4MaxFrame is the size of the largest frame of the native protocol,
+	// which bounds the size a frame may claim to decompress to.
+	lz4MaxFrame = 256 << 20
+
+	errLZ4Corrupt = "corrupt LZ4 block"
+)
+
+// An LZ4Compressor compresses frames of the native protocol with LZ4. Frames
+// are LZ4 blocks preceded by their uncompressed length as a big-endian 32-bit
+// integer, as Cassandra expects.
+type LZ4Compressor struct{}
+
+// Name of the compression algorithm, as negotiated with the cluster.
+func (LZ4Compressor) Name() string {
+	return "lz4"
+}
+
+// Encode compresses a frame.
+func (LZ4Compressor) Encode(data []byte) ([]byte, error) {
+	dst := make([]byte, 4+lz4.CompressBlockBound(len(data)))
+	binary.BigEndian.PutUint32(dst, uint32(len(data)))
+	n, err := lz4.CompressBlock(data, dst[4:], nil)
+	if err != nil {
+		return nil, err
+	}
+	return dst[:4+n], nil
+}
+
+// Decode decompresses a frame.
+func (LZ4Compressor) Decode(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, errors.New(errLZ4Corrupt)
+	}
+	size := binary.BigEndian.Uint32(data)
+	if size > lz4MaxFrame {
+		return nil, errors.New(errLZ4Corrupt)
+	}
+	dst := make([]byte, size)
+	n, err := lz4.UncompressBlock(data[4:], dst)
+	if err != nil {
+		return nil, errors.Wrap(err, errLZ4Corrupt)
+	}
+	if n != int(size) {
+		return nil, errors.New(errLZ4Corrupt)
+	}
+	return dst, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+func TestCompressionOption(t *testing.T) {
+	lz4, snappy := compressionLZ4, compressionSnappy
+
+	cases := map[string]struct {
+		reason      string
+		compression *string
+		want        gocql.Compressor
+	}{
+		"Unset": {
+			reason: "Should not compress frames when no compression is set",
+		},
+		"LZ4": {
+			reason:      "Should compress frames with LZ4",
+			compression: &lz4,
+			want:        LZ4Compressor{},
+		},
+		"Snappy": {
+			reason:      "Should compress frames with Snappy",
+			compression: &snappy,
+			want:        gocql.SnappyCompressor{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts, err := ProviderConfigOptions(context.Background(), nil, apisv1alpha1.ProviderConfigSpec{Compression: tc.compression})
+			if err != nil {
+				t.Fatalf("ProviderConfigOptions(...): %v", err)
+			}
+			cfg := &config{cluster: gocql.NewCluster()}
+			for _, o := range opts {
+				o(cfg)
+			}
+			if diff := cmp.Diff(tc.want, cfg.cluster.Compressor); diff != "" {
+				t.Errorf("\n%s\nProviderConfigOptions(...): -want compressor, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLZ4Decode(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		frame   []byte
+		want    []byte
+		wantErr bool
+	}{
+		"Literals": {
+			reason: "Should decode a frame of a block of literals only",
+			frame:  []byte{0, 0, 0, 5, 0x50, 'h', 'e', 'l', 'l', 'o'},
+			want:   []byte("hello"),
+		},
+		"Match": {
+			reason: "Should decode a frame of a block that copies an earlier match",
+			frame:  []byte{0, 0, 0, 24, 0x3c, 'a', 'b', 'c', 3, 0, 0x50, 'b', 'c', 'a', 'b', 'c'},
+			want:   []byte("abcabcabcabcabcabcabcabc"),
+		},
+		"NoLength": {
+			reason:  "Should reject a frame too short to hold its length",
+			frame:   []byte{0, 0, 5},
+			wantErr: true,
+		},
+		"TooLarge": {
+			reason:  "Should reject a frame that claims to be larger than the native protocol allows",
+			frame:   []byte{0x7f, 0xff, 0xff, 0xff, 0x50, 'h', 'e', 'l', 'l', 'o'},
+			wantErr: true,
+		},
+		"WrongLength": {
+			reason:  "Should reject a frame whose block decompresses to another length than it claims",
+			frame:   []byte{0, 0, 0, 6, 0x50, 'h', 'e', 'l', 'l', 'o'},
+			wantErr: true,
+		},
+		"Truncated": {
+			reason:  "Should reject a frame whose block is cut short",
+			frame:   []byte{0, 0, 0, 24, 0x3c, 'a', 'b', 'c', 3},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := LZ4Compressor{}.Decode(tc.frame)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nDecode(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); !tc.wantErr && diff != "" {
+				t.Errorf("\n%s\nDecode(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLZ4RoundTrip(t *testing.T) {
+	random := make([]byte, 64<<10)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	cases := map[string][]byte{
+		"Empty":      {},
+		"Short":      []byte("SELECT"),
+		"Repetitive": bytes.Repeat([]byte("SELECT role FROM system_auth.roles; "), 1000),
+		"Random":     random,
+	}
+
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			frame, err := LZ4Compressor{}.Encode(data)
+			if err != nil {
+				t.Fatalf("Encode(...): %v", err)
+			}
+			if got := int(frame[0])<<24 | int(frame[1])<<16 | int(frame[2])<<8 | int(frame[3]); got != len(data) {
+				t.Errorf("Encode(...): want the frame to start with length %d, got %d", len(data), got)
+			}
+			got, err := LZ4Compressor{}.Decode(frame)
+			if err != nil {
+				t.Fatalf("Decode(Encode(...)): %v", err)
+			}
+			if !bytes.Equal(data, got) {
+				t.Errorf("Decode(Encode(...)): want the data that was encoded")
+			}
+		})
+	}
+}
+
+func FuzzLZ4RoundTrip(f *testing.F) {
+	f.Add([]byte("abcabcabcabcabcabcabcabc"))
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		frame, err := LZ4Compressor{}.Encode(data)
+		if err != nil {
+			t.Fatalf("Encode(...): %v", err)
+		}
+		got, err := LZ4Compressor{}.Decode(frame)
+		if err != nil {
+			t.Fatalf("Decode(Encode(...)): %v", err)
+		}
+		if !bytes.Equal(data, got) {
+			t.Errorf("Decode(Encode(...)): want the data that was encoded")
+		}
+	})
+}
+
+func FuzzLZ4Decode(f *testing.F) {
+	f.Add([]byte{0, 0, 0, 24, 0x3c, 'a', 'b', 'c', 3, 0, 0x50, 'b', 'c', 'a', 'b', 'c'})
+	f.Fuzz(func(t *testing.T, frame []byte) {
+		// Corrupt frames must be rejected rather than crash the provider.
+		_, _ = LZ4Compressor{}.Decode(frame)
+	})
+}
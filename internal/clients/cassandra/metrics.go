@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gocql/gocql"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// Results of statements, queries and connection attempts, by which their
+// metrics are labelled.
+const (
+	resultSuccess      = "success"
+	resultUnavailable  = "unavailable"
+	resultReadTimeout  = "read_timeout"
+	resultWriteTimeout = "write_timeout"
+	resultOverloaded   = "overloaded"
+	resultTimeout      = "timeout"
+	resultError        = "error"
+)
+
+var (
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cassandra_query_duration_seconds",
+		Help:    "How long each attempt of a CQL statement or query took, by node and result.",
+		Buckets: []float64{.001, .005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"host", "result"})
+
+	connectAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cassandra_connect_attempts_total",
+		Help: "Connection attempts to nodes, by node and result.",
+	}, []string{"host", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(queryDuration, connectAttempts)
+}
+
+// result classifies the error of a statement, query or connection attempt, so
+// that failures to achieve a consistency level can be told apart from other
+// errors.
+func result(err error) string {
+	var (
+		unavailable  *gocql.RequestErrUnavailable
+		readTimeout  *gocql.RequestErrReadTimeout
+		writeTimeout *gocql.RequestErrWriteTimeout
+		reqErr       gocql.RequestError
+	)
+	switch {
+	case err == nil:
+		return resultSuccess
+	case errors.As(err, &unavailable):
+		return resultUnavailable
+	case errors.As(err, &readTimeout):
+		return resultReadTimeout
+	case errors.As(err, &writeTimeout):
+		return resultWriteTimeout
+	case errors.As(err, &reqErr) && reqErr.Code() == gocql.ErrCodeOverloaded:
+		return resultOverloaded
+	case errors.Is(err, gocql.ErrTimeoutNoResponse), errors.Is(err, context.DeadlineExceeded):
+		return resultTimeout
+	}
+	return resultError
+}
+
+// hostLabel returns the label of the node a statement was sent to.
+func hostLabel(h *gocql.HostInfo) string {
+	if h == nil {
+		return ""
+	}
+	return h.HostnameAndPort()
+}
+
+// A queryObserver records the metrics of each execution of a statement or
+// query, and logs it if the supplied logger is not nil.
+type queryObserver struct {
+	log *queryLogger
+}
+
+// ObserveQuery records the duration and result of an execution.
+func (o queryObserver) ObserveQuery(ctx context.Context, q gocql.ObservedQuery) {
+	queryDuration.WithLabelValues(hostLabel(q.Host), result(q.Err)).Observe(q.End.Sub(q.Start).Seconds())
+	if o.log != nil {
+		o.log.ObserveQuery(ctx, q)
+	}
+}
+
+// A connectObserver records connection attempts to nodes, and logs those that
+// fail if the supplied logger is not nil.
+type connectObserver struct {
+	log logging.Logger
+}
+
+// ObserveConnect records the result of a connection attempt.
+func (o connectObserver) ObserveConnect(c gocql.ObservedConnect) {
+	connectAttempts.WithLabelValues(hostLabel(c.Host), result(c.Err)).Inc()
+	if o.log != nil && c.Err != nil {
+		o.log.Info("Cannot connect to node", "host", hostLabel(c.Host), "duration", c.End.Sub(c.Start), "error", c.Err)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResult(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   string
+	}{
+		"Success": {
+			reason: "An operation without an error succeeded.",
+			want:   resultSuccess,
+		},
+		"Unavailable": {
+			reason: "An operation that could not achieve its consistency level because too few replicas were alive is unavailable.",
+			err:    fmt.Errorf("failed to execute query: %w", &gocql.RequestErrUnavailable{}),
+			want:   resultUnavailable,
+		},
+		"ReadTimeout": {
+			reason: "An operation whose replicas did not respond in time timed out reading.",
+			err:    &gocql.RequestErrReadTimeout{},
+			want:   resultReadTimeout,
+		},
+		"Deadline": {
+			reason: "An operation whose context expired timed out.",
+			err:    context.DeadlineExceeded,
+			want:   resultTimeout,
+		},
+		"Other": {
+			reason: "Other errors are not classified.",
+			err:    errors.New("boom"),
+			want:   resultError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, result(tc.err)); diff != "" {
+				t.Errorf("\n%s\nresult(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// AnnotationKeyPollInterval overrides the interval at which a managed resource
+// is polled, as a duration such as 1h or 1m, so that schema can be polled
+// rarely and the grants of sensitive keyspaces often.
+const AnnotationKeyPollInterval = "cassandra.crossplane.io/poll-interval"
+
+// PollInterval returns the interval at which a managed resource is polled: the
+// one it overrides the interval of its controller with, if it is a positive
+// duration, or the interval of its controller.
+func PollInterval(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	v, ok := mg.GetAnnotations()[AnnotationKeyPollInterval]
+	if !ok {
+		return pollInterval
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return pollInterval
+	}
+	return d
+}
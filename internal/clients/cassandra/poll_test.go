@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestPollInterval(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		want        time.Duration
+	}{
+		"NoAnnotation": {
+			reason: "A resource without an override should be polled at the interval of its controller.",
+			want:   time.Minute,
+		},
+		"Override": {
+			reason:      "A resource should be polled at the interval it overrides the interval of its controller with.",
+			annotations: map[string]string{AnnotationKeyPollInterval: "1h"},
+			want:        time.Hour,
+		},
+		"Invalid": {
+			reason:      "A resource with an invalid override should be polled at the interval of its controller.",
+			annotations: map[string]string{AnnotationKeyPollInterval: "hourly"},
+			want:        time.Minute,
+		},
+		"NotPositive": {
+			reason:      "A resource whose override is not positive should be polled at the interval of its controller.",
+			annotations: map[string]string{AnnotationKeyPollInterval: "0s"},
+			want:        time.Minute,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			got := PollInterval(mg, time.Minute)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPollInterval(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+// DefaultIdleTimeout is how long a shared session that is not used by any
+// reconcile is kept open.
+const DefaultIdleTimeout = 5 * time.Minute
+
+// Sessions is the pool the sessions of managed resources are shared through.
+var Sessions = NewPool(New, DefaultIdleTimeout)
+
+// A share configures the session of a client to be shared.
+type share struct {
+	ctx context.Context
+	key string
+}
+
+// WithSharedSession shares the session of the client with the other clients
+// created through a Pool with the same key, until ctx is done. It has no
+// effect on clients that are not created through a Pool.
+func WithSharedSession(ctx context.Context, key string) Option {
+	return func(cfg *config) {
+		cfg.share = &share{ctx: ctx, key: key}
+	}
+}
+
+// SessionKey returns the key of the sessions a managed resource may share:
+// those of the managed resources of the same ProviderConfig that connect with
+// the same spec, credentials and consistency overrides.
+func SessionKey(pc *apisv1alpha1.ProviderConfig, creds map[string][]byte, o metav1.Object) string {
+	h := sha256.New()
+	spec, _ := json.Marshal(pc.Spec)
+	h.Write(spec)
+
+	keys := make([]string, 0, len(creds))
+	for k := range creds {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write(creds[k])
+		h.Write([]byte{0})
+	}
+
+	for _, k := range []string{AnnotationKeyConsistency, AnnotationKeyReadConsistency} {
+		h.Write([]byte(o.GetAnnotations()[k]))
+		h.Write([]byte{0})
+	}
+
+	return pc.GetName() + "/" + hex.EncodeToString(h.Sum(nil))
+}
+
+// A Pool shares sessions between clients, so that the reconciles of the
+// managed resources of a ProviderConfig do not each connect to the cluster.
+// A session is referenced by the clients sharing it until their contexts are
+// done, and closed once it has not been referenced for the idle timeout.
+type Pool struct {
+	newClient func(creds map[string][]byte, keyspace string, opts ...Option) DB
+	idle      time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// A session is a client shared through a Pool.
+type session struct {
+	db    DB
+	ready chan struct{}
+
+	// refs is the number of clients referencing the session, and expiry
+	// closes it once it has been unreferenced for the idle timeout.
+	refs   int
+	expiry *time.Timer
+
+	// stale sessions are no longer shared, and are closed as soon as they
+	// are unreferenced.
+	stale bool
+}
+
+// NewPool returns a Pool that creates clients with the supplied function, and
+// closes them once they have been idle for the supplied duration.
+func NewPool(newClient func(creds map[string][]byte, keyspace string, opts ...Option) DB, idle time.Duration) *Pool {
+	return &Pool{newClient: newClient, idle: idle, sessions: map[string]*session{}}
+}
+
+// New returns a client that shares its session with the other clients with
+// the same key, per WithSharedSession. Clients without a key are not shared.
+// Closing a shared client has no effect; the Pool closes its session.
+func (p *Pool) New(creds map[string][]byte, keyspace string, opts ...Option) DB {
+	cfg := &config{cluster: gocql.NewCluster()}
+	for _, o := range opts {
+		o(cfg)
+	}
+	if cfg.share == nil {
+		return p.newClient(creds, keyspace, opts...)
+	}
+	key := cfg.share.key + "/" + keyspace
+
+	p.mu.Lock()
+	s, ok := p.sessions[key]
+	if !ok {
+		s = &session{ready: make(chan struct{})}
+		p.sessions[key] = s
+	}
+	s.refs++
+	if s.expiry != nil {
+		s.expiry.Stop()
+		s.expiry = nil
+	}
+	p.mu.Unlock()
+
+	// The session is created outside the lock, as connecting may take as
+	// long as the connect timeout. Clients of the same key wait for it.
+	if !ok {
+		s.db = p.newClient(creds, keyspace, opts...)
+		close(s.ready)
+	}
+	<-s.ready
+
+	// Sessions that were closed are not shared, so that the next client
+	// creates a new one. Sessions that cannot reach their cluster yet are
+	// shared, so that their clients back off from connecting together.
+	if !usable(s.db) {
+		p.mu.Lock()
+		if p.sessions[key] == s {
+			delete(p.sessions, key)
+		}
+		s.stale = true
+		p.mu.Unlock()
+	}
+
+	context.AfterFunc(cfg.share.ctx, func() { p.release(key, s) })
+	return sharedDB{DB: s.db}
+}
+
+// Invalidate stops sharing the sessions of a ProviderConfig, for example
+// because a secret it references changed. They are closed once the clients
+// referencing them are done.
+func (p *Pool) Invalidate(providerConfig string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, s := range p.sessions {
+		if !strings.HasPrefix(key, providerConfig+"/") {
+			continue
+		}
+		delete(p.sessions, key)
+		s.stale = true
+		if s.refs == 0 {
+			p.close(s)
+		}
+	}
+}
+
+// release drops a reference to a session, closing it if it is stale or
+// scheduling it to be closed once it has been idle for the idle timeout.
+func (p *Pool) release(key string, s *session) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s.refs--
+	if s.refs > 0 {
+		return
+	}
+	if s.stale {
+		p.close(s)
+		return
+	}
+	s.expiry = time.AfterFunc(p.idle, func() { p.expire(key, s) })
+}
+
+// expire closes a session that has been idle for the idle timeout, unless it
+// was referenced again in the meantime.
+func (p *Pool) expire(key string, s *session) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s.refs > 0 || s.stale {
+		return
+	}
+	delete(p.sessions, key)
+	s.stale = true
+	p.close(s)
+}
+
+// close closes a session. It must be called with the lock held.
+func (p *Pool) close(s *session) {
+	if s.expiry != nil {
+		s.expiry.Stop()
+		s.expiry = nil
+	}
+	// The session may still be connecting if it is invalidated.
+	go func() {
+		<-s.ready
+		s.db.Close()
+	}()
+}
+
+// usable reports whether a client was not closed.
+func usable(db DB) bool {
+	c, ok := db.(CassandraDB)
+	return !ok || !c.session.isClosed()
+}
+
+// A sharedDB is a client whose session is closed by the Pool it is shared
+// through rather than by its users.
+type sharedDB struct {
+	DB
+}
+
+// Close does nothing; the Pool closes the session once it is idle.
+func (sharedDB) Close() {}
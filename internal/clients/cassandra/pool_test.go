@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingClients returns a function that creates clients, and counts the
+// clients it created and closed.
+func countingClients(created, closed *atomic.Int32) func(map[string][]byte, string, ...Option) DB {
+	return func(map[string][]byte, string, ...Option) DB {
+		created.Add(1)
+		return &MockDB{CloseFunc: func() { closed.Add(1) }}
+	}
+}
+
+// eventually fails the test unless cond becomes true within a second.
+func eventually(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatal(msg)
+}
+
+func TestPoolShares(t *testing.T) {
+	var created, closed atomic.Int32
+	p := NewPool(countingClients(&created, &closed), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p.New(nil, "", WithSharedSession(ctx, "pc/a"))
+	p.New(nil, "", WithSharedSession(ctx, "pc/a"))
+	if got := created.Load(); got != 1 {
+		t.Errorf("New(...): clients of the same key should share a session, created %d", got)
+	}
+
+	p.New(nil, "", WithSharedSession(ctx, "pc/b"))
+	p.New(nil, "")
+	if got := created.Load(); got != 3 {
+		t.Errorf("New(...): clients of other keys or without one should not share a session, created %d", got)
+	}
+
+	p.New(nil, "", WithSharedSession(ctx, "pc/a")).Close()
+	if got := closed.Load(); got != 0 {
+		t.Errorf("Close(): shared sessions should not be closed by their clients, closed %d", got)
+	}
+}
+
+func TestPoolExpires(t *testing.T) {
+	var created, closed atomic.Int32
+	p := NewPool(countingClients(&created, &closed), 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.New(nil, "", WithSharedSession(ctx, "pc/a"))
+
+	time.Sleep(50 * time.Millisecond)
+	if got := closed.Load(); got != 0 {
+		t.Fatalf("expire(): referenced sessions should not be closed, closed %d", got)
+	}
+
+	cancel()
+	eventually(t, func() bool { return closed.Load() == 1 }, "expire(): idle sessions should be closed")
+
+	p.New(nil, "", WithSharedSession(context.Background(), "pc/a"))
+	if got := created.Load(); got != 2 {
+		t.Errorf("New(...): expired sessions should not be shared, created %d", got)
+	}
+}
+
+func TestPoolInvalidate(t *testing.T) {
+	var created, closed atomic.Int32
+	p := NewPool(countingClients(&created, &closed), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.New(nil, "", WithSharedSession(ctx, "pc/a"))
+	p.New(nil, "", WithSharedSession(context.Background(), "other/a"))
+
+	p.Invalidate("pc")
+	time.Sleep(10 * time.Millisecond)
+	if got := closed.Load(); got != 0 {
+		t.Fatalf("Invalidate(...): referenced sessions should not be closed, closed %d", got)
+	}
+
+	p.New(nil, "", WithSharedSession(context.Background(), "pc/a"))
+	if got := created.Load(); got != 3 {
+		t.Errorf("New(...): invalidated sessions should not be shared, created %d", got)
+	}
+
+	cancel()
+	eventually(t, func() bool { return closed.Load() == 1 }, "release(): invalidated sessions should be closed once unreferenced")
+}
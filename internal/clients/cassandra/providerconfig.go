@@ -0,0 +1,685 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/connection/store"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+// defaultPort is the port of the native transport of Cassandra.
+const defaultPort = 9042
+
+// Annotations that override the consistency levels of the ProviderConfig of a
+// managed resource, for example to change schema at ALL but roles and grants
+// at LOCAL_QUORUM.
+const (
+	// AnnotationKeyConsistency overrides the consistency level of the
+	// statements that create, update and delete the resource.
+	AnnotationKeyConsistency = "cassandra.crossplane.io/consistency"
+
+	// AnnotationKeyReadConsistency overrides the consistency level of the
+	// queries that observe the resource.
+	AnnotationKeyReadConsistency = "cassandra.crossplane.io/read-consistency"
+)
+
+// Compression algorithms of frames.
+const (
+	compressionLZ4    = "LZ4"
+	compressionSnappy = "Snappy"
+)
+
+// credentialsHostKey is the key of the host in credentials secrets that hold
+// the credentials as separate keys, as produced by cass-operator and K8ssandra.
+const credentialsHostKey = "host"
+
+const (
+	errNoCredentials          = "credentials must be a JSON document, or a secret holding the username and password keys"
+	errGetCredsSecret         = "cannot get credentials secret"
+	errNoFs                   = "fs must be set when the credentials source is Filesystem"
+	errNoEnv                  = "env or keys must be set when the credentials source is Environment"
+	errNoRoleRef              = "roleRef must be set when the credentials source is Role"
+	errGetRole                = "cannot get Role"
+	errNoDatacenterRef        = "cassandraDatacenterRef must be set when the credentials source is CassandraDatacenter"
+	errNoRoleConnectionSecret = "Role does not write a connection secret"
+	errNoStoreRef             = "externalSecretStoreRef must be set when the credentials source is ExternalSecretStore"
+	errGetStoreConfig         = "cannot get StoreConfig"
+	errConnectStore           = "cannot connect to secret store"
+	errReadStore              = "cannot read secret from secret store"
+	errResolveService         = "cannot resolve contact points of service"
+	errNoEndpoints            = "service has no ready endpoints"
+	errResolveSRV             = "cannot resolve contact points of SRV record"
+	errReadConsistency        = "cannot parse read consistency"
+	errConsistency            = "cannot parse consistency"
+	errSerial                 = "cannot parse serial consistency"
+	errGetCA                  = "cannot get CA certificate"
+	errParseCA                = "cannot parse CA certificate"
+	errGetClientCert          = "cannot get client certificate"
+	errGetClientKey           = "cannot get client key"
+	errParseClientCert        = "cannot parse client certificate and key"
+)
+
+// ProviderCredentials returns the credentials of a ProviderConfig. The
+// credentials are either a JSON document, or separate keys of the referenced
+// secret, named as configured or else username, password, host and port. When
+// the source is a Role they are read from its connection secret, and when it
+// is ExternalSecretStore from a secret of an external secret store. When the
+// source is Filesystem the path may also be a directory holding a file per
+// key, as secrets are mounted, and when it is Environment with keys set the
+// keys name environment variables.
+func ProviderCredentials(ctx context.Context, kube client.Client, cd apisv1alpha1.ProviderCredentials) (map[string][]byte, error) {
+	switch cd.Source {
+	case apisv1alpha1.CredentialsSourceRole:
+		return roleCredentials(ctx, kube, cd.RoleRef)
+	case apisv1alpha1.CredentialsSourceCassandraDatacenter:
+		return datacenterCredentials(ctx, kube, cd.CassandraDatacenterRef)
+	case apisv1alpha1.CredentialsSourceExternalSecretStore:
+		ref := cd.ExternalSecretStoreRef
+		if ref == nil {
+			return nil, errors.New(errNoStoreRef)
+		}
+		data, err := storeSecret(ctx, kube, ref.StoreConfigRef.Name, store.ScopedName{Name: ref.Name, Scope: ref.Scope})
+		if err != nil {
+			return nil, err
+		}
+		return keyedCredentials(func(k string) []byte { return data[k] }, cd.Keys)
+	case xpv1.CredentialsSourceFilesystem:
+		if cd.Fs == nil {
+			return nil, errors.New(errNoFs)
+		}
+		if fi, err := os.Stat(cd.Fs.Path); err == nil && fi.IsDir() {
+			return keyedCredentials(fileKeys(cd.Fs.Path), cd.Keys)
+		}
+	case xpv1.CredentialsSourceEnvironment:
+		if cd.Keys != nil {
+			return keyedCredentials(func(k string) []byte { return []byte(os.Getenv(k)) }, cd.Keys)
+		}
+		if cd.Env == nil {
+			return nil, errors.New(errNoEnv)
+		}
+	}
+
+	if cd.Keys == nil {
+		data, err := resource.CommonCredentialExtractor(ctx, cd.Source, kube, cd.CommonCredentialSelectors)
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]string
+		if err := json.Unmarshal(data, &m); err == nil {
+			creds := make(map[string][]byte, len(m))
+			for k, v := range m {
+				creds[k] = []byte(v)
+			}
+			return creds, nil
+		}
+	}
+
+	if cd.Source != xpv1.CredentialsSourceSecret || cd.SecretRef == nil {
+		return nil, errors.New(errNoCredentials)
+	}
+	return secretCredentials(ctx, kube, cd.SecretRef.Name, cd.SecretRef.Namespace, cd.Keys)
+}
+
+// roleCredentials returns the credentials published to the connection secret
+// of the referenced Role, either to Kubernetes or to an external secret store.
+func roleCredentials(ctx context.Context, kube client.Client, ref *xpv1.Reference) (map[string][]byte, error) {
+	if ref == nil {
+		return nil, errors.New(errNoRoleRef)
+	}
+	r := &v1alpha1.Role{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, r); err != nil {
+		return nil, errors.Wrap(err, errGetRole)
+	}
+	key := func(k string) string {
+		if mapped, ok := r.Spec.ForProvider.ConnectionSecretKeys[k]; ok {
+			return mapped
+		}
+		return k
+	}
+	keys := &apisv1alpha1.CredentialKeys{
+		Username: key(xpv1.ResourceCredentialsSecretUserKey),
+		Password: key(xpv1.ResourceCredentialsSecretPasswordKey),
+		Endpoint: key(xpv1.ResourceCredentialsSecretEndpointKey),
+		Port:     key(xpv1.ResourceCredentialsSecretPortKey),
+	}
+	if cs := r.GetWriteConnectionSecretToReference(); cs != nil {
+		return secretCredentials(ctx, kube, cs.Name, cs.Namespace, keys)
+	}
+	if p := r.GetPublishConnectionDetailsTo(); p != nil && p.SecretStoreConfigRef != nil {
+		data, err := storeSecret(ctx, kube, p.SecretStoreConfigRef.Name, store.ScopedName{Name: p.Name})
+		if err != nil {
+			return nil, err
+		}
+		return keyedCredentials(func(k string) []byte { return data[k] }, keys)
+	}
+	return nil, errors.New(errNoRoleConnectionSecret)
+}
+
+// storeSecret reads a secret of the secret store configured by the named
+// StoreConfig. Secrets without a scope are read from its default scope.
+func storeSecret(ctx context.Context, kube client.Client, config string, n store.ScopedName) (map[string][]byte, error) {
+	sc := &apisv1alpha1.StoreConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: config}, sc); err != nil {
+		return nil, errors.Wrap(err, errGetStoreConfig)
+	}
+	ss, err := connection.RuntimeStoreBuilder(ctx, kube, nil, sc.GetStoreConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectStore)
+	}
+	s := &store.Secret{}
+	if err := ss.ReadKeyValues(ctx, n, s); err != nil {
+		return nil, errors.Wrap(err, errReadStore)
+	}
+	return s.Data, nil
+}
+
+// datacenterCredentials returns the credentials of the superuser of a
+// datacenter provisioned by cass-operator, and the service of the datacenter
+// as its endpoint.
+func datacenterCredentials(ctx context.Context, kube client.Client, ref *apisv1alpha1.CassandraDatacenterReference) (map[string][]byte, error) {
+	if ref == nil {
+		return nil, errors.New(errNoDatacenterRef)
+	}
+	creds, err := secretCredentials(ctx, kube, SuperuserSecretName(ref), ref.Namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+	service := fmt.Sprintf("%s-%s-service.%s.svc", kubernetesName(ref.ClusterName), kubernetesName(ref.Name), ref.Namespace)
+	creds[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(service)
+	creds[xpv1.ResourceCredentialsSecretPortKey] = []byte(strconv.Itoa(defaultPort))
+	return creds, nil
+}
+
+// SuperuserSecretName returns the name of the secret holding the credentials
+// of the superuser of a datacenter provisioned by cass-operator.
+func SuperuserSecretName(ref *apisv1alpha1.CassandraDatacenterReference) string {
+	if ref.SuperuserSecretName != "" {
+		return ref.SuperuserSecretName
+	}
+	return kubernetesName(ref.ClusterName) + "-superuser"
+}
+
+// kubernetesName returns the name cass-operator derives from a cluster or
+// datacenter name for the resources it creates.
+func kubernetesName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "-")
+}
+
+// secretCredentials returns the credentials held by separate keys of the
+// secret.
+func secretCredentials(ctx context.Context, kube client.Client, name, namespace string, k *apisv1alpha1.CredentialKeys) (map[string][]byte, error) {
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, s); err != nil {
+		return nil, errors.Wrap(err, errGetCredsSecret)
+	}
+	return keyedCredentials(func(k string) []byte { return s.Data[k] }, k)
+}
+
+// keyedCredentials returns the credentials held by separate keys, which are
+// looked up with the supplied function.
+func keyedCredentials(lookup func(key string) []byte, k *apisv1alpha1.CredentialKeys) (map[string][]byte, error) {
+	keys := credentialKeys(k)
+	creds := map[string][]byte{
+		xpv1.ResourceCredentialsSecretUserKey:     lookup(keys.Username),
+		xpv1.ResourceCredentialsSecretPasswordKey: lookup(keys.Password),
+		xpv1.ResourceCredentialsSecretEndpointKey: lookup(keys.Endpoint),
+		xpv1.ResourceCredentialsSecretPortKey:     lookup(keys.Port),
+	}
+	if len(creds[xpv1.ResourceCredentialsSecretUserKey]) == 0 || len(creds[xpv1.ResourceCredentialsSecretPasswordKey]) == 0 {
+		return nil, errors.New(errNoCredentials)
+	}
+	if h := lookup(credentialsHostKey); len(h) > 0 && k == nil {
+		creds[xpv1.ResourceCredentialsSecretEndpointKey] = h
+	}
+	return creds, nil
+}
+
+// fileKeys looks up keys as the files of a directory, trimming the trailing
+// newline that files written by hand or by secret stores often end with.
+func fileKeys(dir string) func(key string) []byte {
+	return func(key string) []byte {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.Base(key)))
+		if err != nil {
+			return nil
+		}
+		return bytes.TrimRight(b, "\r\n")
+	}
+}
+
+// credentialKeys returns the keys of the credentials secret, defaulting those
+// that are not configured.
+func credentialKeys(k *apisv1alpha1.CredentialKeys) apisv1alpha1.CredentialKeys {
+	keys := apisv1alpha1.CredentialKeys{
+		Username: xpv1.ResourceCredentialsSecretUserKey,
+		Password: xpv1.ResourceCredentialsSecretPasswordKey,
+		Endpoint: xpv1.ResourceCredentialsSecretEndpointKey,
+		Port:     xpv1.ResourceCredentialsSecretPortKey,
+	}
+	if k == nil {
+		return keys
+	}
+	if k.Username != "" {
+		keys.Username = k.Username
+	}
+	if k.Password != "" {
+		keys.Password = k.Password
+	}
+	if k.Endpoint != "" {
+		keys.Endpoint = k.Endpoint
+	}
+	if k.Port != "" {
+		keys.Port = k.Port
+	}
+	return keys
+}
+
+// ProviderConfigOptions returns the client options configured by the spec of
+// a ProviderConfig.
+func ProviderConfigOptions(ctx context.Context, kube client.Client, spec apisv1alpha1.ProviderConfigSpec) ([]Option, error) {
+	var opts []Option
+
+	// Amazon Keyspaces only supports LOCAL_QUORUM for writes.
+	if spec.Dialect == apisv1alpha1.DialectAmazonKeyspaces {
+		opts = append(opts, WithConsistency(gocql.LocalQuorum))
+	}
+
+	switch {
+	case len(spec.ContactPoints) > 0:
+		port := defaultPort
+		if spec.Port != nil {
+			port = *spec.Port
+		}
+		opts = append(opts, WithContactPoints(spec.ContactPoints, port))
+	case spec.ServiceRef != nil:
+		hosts, port, err := serviceEndpoints(ctx, kube, spec.ServiceRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errResolveService)
+		}
+		opts = append(opts, WithContactPoints(hosts, port))
+	case spec.SRVRecord != nil && *spec.SRVRecord != "":
+		hosts, port, err := srvTargets(ctx, resolver, *spec.SRVRecord)
+		if err != nil {
+			return nil, errors.Wrap(err, errResolveSRV)
+		}
+		opts = append(opts, WithContactPoints(hosts, port))
+	}
+
+	if at := spec.AddressTranslation; at != nil {
+		switch at.Mode {
+		case apisv1alpha1.AddressTranslationContactPoint:
+			opts = append(opts, WithContactPointsOnly())
+		case apisv1alpha1.AddressTranslationStatic:
+			opts = append(opts, WithAddressTranslation(at.Mappings))
+		}
+	}
+
+	if spec.LocalDatacenter != nil && *spec.LocalDatacenter != "" {
+		opts = append(opts, WithLocalDatacenter(*spec.LocalDatacenter))
+	}
+
+	if spec.Consistency != nil {
+		c, err := gocql.ParseConsistencyWrapper(*spec.Consistency)
+		if err != nil {
+			return nil, errors.Wrap(err, errConsistency)
+		}
+		opts = append(opts, WithConsistency(c))
+	}
+
+	if spec.SerialConsistency != nil {
+		var c gocql.SerialConsistency
+		if err := c.UnmarshalText([]byte(*spec.SerialConsistency)); err != nil {
+			return nil, errors.Wrap(err, errSerial)
+		}
+		opts = append(opts, WithSerialConsistency(c))
+	}
+
+	if spec.ProtocolVersion != nil {
+		opts = append(opts, WithProtocolVersion(*spec.ProtocolVersion))
+	}
+
+	if spec.CQLVersion != nil && *spec.CQLVersion != "" {
+		opts = append(opts, WithCQLVersion(*spec.CQLVersion))
+	}
+
+	if spec.ConnectTimeout != nil {
+		opts = append(opts, WithConnectTimeout(spec.ConnectTimeout.Duration))
+	}
+
+	if spec.RequestTimeout != nil {
+		opts = append(opts, WithRequestTimeout(spec.RequestTimeout.Duration))
+	}
+
+	if spec.OperationTimeout != nil {
+		opts = append(opts, WithOperationTimeout(spec.OperationTimeout.Duration))
+	}
+
+	if AsynchronousSchemaChanges(spec) {
+		opts = append(opts, WithoutSchemaAgreement())
+	}
+
+	if spec.PageSize != nil {
+		opts = append(opts, WithPageSize(*spec.PageSize))
+	}
+
+	if spec.Keepalive != nil {
+		opts = append(opts, WithKeepalive(spec.Keepalive.Duration))
+	}
+
+	if r := spec.Reconnection; r != nil {
+		opts = append(opts, reconnectionOptions(r)...)
+	}
+
+	if r := spec.Retry; r != nil {
+		opts = append(opts, WithRetryPolicy(retryPolicy(r)))
+	}
+
+	if spec.Tracing || TraceAll {
+		opts = append(opts, WithTracing(TraceLogger))
+	}
+
+	if QueryLogger != nil {
+		opts = append(opts, WithQueryLogging(QueryLogger))
+	}
+
+	if cb := spec.CircuitBreaker; cb != nil {
+		failures, coolDown := 5, 30*time.Second
+		if cb.Failures != nil {
+			failures = *cb.Failures
+		}
+		if cb.CoolDown != nil {
+			coolDown = cb.CoolDown.Duration
+		}
+		opts = append(opts, WithCircuitBreaker(failures, coolDown))
+	}
+
+	if se := spec.SpeculativeExecution; se != nil {
+		p := &gocql.SimpleSpeculativeExecution{NumAttempts: 2, TimeoutDelay: 100 * time.Millisecond}
+		if se.Attempts != nil {
+			p.NumAttempts = *se.Attempts
+		}
+		if se.Delay != nil {
+			p.TimeoutDelay = se.Delay.Duration
+		}
+		opts = append(opts, WithSpeculativeExecution(p))
+	}
+
+	if spec.ReadConsistency != nil {
+		c, err := gocql.ParseConsistencyWrapper(*spec.ReadConsistency)
+		if err != nil {
+			return nil, errors.Wrap(err, errReadConsistency)
+		}
+		opts = append(opts, WithReadConsistency(c))
+	}
+
+	if spec.Compression != nil {
+		switch *spec.Compression {
+		case compressionLZ4:
+			opts = append(opts, WithCompression(LZ4Compressor{}))
+		case compressionSnappy:
+			opts = append(opts, WithCompression(gocql.SnappyCompressor{}))
+		}
+	}
+
+	if sc := spec.Scylla; sc != nil {
+		if sc.ShardAwarePort != nil {
+			opts = append(opts, WithShardAwarePort(*sc.ShardAwarePort))
+		}
+		if sc.ConnectionsPerHost != nil {
+			opts = append(opts, WithConnectionsPerHost(*sc.ConnectionsPerHost))
+		}
+		if sc.TokenAwareRouting == nil || *sc.TokenAwareRouting {
+			opts = append(opts, WithTokenAwareRouting())
+		}
+	}
+
+	if spec.TLS != nil && spec.TLS.Enabled {
+		var ca []byte
+		if ref := spec.TLS.CASecretRef; ref != nil {
+			v, err := secretKey(ctx, kube, ref)
+			if err != nil {
+				return nil, errors.Wrap(err, errGetCA)
+			}
+			if !x509.NewCertPool().AppendCertsFromPEM(v) {
+				return nil, errors.New(errParseCA)
+			}
+			ca = v
+		}
+		opts = append(opts, WithTLS(ca))
+
+		if spec.TLS.ClientCertSecretRef != nil && spec.TLS.ClientKeySecretRef != nil {
+			cert, err := secretKey(ctx, kube, spec.TLS.ClientCertSecretRef)
+			if err != nil {
+				return nil, errors.Wrap(err, errGetClientCert)
+			}
+			key, err := secretKey(ctx, kube, spec.TLS.ClientKeySecretRef)
+			if err != nil {
+				return nil, errors.Wrap(err, errGetClientKey)
+			}
+			pair, err := tls.X509KeyPair(cert, key)
+			if err != nil {
+				return nil, errors.Wrap(err, errParseClientCert)
+			}
+			opts = append(opts, WithClientCertificate(pair))
+		}
+
+		if isTrue(spec.TLS.InsecureSkipVerify) {
+			opts = append(opts, WithInsecureSkipVerify())
+		} else if isTrue(spec.TLS.DisableHostnameVerification) {
+			opts = append(opts, WithoutHostnameVerification())
+		}
+	}
+
+	return opts, nil
+}
+
+// serviceEndpoints returns the addresses of the ready endpoints of a Service,
+// and the port of the native transport.
+func serviceEndpoints(ctx context.Context, kube client.Client, ref *apisv1alpha1.ServiceReference) ([]string, int, error) {
+	l := &discoveryv1.EndpointSliceList{}
+	if err := kube.List(ctx, l, client.InNamespace(ref.Namespace), client.MatchingLabels{discoveryv1.LabelServiceName: ref.Name}); err != nil {
+		return nil, 0, err
+	}
+
+	var hosts []string
+	port := 0
+	for _, s := range l.Items {
+		p := slicePort(s.Ports, ref.PortName)
+		if p == 0 {
+			continue
+		}
+		for _, e := range s.Endpoints {
+			if e.Conditions.Ready != nil && !*e.Conditions.Ready {
+				continue
+			}
+			hosts = append(hosts, e.Addresses...)
+			port = p
+		}
+	}
+	if len(hosts) == 0 {
+		return nil, 0, errors.New(errNoEndpoints)
+	}
+	return hosts, port, nil
+}
+
+// An srvResolver looks up DNS SRV records.
+type srvResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// resolver resolves the SRV records of ProviderConfigs.
+var resolver srvResolver = net.DefaultResolver
+
+// srvTargets returns the targets of a DNS SRV record and the port they share.
+// Targets that do not share a port are each returned with their own, and the
+// returned port is 0.
+func srvTargets(ctx context.Context, r srvResolver, name string) ([]string, int, error) {
+	_, records, err := r.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, 0, err
+	}
+	shared := len(records) > 0
+	for _, rec := range records {
+		shared = shared && rec.Port == records[0].Port
+	}
+	hosts := make([]string, 0, len(records))
+	for _, rec := range records {
+		h := strings.TrimSuffix(rec.Target, ".")
+		if !shared {
+			h = net.JoinHostPort(h, strconv.Itoa(int(rec.Port)))
+		}
+		hosts = append(hosts, h)
+	}
+	if !shared {
+		return hosts, 0, nil
+	}
+	return hosts, int(records[0].Port), nil
+}
+
+// slicePort returns the number of the named port of an EndpointSlice, or of
+// its first port when name is empty. It returns 0 when there is no such port.
+func slicePort(ports []discoveryv1.EndpointPort, name string) int {
+	for _, p := range ports {
+		if p.Port == nil {
+			continue
+		}
+		if name == "" || (p.Name != nil && *p.Name == name) {
+			return int(*p.Port)
+		}
+	}
+	return 0
+}
+
+// ResourceOptions returns the options a managed resource overrides those of
+// its ProviderConfig with through its annotations.
+func ResourceOptions(o metav1.Object) ([]Option, error) {
+	var opts []Option
+	if v, ok := o.GetAnnotations()[AnnotationKeyConsistency]; ok {
+		c, err := gocql.ParseConsistencyWrapper(v)
+		if err != nil {
+			return nil, errors.Wrap(err, errConsistency)
+		}
+		opts = append(opts, WithConsistency(c))
+	}
+	if v, ok := o.GetAnnotations()[AnnotationKeyReadConsistency]; ok {
+		c, err := gocql.ParseConsistencyWrapper(v)
+		if err != nil {
+			return nil, errors.Wrap(err, errReadConsistency)
+		}
+		opts = append(opts, WithReadConsistency(c))
+	}
+	return opts, nil
+}
+
+// AsynchronousSchemaChanges reports whether the managed resources of a
+// ProviderConfig are created without waiting for the schema of its cluster to
+// settle. Amazon Keyspaces changes schema asynchronously, so it does by
+// default.
+func AsynchronousSchemaChanges(spec apisv1alpha1.ProviderConfigSpec) bool {
+	if spec.AsynchronousSchemaChanges != nil {
+		return *spec.AsynchronousSchemaChanges
+	}
+	return spec.Dialect == apisv1alpha1.DialectAmazonKeyspaces
+}
+
+// AwaitSchemaSettled reports whether the managed resources of a ProviderConfig
+// are not ready until the schema of its cluster has settled, which they are
+// not when its schema changes are asynchronous. Amazon Keyspaces has no schema
+// versions to compare; the status of its tables in system_schema_mcs is
+// polled instead.
+func AwaitSchemaSettled(spec apisv1alpha1.ProviderConfigSpec) bool {
+	return AsynchronousSchemaChanges(spec) && spec.Dialect != apisv1alpha1.DialectAmazonKeyspaces
+}
+
+// reconnectionOptions returns the options that configure how nodes are
+// reconnected to, defaulting them as gocql does.
+func reconnectionOptions(r *apisv1alpha1.Reconnection) []Option {
+	var opts []Option
+	if r.Interval != nil {
+		opts = append(opts, WithReconnectInterval(r.Interval.Duration))
+	}
+
+	retries, initial, maxInterval := 3, time.Second, time.Minute
+	if r.MaxRetries != nil {
+		retries = *r.MaxRetries
+	}
+	if r.InitialInterval != nil {
+		initial = r.InitialInterval.Duration
+	}
+	if r.MaxInterval != nil {
+		maxInterval = r.MaxInterval.Duration
+	}
+	if r.Policy == apisv1alpha1.ReconnectionExponential {
+		return append(opts, WithReconnectionPolicy(&gocql.ExponentialReconnectionPolicy{MaxRetries: retries, InitialInterval: initial, MaxInterval: maxInterval}))
+	}
+	return append(opts, WithReconnectionPolicy(&gocql.ConstantReconnectionPolicy{MaxRetries: retries, Interval: initial}))
+}
+
+// retryPolicy returns the exponential backoff retry policy of queries,
+// defaulting it as gocql does.
+func retryPolicy(r *apisv1alpha1.Retry) gocql.RetryPolicy {
+	p := &gocql.ExponentialBackoffRetryPolicy{NumRetries: 3, Min: 100 * time.Millisecond, Max: 10 * time.Second}
+	if r.MaxRetries != nil {
+		p.NumRetries = *r.MaxRetries
+	}
+	if r.MinBackoff != nil {
+		p.Min = r.MinBackoff.Duration
+	}
+	if r.MaxBackoff != nil {
+		p.Max = r.MaxBackoff.Duration
+	}
+	return p
+}
+
+// isTrue reports whether the optional flag is set to true.
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+// secretKey returns the value of the referenced secret key.
+func secretKey(ctx context.Context, kube client.Client, ref *xpv1.SecretKeySelector) ([]byte, error) {
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+		return nil, err
+	}
+	return s.Data[ref.Key], nil
+}
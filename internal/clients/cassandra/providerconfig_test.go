@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+type mockResolver struct {
+	records []*net.SRV
+	err     error
+}
+
+func (r mockResolver) LookupSRV(_ context.Context, _, _, _ string) (string, []*net.SRV, error) {
+	return "", r.records, r.err
+}
+
+func TestSRVTargets(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		hosts []string
+		port  int
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		r      srvResolver
+		want   want
+	}{
+		"SharedPort": {
+			reason: "Should return the targets without their port, and the port they share",
+			r: mockResolver{records: []*net.SRV{
+				{Target: "node1.example.org.", Port: 9142},
+				{Target: "node2.example.org.", Port: 9142},
+			}},
+			want: want{hosts: []string{"node1.example.org", "node2.example.org"}, port: 9142},
+		},
+		"DistinctPorts": {
+			reason: "Should return each target with its own port when they do not share one",
+			r: mockResolver{records: []*net.SRV{
+				{Target: "node1.example.org.", Port: 9042},
+				{Target: "node2.example.org.", Port: 9043},
+			}},
+			want: want{hosts: []string{"node1.example.org:9042", "node2.example.org:9043"}},
+		},
+		"LookupError": {
+			reason: "Should return errors looking up the record",
+			r:      mockResolver{err: errBoom},
+			want:   want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hosts, port, err := srvTargets(context.Background(), tc.r, "_cql._tcp.cassandra.example.org")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nsrvTargets(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.hosts, hosts); diff != "" {
+				t.Errorf("\n%s\nsrvTargets(...): -want hosts, +got hosts:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.port, port); diff != "" {
+				t.Errorf("\n%s\nsrvTargets(...): -want port, +got port:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigOptionsSRVRecord(t *testing.T) {
+	type want struct {
+		contactPoints string
+		port          []byte
+	}
+
+	cases := map[string]struct {
+		reason  string
+		records []*net.SRV
+		want    want
+	}{
+		"SharedPort": {
+			reason: "Should connect to the targets of the record and publish the port they share",
+			records: []*net.SRV{
+				{Target: "node1.example.org.", Port: 9142},
+				{Target: "node2.example.org.", Port: 9142},
+			},
+			want: want{contactPoints: "node1.example.org:9142,node2.example.org:9142", port: []byte("9142")},
+		},
+		"DistinctPorts": {
+			reason: "Should connect to each target on its own port and publish no port",
+			records: []*net.SRV{
+				{Target: "node1.example.org.", Port: 9042},
+				{Target: "node2.example.org.", Port: 9043},
+			},
+			want: want{contactPoints: "node1.example.org:9042,node2.example.org:9043"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func(r srvResolver) { resolver = r }(resolver)
+			resolver = mockResolver{records: tc.records}
+
+			record := "_cql._tcp.cassandra.example.org"
+			opts, err := ProviderConfigOptions(context.Background(), nil, apisv1alpha1.ProviderConfigSpec{SRVRecord: &record})
+			if err != nil {
+				t.Fatalf("ProviderConfigOptions(...): %v", err)
+			}
+			cd := New(map[string][]byte{}, "", opts...).GetConnectionDetails("user", "pass")
+			if diff := cmp.Diff(tc.want.contactPoints, string(cd[ConnectionSecretContactPointsKey])); diff != "" {
+				t.Errorf("\n%s\nGetConnectionDetails(...): -want contact points, +got contact points:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.port, cd[xpv1.ResourceCredentialsSecretPortKey]); diff != "" {
+				t.Errorf("\n%s\nGetConnectionDetails(...): -want port, +got port:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAwaitSchemaSettled(t *testing.T) {
+	async := true
+
+	cases := map[string]struct {
+		reason string
+		spec   apisv1alpha1.ProviderConfigSpec
+		want   bool
+	}{
+		"Synchronous": {
+			reason: "Resources should not wait for the schema of clusters that agree on schema changes when they are made",
+		},
+		"Asynchronous": {
+			reason: "Resources should wait for the schema of clusters whose schema changes are asynchronous to settle",
+			spec:   apisv1alpha1.ProviderConfigSpec{AsynchronousSchemaChanges: &async},
+			want:   true,
+		},
+		"AmazonKeyspaces": {
+			reason: "Resources should not compare schema versions on Amazon Keyspaces, whose schema changes are asynchronous",
+			spec:   apisv1alpha1.ProviderConfigSpec{Dialect: apisv1alpha1.DialectAmazonKeyspaces},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := AwaitSchemaSettled(tc.spec); got != tc.want {
+				t.Errorf("\n%s\nAwaitSchemaSettled(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+
+	"github.com/gocql/gocql"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// QueryLogger is the logger every statement and query is logged to. They are
+// not logged when it is nil.
+var QueryLogger logging.Logger
+
+// WithQueryLogging logs every execution of a statement or query to the
+// supplied logger, with its duration, the node it was sent to and its result.
+// The values bound to it are elided, and the sensitive values embedded in
+// statements are redacted. Failed connection attempts to nodes are logged too.
+func WithQueryLogging(log logging.Logger) Option {
+	return func(cfg *config) {
+		cfg.queryLog = log
+	}
+}
+
+// A queryLogger logs the executions of a statement or query.
+type queryLogger struct {
+	log       logging.Logger
+	sensitive []string
+}
+
+// ObserveQuery logs an execution of the statement or query. Each attempt and
+// speculative execution is logged separately.
+func (l queryLogger) ObserveQuery(_ context.Context, q gocql.ObservedQuery) {
+	kv := []interface{}{
+		"statement", redactString(q.Statement, l.sensitive...),
+		"values", len(q.Values),
+		"duration", q.End.Sub(q.Start),
+		"attempt", q.Attempt,
+	}
+	if q.Keyspace != "" {
+		kv = append(kv, "keyspace", q.Keyspace)
+	}
+	if q.Host != nil {
+		kv = append(kv, "host", q.Host.HostnameAndPort())
+	}
+	if q.Err != nil {
+		l.log.Info("CQL statement failed", append(kv, "error", Redact(q.Err, l.sensitive...))...)
+		return
+	}
+	l.log.Info("Executed CQL statement", append(kv, "rows", q.Rows)...)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// recordingLogger records the messages and key/value pairs it is sent.
+type recordingLogger struct {
+	logging.Logger
+	msg string
+	kv  []interface{}
+}
+
+func (l *recordingLogger) Info(msg string, kv ...interface{}) {
+	l.msg, l.kv = msg, kv
+}
+
+func TestQueryLogger(t *testing.T) {
+	start := time.Now()
+
+	cases := map[string]struct {
+		reason    string
+		q         gocql.ObservedQuery
+		sensitive []string
+		wantMsg   string
+		wantKV    []interface{}
+	}{
+		"Succeeded": {
+			reason: "The statement, the number of values bound to it and its result should be logged.",
+			q: gocql.ObservedQuery{
+				Statement: "SELECT role FROM system_auth.roles WHERE role = ?",
+				Values:    []interface{}{"admin"},
+				Start:     start,
+				End:       start.Add(time.Millisecond),
+				Rows:      1,
+			},
+			wantMsg: "Executed CQL statement",
+			wantKV:  []interface{}{"statement", "SELECT role FROM system_auth.roles WHERE role = ?", "values", 1, "duration", time.Millisecond, "attempt", 0, "rows", 1},
+		},
+		"FailedRedacted": {
+			reason: "Sensitive values should be redacted from both the statement and its error.",
+			q: gocql.ObservedQuery{
+				Keyspace:  "ks",
+				Statement: "CREATE ROLE \"admin\" WITH PASSWORD = 's3cr''et'",
+				Start:     start,
+				End:       start,
+				Err:       errors.New("cannot create role with PASSWORD = 's3cr''et'"),
+			},
+			sensitive: []string{"s3cr'et"},
+			wantMsg:   "CQL statement failed",
+			wantKV:    []interface{}{"statement", "CREATE ROLE \"admin\" WITH PASSWORD = '[REDACTED]'", "values", 0, "duration", time.Duration(0), "attempt", 0, "keyspace", "ks", "error", Redact(errors.New("cannot create role with PASSWORD = '[REDACTED]'"))},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			log := &recordingLogger{}
+			queryLogger{log: log, sensitive: tc.sensitive}.ObserveQuery(context.Background(), tc.q)
+			if diff := cmp.Diff(tc.wantMsg, log.msg); diff != "" {
+				t.Errorf("\n%s\nObserveQuery(...): -want message, +got message:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantKV, log.kv, cmp.Comparer(func(a, b error) bool { return a.Error() == b.Error() })); diff != "" {
+				t.Errorf("\n%s\nObserveQuery(...): -want key/values, +got key/values:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+const errRateLimit = "cannot wait for the rate limit of the ProviderConfig"
+
+// RateLimits are the rate limits statements and queries are sent to clusters
+// at.
+var RateLimits = NewRateLimiters()
+
+// RateLimiters keeps a rate limiter per ProviderConfig, so that the clients
+// of its managed resources share it whether or not they share a session.
+type RateLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*limiter
+}
+
+// NewRateLimiters returns an empty set of rate limiters.
+func NewRateLimiters() *RateLimiters {
+	return &RateLimiters{limiters: map[string]*limiter{}}
+}
+
+// For returns an option that limits the rate at which a client sends
+// statements and queries to the rate limit of the supplied ProviderConfig.
+// The limits of a ProviderConfig that is already limited are updated to its
+// spec. It has no effect on ProviderConfigs without a rate limit.
+func (r *RateLimiters) For(pc *apisv1alpha1.ProviderConfig) Option {
+	rl := pc.Spec.RateLimit
+	if rl == nil {
+		return func(_ *config) {}
+	}
+
+	burst := 1
+	if rl.Burst != nil {
+		burst = *rl.Burst
+	}
+	statements, queries := rate.Inf, rate.Inf
+	if rl.Statements != nil {
+		statements = rate.Limit(*rl.Statements)
+	}
+	if rl.Queries != nil {
+		queries = rate.Limit(*rl.Queries)
+	}
+
+	r.mu.Lock()
+	l, ok := r.limiters[pc.GetName()]
+	if !ok {
+		l = &limiter{statements: rate.NewLimiter(statements, burst), queries: rate.NewLimiter(queries, burst)}
+		r.limiters[pc.GetName()] = l
+	}
+	r.mu.Unlock()
+
+	l.statements.SetLimit(statements)
+	l.statements.SetBurst(burst)
+	l.queries.SetLimit(queries)
+	l.queries.SetBurst(burst)
+
+	return func(cfg *config) {
+		cfg.limiter = l
+	}
+}
+
+// A limiter limits the rate of statements and that of queries separately, so
+// that observing many managed resources does not starve changes to them.
+type limiter struct {
+	statements *rate.Limiter
+	queries    *rate.Limiter
+}
+
+// waitStatement blocks until a statement may be executed, or returns an error
+// if ctx is done first. A nil limiter never blocks.
+func (l *limiter) waitStatement(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	return errors.Wrap(l.statements.Wait(ctx), errRateLimit)
+}
+
+// waitQuery blocks until a query may be performed, or returns an error if ctx
+// is done first. A nil limiter never blocks.
+func (l *limiter) waitQuery(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	return errors.Wrap(l.queries.Wait(ctx), errRateLimit)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+func TestRateLimiters(t *testing.T) {
+	r := NewRateLimiters()
+	one, two := 1, 2
+
+	unlimited := &config{cluster: gocql.NewCluster()}
+	r.For(&apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "unlimited"}})(unlimited)
+	if unlimited.limiter != nil {
+		t.Fatalf("For(...): want no limiter for a ProviderConfig without a rate limit, got %v", unlimited.limiter)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "limited"},
+		Spec:       apisv1alpha1.ProviderConfigSpec{RateLimit: &apisv1alpha1.RateLimit{Statements: &one}},
+	}
+	a, b := &config{}, &config{}
+	r.For(pc)(a)
+	r.For(pc)(b)
+	if a.limiter == nil || a.limiter != b.limiter {
+		t.Fatalf("For(...): want the clients of a ProviderConfig to share a limiter")
+	}
+	if got := a.limiter.queries.Limit(); got != rate.Inf {
+		t.Errorf("For(...): want queries to be unlimited, got %v", got)
+	}
+
+	pc.Spec.RateLimit.Statements = &two
+	r.For(pc)(b)
+	if got := a.limiter.statements.Limit(); got != 2 {
+		t.Errorf("For(...): want the limit to be updated to the spec, got %v", got)
+	}
+
+	if err := a.limiter.waitStatement(context.Background()); err != nil {
+		t.Fatalf("waitStatement(...): want the burst to be allowed, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := a.limiter.waitStatement(ctx); err == nil {
+		t.Errorf("waitStatement(...): want an error once the deadline is too short to wait for the limit")
+	}
+	if err := a.limiter.waitQuery(ctx); err != nil {
+		t.Errorf("waitQuery(...): want unlimited queries not to wait, got %v", err)
+	}
+
+	var none *limiter
+	if err := none.waitStatement(ctx); err != nil {
+		t.Errorf("waitStatement(...): want nil limiter to never block, got %v", err)
+	}
+}
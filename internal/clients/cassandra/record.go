@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+)
+
+// ReasonExecutedStatement is the reason of the events that record the
+// statements executed for a managed resource.
+const ReasonExecutedStatement event.Reason = "ExecutedStatement"
+
+// recorded are the first keywords of the statements that change the schema or
+// access control of a cluster, which are recorded as events.
+var recorded = map[string]bool{
+	"CREATE":     true,
+	"ALTER":      true,
+	"DROP":       true,
+	"GRANT":      true,
+	"REVOKE":     true,
+	"RESTRICT":   true,
+	"UNRESTRICT": true,
+}
+
+// RecordStatements returns a client that records an event on the supplied
+// object for every statement it executes successfully that changes the schema
+// or access control of the cluster, so that what was changed and when can be
+// audited with kubectl describe. The sensitive values of the statements are
+// redacted. Statements are not recorded when the recorder is nil.
+func RecordStatements(db DB, r event.Recorder, o runtime.Object) DB {
+	if r == nil {
+		return db
+	}
+	return recordingDB{DB: db, recorder: r, object: o}
+}
+
+// A recordingDB is a client that records the statements it executes.
+type recordingDB struct {
+	DB
+	recorder event.Recorder
+	object   runtime.Object
+}
+
+// Exec executes a statement, and records it if it succeeded.
+func (db recordingDB) Exec(ctx context.Context, query string, args ...interface{}) error {
+	if err := db.DB.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+	db.record(query)
+	return nil
+}
+
+// ExecSensitive executes a statement that embeds sensitive values, and
+// records it with the values redacted if it succeeded.
+func (db recordingDB) ExecSensitive(ctx context.Context, query string, sensitive []string, args ...interface{}) error {
+	if err := db.DB.ExecSensitive(ctx, query, sensitive, args...); err != nil {
+		return err
+	}
+	db.record(redactString(query, sensitive...))
+	return nil
+}
+
+func (db recordingDB) record(statement string) {
+	keyword, _, _ := strings.Cut(strings.TrimSpace(statement), " ")
+	if !recorded[strings.ToUpper(keyword)] {
+		return
+	}
+	db.recorder.Event(db.object, event.Normal(ReasonExecutedStatement, statement))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+// A recorder records the messages of the events it is given.
+type recorder struct {
+	messages []string
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.messages = append(r.messages, e.Message)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestRecordStatements(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		query     string
+		sensitive []string
+		err       error
+	}
+	type want struct {
+		err      error
+		messages []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SchemaChange": {
+			reason: "Statements that change the schema should be recorded.",
+			args: args{
+				query: `CREATE KEYSPACE IF NOT EXISTS "ks" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}`,
+			},
+			want: want{
+				messages: []string{`CREATE KEYSPACE IF NOT EXISTS "ks" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}`},
+			},
+		},
+		"Sensitive": {
+			reason: "Sensitive values should be redacted from recorded statements.",
+			args: args{
+				query:     `ALTER ROLE "user" WITH PASSWORD = 'pa''ss'`,
+				sensitive: []string{"pa'ss"},
+			},
+			want: want{
+				messages: []string{`ALTER ROLE "user" WITH PASSWORD = '` + redacted + `'`},
+			},
+		},
+		"Permission": {
+			reason: "Statements that change permissions should be recorded.",
+			args: args{
+				query: `  grant SELECT ON KEYSPACE "ks" TO "user"`,
+			},
+			want: want{
+				messages: []string{`  grant SELECT ON KEYSPACE "ks" TO "user"`},
+			},
+		},
+		"Data": {
+			reason: "Statements that do not change the schema or permissions should not be recorded.",
+			args: args{
+				query: `INSERT INTO "ks"."t" ("id") VALUES (?)`,
+			},
+			want: want{},
+		},
+		"Failed": {
+			reason: "Statements that fail should not be recorded.",
+			args: args{
+				query: `DROP KEYSPACE IF EXISTS "ks"`,
+				err:   errBoom,
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{}
+			exec := func(_ context.Context, _ string, _ ...interface{}) error { return tc.args.err }
+			db := RecordStatements(&MockDB{
+				ExecFunc: exec,
+				ExecSensitiveFunc: func(ctx context.Context, query string, _ []string, args ...interface{}) error {
+					return exec(ctx, query, args...)
+				},
+			}, r, &fake.Managed{})
+
+			var err error
+			if tc.args.sensitive != nil {
+				err = db.ExecSensitive(context.Background(), tc.args.query, tc.args.sensitive)
+			} else {
+				err = db.Exec(context.Background(), tc.args.query)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExec(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.messages, r.messages); diff != "" {
+				t.Errorf("\n%s\nExec(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+// rejections are the reasons and explanations of the classes of errors that
+// are reported in the Accepted condition.
+var rejections = map[ErrorClass]struct {
+	reason  xpv1.ConditionReason
+	explain string
+}{
+	ErrorUnavailable:     {apisv1alpha1.ReasonUnavailable, "not enough replicas are alive to achieve the consistency level"},
+	ErrorTimeout:         {apisv1alpha1.ReasonTimedOut, "the cluster did not respond in time"},
+	ErrorOverloaded:      {apisv1alpha1.ReasonOverloaded, "the cluster is too busy to serve"},
+	ErrorUnauthenticated: {apisv1alpha1.ReasonUnauthenticated, "the cluster rejected the credentials of the ProviderConfig"},
+	ErrorUnauthorized:    {apisv1alpha1.ReasonUnauthorized, "the role of the ProviderConfig lacks a permission the statement requires"},
+	ErrorAlreadyExists:   {apisv1alpha1.ReasonAlreadyExists, "the object already exists in the cluster"},
+	ErrorInvalid:         {apisv1alpha1.ReasonInvalidStatement, "the cluster cannot run the statement as written"},
+}
+
+// ReportErrors reflects the errors of the external client in the conditions
+// of the managed resources it reconciles: whether it can reach its cluster in
+// their Reachable condition, and why the cluster rejected their statements
+// and queries in their Accepted condition. Operations still fail, so that
+// they are retried with the backoff of the reconciler, but the conditions
+// tell a permissions problem apart from an outage.
+func ReportErrors(c managed.ExternalClient) managed.ExternalClient {
+	return reportingClient{ExternalClient: c}
+}
+
+// A reportingClient is an external client that reports its errors in
+// conditions.
+type reportingClient struct {
+	managed.ExternalClient
+}
+
+// Observe observes the managed resource, and reports its error and how its
+// external resource differs from it.
+func (c reportingClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.ExternalClient.Observe(ctx, mg)
+	report(mg, err)
+	if err == nil && o.ResourceExists {
+		reportDrift(mg, o)
+	}
+	return o, err
+}
+
+// Create creates the managed resource, and reports its error.
+func (c reportingClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	o, err := c.ExternalClient.Create(ctx, mg)
+	report(mg, err)
+	return o, err
+}
+
+// Update updates the managed resource, and reports its error.
+func (c reportingClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	o, err := c.ExternalClient.Update(ctx, mg)
+	report(mg, err)
+	return o, err
+}
+
+// Delete deletes the managed resource, and reports its error.
+func (c reportingClient) Delete(ctx context.Context, mg resource.Managed) error {
+	err := c.ExternalClient.Delete(ctx, mg)
+	report(mg, err)
+	return err
+}
+
+// report sets the Reachable condition of the managed resource to false if the
+// cluster could not be reached, and its Accepted condition to false if the
+// cluster rejected a statement or query. The conditions are only set back to
+// true once they were false, so that they are not added to every managed
+// resource. Errors that cannot be classified leave them as they are.
+func report(mg resource.Managed, err error) {
+	class := Classify(err)
+	r, ok := rejections[class]
+	switch {
+	case class == ErrorUnreachable:
+		mg.SetConditions(apisv1alpha1.Unreachable(err))
+	case ok:
+		mg.SetConditions(apisv1alpha1.Rejected(r.reason, r.explain+": "+err.Error()))
+	case err == nil:
+		if mg.GetCondition(apisv1alpha1.TypeReachable).Status == corev1.ConditionFalse {
+			mg.SetConditions(apisv1alpha1.Reachable())
+		}
+		if mg.GetCondition(apisv1alpha1.TypeAccepted).Status == corev1.ConditionFalse {
+			mg.SetConditions(apisv1alpha1.Accepted())
+		}
+	}
+}
+
+// reportDrift sets the InSync condition of the managed resource to false with
+// the fields that differ when its external resource is not up to date, so that
+// the statements issued to correct it can be explained without debug logs. It
+// is only set back to true once it was false.
+func reportDrift(mg resource.Managed, o managed.ExternalObservation) {
+	switch {
+	case !o.ResourceUpToDate && o.Diff != "":
+		mg.SetConditions(apisv1alpha1.Drifted(o.Diff))
+	case o.ResourceUpToDate && mg.GetCondition(apisv1alpha1.TypeInSync).Status == corev1.ConditionFalse:
+		mg.SetConditions(apisv1alpha1.InSync())
+	}
+}
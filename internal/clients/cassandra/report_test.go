@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+// observer is an external client whose observations fail with an error.
+type observer struct {
+	managed.ExternalClient
+	err error
+}
+
+func (o observer) Observe(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+	return managed.ExternalObservation{ResourceExists: o.err == nil}, o.err
+}
+
+func TestReportErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	unreachable := UnreachableError{err: errBoom}
+	exists := &gocql.RequestErrAlreadyExists{}
+
+	type want struct {
+		reachable corev1.ConditionStatus
+		accepted  corev1.ConditionStatus
+	}
+
+	cases := map[string]struct {
+		reason string
+		was    []xpv1.Condition
+		err    error
+		want   want
+	}{
+		"Unreachable": {
+			reason: "A managed resource whose cluster cannot be reached should be marked unreachable.",
+			err:    unreachable,
+			want:   want{reachable: corev1.ConditionFalse, accepted: corev1.ConditionUnknown},
+		},
+		"Rejected": {
+			reason: "A managed resource whose statement was rejected should be marked as such.",
+			err:    exists,
+			want:   want{reachable: corev1.ConditionUnknown, accepted: corev1.ConditionFalse},
+		},
+		"OtherError": {
+			reason: "Errors that cannot be classified should not change the conditions of a managed resource.",
+			err:    errBoom,
+			was:    []xpv1.Condition{apisv1alpha1.Rejected(apisv1alpha1.ReasonAlreadyExists, "exists")},
+			want:   want{reachable: corev1.ConditionUnknown, accepted: corev1.ConditionFalse},
+		},
+		"Reached": {
+			reason: "A managed resource that was unreachable and rejected should be marked reachable and accepted once it is observed.",
+			was:    []xpv1.Condition{apisv1alpha1.Unreachable(unreachable), apisv1alpha1.Rejected(apisv1alpha1.ReasonAlreadyExists, "exists")},
+			want:   want{reachable: corev1.ConditionTrue, accepted: corev1.ConditionTrue},
+		},
+		"NeverFailed": {
+			reason: "A managed resource that never failed should not be given the conditions.",
+			want:   want{reachable: corev1.ConditionUnknown, accepted: corev1.ConditionUnknown},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.was...)
+			_, err := ReportErrors(observer{err: tc.err}).Observe(context.Background(), mg)
+			if diff := cmp.Diff(tc.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			got := want{
+				reachable: mg.GetCondition(apisv1alpha1.TypeReachable).Status,
+				accepted:  mg.GetCondition(apisv1alpha1.TypeAccepted).Status,
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// drifter is an external client whose observations succeed.
+type drifter struct {
+	managed.ExternalClient
+	o managed.ExternalObservation
+}
+
+func (d drifter) Observe(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+	return d.o, nil
+}
+
+func TestReportDrift(t *testing.T) {
+	type want struct {
+		status  corev1.ConditionStatus
+		message string
+	}
+
+	cases := map[string]struct {
+		reason string
+		was    []xpv1.Condition
+		o      managed.ExternalObservation
+		want   want
+	}{
+		"Drifted": {
+			reason: "A managed resource whose external resource differs should be marked drifted with the diff.",
+			o:      managed.ExternalObservation{ResourceExists: true, Diff: "replication_factor: want 3, got 2"},
+			want:   want{status: corev1.ConditionFalse, message: "replication_factor: want 3, got 2"},
+		},
+		"NotExisting": {
+			reason: "A managed resource whose external resource does not exist has not drifted.",
+			o:      managed.ExternalObservation{Diff: "replication_factor: want 3, got unset"},
+			want:   want{status: corev1.ConditionUnknown},
+		},
+		"Corrected": {
+			reason: "A managed resource that had drifted should be marked in sync once it is up to date.",
+			was:    []xpv1.Condition{apisv1alpha1.Drifted("replication_factor: want 3, got 2")},
+			o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			want:   want{status: corev1.ConditionTrue},
+		},
+		"NeverDrifted": {
+			reason: "A managed resource that never drifted should not be given the condition.",
+			o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			want:   want{status: corev1.ConditionUnknown},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.was...)
+			if _, err := ReportErrors(drifter{o: tc.o}).Observe(context.Background(), mg); err != nil {
+				t.Fatalf("\n%s\nObserve(...): unexpected error: %v", tc.reason, err)
+			}
+			c := mg.GetCondition(apisv1alpha1.TypeInSync)
+			got := want{status: c.Status, message: c.Message}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
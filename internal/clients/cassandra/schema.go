@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	errSelectLocalSchema = "cannot select schema version of the local node"
+	errSelectPeerSchemas = "cannot select schema versions of peers"
+)
+
+// SchemaSettled reports whether every node of the cluster that is known to
+// the node queried agrees on the version of the schema, that is whether the
+// schema changes made through any node have reached all of them.
+func SchemaSettled(ctx context.Context, db DB) (bool, error) {
+	iter, err := db.Query(ctx, "SELECT schema_version FROM system.local")
+	if err != nil {
+		return false, errors.Wrap(err, errSelectLocalSchema)
+	}
+	var local, version string
+	iter.Scan(&local)
+	if err := iter.Close(); err != nil {
+		return false, errors.Wrap(err, errSelectLocalSchema)
+	}
+
+	iter, err = db.Query(ctx, "SELECT schema_version FROM system.peers")
+	if err != nil {
+		return false, errors.Wrap(err, errSelectPeerSchemas)
+	}
+	settled := true
+	for iter.Scan(&version) {
+		// Peers that have not reported a schema version yet are not
+		// considered, as the driver does.
+		if version != "" && version != local {
+			settled = false
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return false, errors.Wrap(err, errSelectPeerSchemas)
+	}
+	return settled, nil
+}
+
+// Settled reports whether a managed resource created by an asynchronous schema
+// change may be considered ready: whether it was already available, or the
+// schema of the cluster has settled since it was created.
+func Settled(ctx context.Context, db DB, mg resource.Managed) (bool, error) {
+	if mg.GetCondition(xpv1.TypeReady).Reason == xpv1.ReasonAvailable {
+		return true, nil
+	}
+	return SchemaSettled(ctx, db)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestSchemaSettled(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		settled bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		peers    [][]interface{}
+		peersErr error
+		want     want
+	}{
+		"Agreed": {
+			reason: "The schema has settled when every node has the same schema version.",
+			peers:  [][]interface{}{{"a"}, {"a"}},
+			want:   want{settled: true},
+		},
+		"Disagreed": {
+			reason: "The schema has not settled while a node has another schema version.",
+			peers:  [][]interface{}{{"a"}, {"b"}},
+			want:   want{settled: false},
+		},
+		"UnknownVersion": {
+			reason: "Peers that have not reported a schema version are not considered.",
+			peers:  [][]interface{}{{""}},
+			want:   want{settled: true},
+		},
+		"SingleNode": {
+			reason: "The schema of a cluster of a single node has always settled.",
+			want:   want{settled: true},
+		},
+		"ErrSelectPeers": {
+			reason:   "Errors selecting the schema versions of peers should be returned.",
+			peersErr: errBoom,
+			want:     want{err: errors.Wrap(errBoom, errSelectPeerSchemas)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &MockDB{
+				QueryFunc: func(_ context.Context, query string, _ ...interface{}) (Iterator, error) {
+					if query == "SELECT schema_version FROM system.local" {
+						return &MockIterator{Rows: [][]interface{}{{"a"}}}, nil
+					}
+					return &MockIterator{Rows: tc.peers}, tc.peersErr
+				},
+			}
+			settled, err := SchemaSettled(context.Background(), db)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSchemaSettled(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.settled, settled); diff != "" {
+				t.Errorf("\n%s\nSchemaSettled(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"crypto/tls"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+)
+
+// ScyllaDB maps connections to its shard-aware port to the shard their local
+// port modulo the number of shards is, and expects the local ports to be
+// chosen from this range.
+const (
+	shardAwarePortMin = 49152
+	shardAwarePortMax = 65535
+
+	// shardAwareAttempts is how many local ports are tried before giving up
+	// on a connection, as ports may be in use by other connections.
+	shardAwareAttempts = 8
+)
+
+// A shardAwareDialer dials the ScyllaDB shard-aware port of nodes. It dials
+// from consecutive local ports, so that the connections to each node are
+// spread across its shards rather than all handled by the shard that accepted
+// them.
+type shardAwareDialer struct {
+	port   int
+	dialer net.Dialer
+	tls    *tls.Config
+	next   atomic.Uint32
+}
+
+func newShardAwareDialer(port int, cluster *gocql.ClusterConfig, tc *tls.Config) *shardAwareDialer {
+	d := &shardAwareDialer{
+		port:   port,
+		dialer: net.Dialer{Timeout: cluster.ConnectTimeout, KeepAlive: cluster.SocketKeepalive},
+		tls:    tc,
+	}
+	d.next.Store(rand.Uint32()) //nolint:gosec // The first local port does not need to be unpredictable.
+	return d
+}
+
+// DialHost dials the shard-aware port of the host, over TLS if it is
+// configured.
+func (d *shardAwareDialer) DialHost(ctx context.Context, host *gocql.HostInfo) (*gocql.DialedHost, error) {
+	addr := net.JoinHostPort(host.ConnectAddress().String(), strconv.Itoa(d.port))
+
+	var conn net.Conn
+	var err error
+	for i := 0; i < shardAwareAttempts; i++ {
+		dl := d.dialer
+		dl.LocalAddr = &net.TCPAddr{Port: shardAwarePortMin + int(d.next.Add(1)%(shardAwarePortMax-shardAwarePortMin+1))}
+		conn, err = dl.DialContext(ctx, "tcp", addr)
+		if !errors.Is(err, syscall.EADDRINUSE) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gocql.WrapTLS(ctx, conn, addr, d.tls)
+}
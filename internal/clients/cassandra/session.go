@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// Bounds of how long to wait before connecting to a cluster that could not be
+// reached again. The wait doubles with each failed attempt.
+const (
+	minConnectBackoff = time.Second
+	maxConnectBackoff = time.Minute
+)
+
+var errClosed = errors.New("client is closed")
+
+// An UnreachableError is returned by the statements and queries of a client
+// that cannot connect to its cluster.
+type UnreachableError struct {
+	err error
+}
+
+func (e UnreachableError) Error() string {
+	return "cannot reach cluster: " + e.err.Error()
+}
+
+func (e UnreachableError) Unwrap() error {
+	return e.err
+}
+
+// IsUnreachable reports whether an error is due to the cluster being
+// unreachable, either because a client could not connect to it or because
+// none of the nodes it connected to are up any longer.
+func IsUnreachable(err error) bool {
+	return errors.As(err, &UnreachableError{}) || errors.Is(err, gocql.ErrNoConnections)
+}
+
+// A lazySession connects to a cluster when it is first used rather than when
+// a client is created, so that clients of clusters that are down can still be
+// created. Connecting is retried with an exponential backoff, during which the
+// last error is returned without dialing the cluster again.
+type lazySession struct {
+	cluster *gocql.ClusterConfig
+	driver  Driver
+
+	mu         sync.Mutex
+	session    *gocql.Session
+	datacenter string
+	closed     bool
+	err        error
+	backoff    time.Duration
+	next       time.Time
+}
+
+// get returns the session, connecting to the cluster if it is not connected
+// yet and the backoff elapsed.
+func (s *lazySession) get() (*gocql.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.closed:
+		return nil, errClosed
+	case s.session != nil:
+		return s.session, nil
+	case s.err != nil && time.Now().Before(s.next):
+		return nil, UnreachableError{err: s.err}
+	}
+
+	session, err := s.driver.CreateSession(s.cluster)
+	if err != nil {
+		s.backoff = min(max(2*s.backoff, minConnectBackoff), maxConnectBackoff)
+		s.err, s.next = err, time.Now().Add(s.backoff)
+		return nil, UnreachableError{err: err}
+	}
+	s.session, s.err, s.backoff = session, nil, 0
+	s.datacenter = connectedDatacenter(session, s.cluster.Timeout)
+	return session, nil
+}
+
+// connectedDatacenter returns the datacenter of the node the session is
+// connected to, or an empty string if it cannot be read within the timeout.
+// It is read once when the session connects rather than whenever it is
+// needed.
+func connectedDatacenter(session *gocql.Session, timeout time.Duration) string {
+	ctx, cancel := context.WithCancel(context.Background())
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	}
+	defer cancel()
+
+	var dc string
+	if err := session.Query("SELECT data_center FROM system.local").WithContext(ctx).Scan(&dc); err != nil {
+		return ""
+	}
+	return dc
+}
+
+// localDatacenter returns the datacenter of the node the session connected
+// to, connecting to the cluster if it is not connected yet. It returns an
+// empty string when it is not known.
+func (s *lazySession) localDatacenter() string {
+	if _, err := s.get(); err != nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.datacenter
+}
+
+// close closes the session, if it connected, and prevents it from connecting
+// again.
+func (s *lazySession) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.session != nil {
+		s.session.Close()
+	}
+}
+
+// isClosed reports whether the session was closed.
+func (s *lazySession) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
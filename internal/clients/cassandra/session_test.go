@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestLazySessionBackoff(t *testing.T) {
+	// A cluster without hosts fails to connect without dialing.
+	s := &lazySession{cluster: gocql.NewCluster(), driver: DefaultDriver}
+
+	if _, err := s.get(); !IsUnreachable(err) {
+		t.Fatalf("get(): want unreachable error, got %v", err)
+	}
+	next := s.next
+	if _, err := s.get(); !IsUnreachable(err) {
+		t.Fatalf("get(): want unreachable error, got %v", err)
+	}
+	if s.backoff != minConnectBackoff || !s.next.Equal(next) {
+		t.Errorf("get(): want no attempt to connect during the backoff, got backoff %s", s.backoff)
+	}
+
+	s.close()
+	if _, err := s.get(); !errors.Is(err, errClosed) {
+		t.Errorf("get(): want %v once closed, got %v", errClosed, err)
+	}
+}
+
+func TestLocalDatacenter(t *testing.T) {
+	// The datacenter is read when the session connects, so the session is
+	// never queried for it again.
+	connected := &lazySession{session: &gocql.Session{}, datacenter: "dc1"}
+
+	cases := map[string]struct {
+		reason string
+		db     CassandraDB
+		want   string
+	}{
+		"Configured": {
+			reason: "The configured local datacenter should be preferred.",
+			db:     CassandraDB{session: connected, datacenter: "dc2"},
+			want:   "dc2",
+		},
+		"Connected": {
+			reason: "The datacenter of the node the session connected to should be used when none is configured.",
+			db:     CassandraDB{session: connected},
+			want:   "dc1",
+		},
+		"Unreachable": {
+			reason: "No datacenter should be returned when the cluster cannot be reached.",
+			db:     CassandraDB{session: &lazySession{cluster: gocql.NewCluster(), driver: DefaultDriver}},
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.db.localDatacenter(); got != tc.want {
+				t.Errorf("\n%s\nlocalDatacenter(): want %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
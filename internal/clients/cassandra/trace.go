@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"encoding/hex"
+
+	"github.com/gocql/gocql"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// TraceAll traces the statements and queries of every ProviderConfig, whether
+// or not it enables tracing.
+var TraceAll bool
+
+// TraceLogger is the logger the trace IDs of the ProviderConfigs that enable
+// tracing are logged to.
+var TraceLogger = logging.NewNopLogger()
+
+// WithTracing enables CQL tracing of statements and queries, and logs their
+// trace IDs to the supplied logger. The traces themselves are recorded by the
+// cluster in the system_traces keyspace.
+func WithTracing(log logging.Logger) Option {
+	return func(cfg *config) {
+		cfg.trace = log
+	}
+}
+
+// A tracer logs the trace ID of a statement or query.
+type tracer struct {
+	log       logging.Logger
+	statement string
+}
+
+// Trace logs the ID of the trace of the statement, which is the session_id of
+// system_traces.sessions.
+func (t tracer) Trace(id []byte) {
+	traceID := hex.EncodeToString(id)
+	if u, err := gocql.UUIDFromBytes(id); err == nil {
+		traceID = u.String()
+	}
+	t.log.Info("Traced CQL statement", "trace-id", traceID, "statement", t.statement)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// An IdentifierValidator rejects resources whose CQL identifiers are invalid
+// on admission, rather than letting them produce broken statements when they
+// are reconciled. It returns the errors of a resource of type T.
+type IdentifierValidator[T client.Object] func(o T) field.ErrorList
+
+// ValidateCreate validates the identifiers of a created resource.
+func (v IdentifierValidator[T]) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, err := v.resource(obj)
+	if err != nil {
+		return nil, err
+	}
+	return nil, invalid(o, v(o))
+}
+
+// ValidateUpdate validates the identifiers of an updated resource. Resources
+// that are being deleted are not validated, nor are identifiers that were
+// already invalid before the update, so that resources created before their
+// identifiers were validated can still be updated by their controllers and
+// deleted.
+func (v IdentifierValidator[T]) ValidateUpdate(_ context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	o, err := v.resource(obj)
+	if err != nil {
+		return nil, err
+	}
+	if o.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	old, err := v.resource(oldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	// An identifier keeps its value but not necessarily its path, as the
+	// name of a resource becomes its external name once it is created.
+	existing := map[string]bool{}
+	for _, e := range v(old) {
+		existing[errorKey(e)] = true
+	}
+	var errs field.ErrorList
+	for _, e := range v(o) {
+		if !existing[errorKey(e)] {
+			errs = append(errs, e)
+		}
+	}
+	return nil, invalid(o, errs)
+}
+
+// ValidateDelete does nothing; resources may always be deleted.
+func (v IdentifierValidator[T]) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v IdentifierValidator[T]) resource(obj runtime.Object) (T, error) {
+	o, ok := obj.(T)
+	if !ok {
+		return o, errors.Errorf("unexpected resource %T", obj)
+	}
+	return o, nil
+}
+
+// invalid returns an Invalid error of the resource for the supplied errors,
+// or nil if there are none.
+func invalid(o client.Object, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(o.GetObjectKind().GroupVersionKind().GroupKind(), o.GetName(), errs)
+}
+
+// errorKey identifies the error of an identifier by its value rather than by
+// its path.
+func errorKey(e *field.Error) string {
+	return fmt.Sprintf("%s\x00%v\x00%s", e.Type, e.BadValue, e.Detail)
+}
@@ -22,8 +22,13 @@ import (
 
 	"github.com/crossplane/provider-cassandra/internal/controller/config"
 	"github.com/crossplane/provider-cassandra/internal/controller/grant"
+	"github.com/crossplane/provider-cassandra/internal/controller/index"
 	"github.com/crossplane/provider-cassandra/internal/controller/keyspace"
+	"github.com/crossplane/provider-cassandra/internal/controller/keyspaceschemaexport"
+	"github.com/crossplane/provider-cassandra/internal/controller/node"
 	"github.com/crossplane/provider-cassandra/internal/controller/role"
+	"github.com/crossplane/provider-cassandra/internal/controller/seeddata"
+	"github.com/crossplane/provider-cassandra/internal/controller/table"
 )
 
 // Setup creates all Cassandra controllers with the supplied logger and adds them to
@@ -31,9 +36,15 @@ import (
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	for _, setup := range []func(ctrl.Manager, controller.Options) error{
 		config.Setup,
+		config.SetupHealth,
 		grant.Setup,
+		index.Setup,
 		keyspace.Setup,
+		keyspaceschemaexport.Setup,
+		node.Setup,
 		role.Setup,
+		seeddata.Setup,
+		table.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
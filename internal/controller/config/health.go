@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+
+	"github.com/crossplane/provider-cassandra/apis/v1alpha1"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+)
+
+const (
+	errGetPC        = "cannot get ProviderConfig"
+	errGetCreds     = "cannot get credentials"
+	errNewClient    = "cannot create new Service"
+	errSelectLocal  = "cannot select local node"
+	errSelectPeers  = "cannot select peers"
+	errUpdateStatus = "cannot update ProviderConfig status"
+
+	healthTimeout = 30 * time.Second
+)
+
+// Event reasons of the health check.
+const (
+	reasonHealthy   event.Reason = "ConnectionSucceeded"
+	reasonUnhealthy event.Reason = "ConnectionFailed"
+)
+
+// SetupHealth adds a controller that periodically checks whether
+// ProviderConfigs can connect to their cluster.
+func SetupHealth(mgr ctrl.Manager, o controller.Options) error {
+	name := "health/" + strings.ToLower(v1alpha1.ProviderConfigGroupKind)
+
+	r := &healthReconciler{
+		kube:      mgr.GetClient(),
+		newClient: cassandra.New,
+		log:       o.Logger.WithValues("controller", name),
+		record:    event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+		interval:  o.PollInterval,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.referencing)).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A healthReconciler checks whether a ProviderConfig can connect to its
+// cluster, and reflects the result in its Healthy condition.
+type healthReconciler struct {
+	kube      client.Client
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+	log       logging.Logger
+	record    event.Recorder
+	interval  time.Duration
+}
+
+func (r *healthReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetPC)
+	}
+
+	was := pc.Status.GetCondition(v1alpha1.TypeHealthy).Status
+	cluster, err := r.check(ctx, pc)
+	if err != nil {
+		r.log.Debug("ProviderConfig is unhealthy", "name", pc.GetName(), "error", err)
+		pc.Status.SetConditions(v1alpha1.Unhealthy(err))
+		r.record.Event(pc, event.Warning(reasonUnhealthy, err))
+	} else {
+		pc.Status.Cluster = cluster
+		pc.Status.SetConditions(v1alpha1.Healthy())
+		if was != corev1.ConditionTrue {
+			r.record.Event(pc, event.Normal(reasonHealthy, "Successfully connected to the cluster"))
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: r.interval}, errors.Wrap(r.kube.Status().Update(ctx, pc), errUpdateStatus)
+}
+
+// referencing returns requests for the ProviderConfigs that reference the
+// secret, so that rotated credentials or certificates are checked as soon as
+// they change rather than at the next poll.
+func (r *healthReconciler) referencing(ctx context.Context, o client.Object) []reconcile.Request {
+	l := &v1alpha1.ProviderConfigList{}
+	if err := r.kube.List(ctx, l); err != nil {
+		r.log.Debug("Cannot list ProviderConfigs", "error", err)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, pc := range l.Items {
+		if referencesSecret(pc.Spec, o.GetNamespace(), o.GetName()) {
+			// Shared sessions may have been created with the previous
+			// contents of the secret, such as an expiring certificate.
+			cassandra.Sessions.Invalidate(pc.GetName())
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: pc.GetName()}})
+		}
+	}
+	return reqs
+}
+
+// referencesSecret reports whether a ProviderConfig reads the named secret.
+func referencesSecret(spec v1alpha1.ProviderConfigSpec, namespace, name string) bool {
+	refs := []xpv1.SecretReference{}
+	if ref := spec.Credentials.SecretRef; ref != nil {
+		refs = append(refs, ref.SecretReference)
+	}
+	if ref := spec.Credentials.CassandraDatacenterRef; ref != nil {
+		refs = append(refs, xpv1.SecretReference{Name: cassandra.SuperuserSecretName(ref), Namespace: ref.Namespace})
+	}
+	if t := spec.TLS; t != nil {
+		for _, ref := range []*xpv1.SecretKeySelector{t.CASecretRef, t.ClientCertSecretRef, t.ClientKeySecretRef} {
+			if ref != nil {
+				refs = append(refs, ref.SecretReference)
+			}
+		}
+	}
+	for _, ref := range refs {
+		if ref.Name == name && ref.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// check connects to the cluster of the ProviderConfig and returns its
+// metadata, which is read with lightweight queries of the node it connects to.
+func (r *healthReconciler) check(ctx context.Context, pc *v1alpha1.ProviderConfig) (*v1alpha1.ClusterObservation, error) {
+	creds, err := cassandra.ProviderCredentials(ctx, r.kube, pc.Spec.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	opts, err := cassandra.ProviderConfigOptions(ctx, r.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	db := r.newClient(creds, "", opts...)
+	defer db.Close()
+
+	iter, err := db.Query(ctx, "SELECT cluster_name, release_version, partitioner, data_center FROM system.local")
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectLocal)
+	}
+	o := &v1alpha1.ClusterObservation{}
+	var dc string
+	iter.Scan(&o.Name, &o.ReleaseVersion, &o.Partitioner, &dc)
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, errSelectLocal)
+	}
+
+	iter, err = db.Query(ctx, "SELECT data_center FROM system.peers")
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectPeers)
+	}
+	dcs := map[string]bool{dc: true}
+	for iter.Scan(&dc) {
+		dcs[dc] = true
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, errSelectPeers)
+	}
+	for dc := range dcs {
+		if dc != "" {
+			o.Datacenters = append(o.Datacenters, dc)
+		}
+	}
+	sort.Strings(o.Datacenters)
+	return o, nil
+}
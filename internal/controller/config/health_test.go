@@ -0,0 +1,206 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/provider-cassandra/apis/v1alpha1"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+)
+
+// recorder records the reasons of the events it is sent.
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestHealthReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		condition xpv1.Condition
+		cluster   *v1alpha1.ClusterObservation
+		reasons   []event.Reason
+	}
+
+	cluster := &v1alpha1.ClusterObservation{
+		Name:           "Test Cluster",
+		ReleaseVersion: "5.0.2",
+		Partitioner:    "org.apache.cassandra.dht.Murmur3Partitioner",
+		Datacenters:    []string{"dc1", "dc2"},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		queryErr error
+		was      *xpv1.Condition
+		want     want
+	}{
+		"BecameHealthy": {
+			reason: "Should mark a ProviderConfig that can connect as healthy, and record that it did.",
+			want: want{
+				condition: v1alpha1.Healthy(),
+				cluster:   cluster,
+				reasons:   []event.Reason{reasonHealthy},
+			},
+		},
+		"StayedHealthy": {
+			reason: "Should not record an event while a ProviderConfig stays healthy.",
+			was:    func() *xpv1.Condition { c := v1alpha1.Healthy(); return &c }(),
+			want: want{
+				condition: v1alpha1.Healthy(),
+				cluster:   cluster,
+			},
+		},
+		"Unhealthy": {
+			reason:   "Should mark a ProviderConfig that cannot connect as unhealthy.",
+			queryErr: errBoom,
+			want: want{
+				condition: v1alpha1.Unhealthy(errors.Wrap(errBoom, errSelectLocal)),
+				reasons:   []event.Reason{reasonUnhealthy},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated *v1alpha1.ProviderConfig
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *v1alpha1.ProviderConfig:
+						o.SetName(key.Name)
+						o.Spec.Credentials = v1alpha1.ProviderCredentials{
+							Source: xpv1.CredentialsSourceSecret,
+							CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+								SecretRef: &xpv1.SecretKeySelector{
+									SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "crossplane-system"},
+									Key:             "credentials",
+								},
+							},
+						}
+						if tc.was != nil {
+							o.Status.SetConditions(*tc.was)
+						}
+					case *corev1.Secret:
+						o.Data = map[string][]byte{"credentials": []byte(`{"username":"cassandra","password":"cassandra"}`)}
+					}
+					return nil
+				},
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					updated = obj.(*v1alpha1.ProviderConfig)
+					return nil
+				},
+			}
+			db := &cassandra.MockDB{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+					if query == "SELECT cluster_name, release_version, partitioner, data_center FROM system.local" {
+						return &cassandra.MockIterator{Rows: [][]interface{}{{"Test Cluster", "5.0.2", "org.apache.cassandra.dht.Murmur3Partitioner", "dc2"}}}, tc.queryErr
+					}
+					return &cassandra.MockIterator{Rows: [][]interface{}{{"dc1"}, {"dc2"}, {"dc1"}}}, tc.queryErr
+				},
+			}
+			rec := &recorder{}
+			r := &healthReconciler{
+				kube:      kube,
+				newClient: func(map[string][]byte, string, ...cassandra.Option) cassandra.DB { return db },
+				log:       logging.NewNopLogger(),
+				record:    rec,
+			}
+
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}}); err != nil {
+				t.Fatalf("\n%s\nReconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			got := updated.Status.GetCondition(v1alpha1.TypeHealthy)
+			if diff := cmp.Diff(tc.want.condition, got, cmpopts.IgnoreTypes(metav1.Time{})); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cluster, updated.Status.Cluster); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want cluster, +got cluster:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reasons, rec.reasons); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReferencesSecret(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.ProviderConfigSpec
+		want   bool
+	}{
+		"Credentials": {
+			reason: "A ProviderConfig references the secret holding its credentials.",
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+						SecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "ns"}},
+					},
+				},
+			},
+			want: true,
+		},
+		"Superuser": {
+			reason: "A ProviderConfig references the superuser secret of a cass-operator datacenter, not a secret named after its cluster.",
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					CassandraDatacenterRef: &v1alpha1.CassandraDatacenterReference{Name: "dc1", Namespace: "ns", ClusterName: "creds"},
+				},
+			},
+			want: false,
+		},
+		"ClientCertificate": {
+			reason: "A ProviderConfig references the secret holding its client certificate.",
+			spec: v1alpha1.ProviderConfigSpec{
+				TLS: &v1alpha1.TLS{
+					ClientCertSecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "ns"}},
+				},
+			},
+			want: true,
+		},
+		"OtherNamespace": {
+			reason: "A ProviderConfig does not reference a secret of the same name in another namespace.",
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+						SecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "other"}},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := referencesSecret(tc.spec, "ns", "creds")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nreferencesSecret(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+const (
+	errListPCs     = "cannot list ProviderConfigs"
+	errUnreachable = "no ProviderConfig can connect to its cluster"
+)
+
+// ClusterReachable returns a readiness check that fails unless a
+// ProviderConfig is Healthy, that is unless the provider could reach at least
+// one of the clusters it is configured for when they were last checked. It
+// passes while there are no ProviderConfigs, as there is nothing to reach.
+func ClusterReachable(kube client.Reader) healthz.Checker {
+	return func(req *http.Request) error {
+		l := &v1alpha1.ProviderConfigList{}
+		if err := kube.List(req.Context(), l); err != nil {
+			return errors.Wrap(err, errListPCs)
+		}
+		if len(l.Items) == 0 {
+			return nil
+		}
+		for _, pc := range l.Items {
+			if pc.Status.GetCondition(v1alpha1.TypeHealthy).Status == corev1.ConditionTrue {
+				return nil
+			}
+		}
+		return errors.New(errUnreachable)
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+func TestClusterReachable(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	list := func(conditions ...xpv1.Condition) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			l := obj.(*v1alpha1.ProviderConfigList)
+			for _, c := range conditions {
+				pc := v1alpha1.ProviderConfig{}
+				pc.Status.SetConditions(c)
+				l.Items = append(l.Items, pc)
+			}
+			return nil
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		list   test.MockListFn
+		want   error
+	}{
+		"NoProviderConfigs": {
+			reason: "The provider should be ready when it is not configured for any cluster.",
+			list:   list(),
+		},
+		"Reachable": {
+			reason: "The provider should be ready when one of its clusters is reachable.",
+			list:   list(v1alpha1.Unhealthy(errBoom), v1alpha1.Healthy()),
+		},
+		"Unreachable": {
+			reason: "The provider should not be ready when none of its clusters is reachable.",
+			list:   list(v1alpha1.Unhealthy(errBoom)),
+			want:   errors.New(errUnreachable),
+		},
+		"ListError": {
+			reason: "The provider should not be ready when its ProviderConfigs cannot be listed.",
+			list:   test.NewMockListFn(errBoom),
+			want:   errors.Wrap(errBoom, errListPCs),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			check := ClusterReachable(&test.MockClient{MockList: tc.list})
+			err := check(httptest.NewRequest("GET", "/readyz", nil))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nClusterReachable(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
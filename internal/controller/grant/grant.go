@@ -18,12 +18,17 @@ package grant
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/gocql/gocql"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -38,42 +43,91 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
 	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	"github.com/crossplane/provider-cassandra/apis/cql/v1beta1"
 	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
 	"github.com/crossplane/provider-cassandra/internal/features"
 )
 
 const (
-	errNotGrant     = "managed resource is not a Grant custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
+	errNotGrant           = "managed resource is not a Grant custom resource"
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+	errGetPC              = "cannot get ProviderConfig"
+	errGetCreds           = "cannot get credentials"
+	errUnsupportedDialect = "Amazon Keyspaces does not support grants; manage access with IAM instead"
 
 	errNewClient    = "cannot create new Service"
 	errGrantCreate  = "cannot create grant"
 	errGrantDelete  = "cannot delete grant"
 	errGrantObserve = "cannot observe grant"
+	errNoRoles      = "grant must be for at least one role"
+	errRestrict     = "cannot restrict permission"
+	errUnrestrict   = "cannot unrestrict permission"
+	errPropagation  = "permission changes are not yet visible"
 	maxConcurrency  = 5
 )
 
+// allPermissions grants every permission that applies to a resource.
+const allPermissions = "ALL PERMISSIONS"
+
+// keyspacePermissions are the permissions that apply to a keyspace.
+var keyspacePermissions = []string{"CREATE", "ALTER", "DROP", "SELECT", "MODIFY", "AUTHORIZE"}
+
+// tablePermissions are the permissions that apply to a table.
+var tablePermissions = []string{"ALTER", "DROP", "SELECT", "MODIFY", "AUTHORIZE"}
+
+// propagation is how long a Grant waits for its permission changes to become
+// visible after a GRANT or REVOKE. Without it the next observation may be
+// served from a stale permissions cache, and report drift that causes the
+// same statements to be issued again.
+var propagation = wait.Backoff{
+	Duration: 100 * time.Millisecond,
+	Factor:   2,
+	Steps:    6,
+}
+
 // Setup adds a controller that reconciles Grant managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.GrantGroupKind)
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
-		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind, connection.WithTLSConfig(o.ESSOptions.TLSConfig)))
 	}
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.GrantGroupVersionKind),
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			kube:      mgr.GetClient(),
 			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newClient: cassandra.New}),
+			newClient: cassandra.Sessions.New,
+			recorder:  recorder}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithConnectionPublishers(cps...))
+		managed.WithPollIntervalHook(cassandra.PollInterval),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...),
+	}
+
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.GrantGroupVersionKind), opts...)
+
+	// Grants of other API versions are converted to and from v1alpha1.
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&v1beta1.Grant{}).Complete(); err != nil {
+		return err
+	}
+
+	// Grants whose identifiers cannot be used in CQL are rejected on
+	// admission.
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Grant{}).
+		WithValidator(cassandra.IdentifierValidator[*v1alpha1.Grant](validate)).
+		Complete(); err != nil {
+		return err
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -83,12 +137,34 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
+// validate returns the errors of the names of the roles, keyspace and table
+// of a Grant.
+func validate(cr *v1alpha1.Grant) field.ErrorList {
+	path := field.NewPath("spec", "forProvider")
+	p := cr.Spec.ForProvider
+	errs := field.ErrorList{}
+	if p.Role != nil {
+		errs = append(errs, cassandra.ValidateRoleName(path.Child("role"), *p.Role)...)
+	}
+	for i, r := range p.Roles {
+		errs = append(errs, cassandra.ValidateRoleName(path.Child("roles").Index(i), r)...)
+	}
+	if p.Keyspace != nil {
+		errs = append(errs, cassandra.ValidateName(path.Child("keyspace"), *p.Keyspace)...)
+	}
+	if p.Table != nil {
+		errs = append(errs, cassandra.ValidateName(path.Child("table"), *p.Table)...)
+	}
+	return errs
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
 	kube      client.Client
 	usage     resource.Tracker
-	newClient func(creds map[string][]byte, keyspace string) cassandra.DB
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+	recorder  event.Recorder
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -106,32 +182,38 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	credsData, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if pc.Spec.Dialect == apisv1alpha1.DialectAmazonKeyspaces {
+		return nil, errors.New(errUnsupportedDialect)
+	}
+
+	creds, err := cassandra.ProviderCredentials(ctx, c.kube, pc.Spec.Credentials)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	// Convert the byte array to a string and parse the JSON
-	credsJSON := string(credsData)
-	var credsMap map[string]string
-	if err := json.Unmarshal([]byte(credsJSON), &credsMap); err != nil {
-		return nil, errors.Wrap(err, "failed to parse credentials JSON")
+	opts, err := cassandra.ProviderConfigOptions(ctx, c.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
 	}
-
-	// Convert map[string]string to map[string][]byte
-	creds := make(map[string][]byte)
-	for k, v := range credsMap {
-		creds[k] = []byte(v)
+	ropts, err := cassandra.ResourceOptions(cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
 	}
+	opts = append(opts, ropts...)
+	opts = append(opts, cassandra.WithSharedSession(ctx, cassandra.SessionKey(pc, creds, cr)))
+	opts = append(opts, cassandra.RateLimits.For(pc))
+	db := cassandra.RecordStatements(c.newClient(creds, "", opts...), c.recorder, cr)
 
-	db := c.newClient(creds, "")
-
-	return &external{db: db}, nil
+	return cassandra.ReportErrors(&external{db: db, identifiers: pc.Spec.IdentifierCase, propagation: &propagation}), nil
 }
 
 type external struct {
-	db cassandra.DB
+	db          cassandra.DB
+	identifiers apisv1alpha1.IdentifierCase
+
+	// propagation is how to wait for permission changes to become visible.
+	// Changes are not waited for when it is nil.
+	propagation *wait.Backoff
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -140,35 +222,120 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotGrant)
 	}
 
-	role := *cr.Spec.ForProvider.Role
-	keyspace := *cr.Spec.ForProvider.Keyspace
-
-	observedPermissions, resourceExists, err := c.getObservedPermissions(ctx, role, keyspace)
+	roles, err := grantRoles(cr)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	res := c.resourceOf(cr)
+	desiredPermissions := c.getDesiredPermissions(cr.Spec.ForProvider.Privileges, res.permissions)
+	desiredRestricted := c.getDesiredPermissions(cr.Spec.ForProvider.Restricted, res.permissions)
+	managedPerms := managedPermissions(cr)
+
+	d := cassandra.Drift{}
+	resourceExists := false
+	// common holds the permissions shared by all roles, and held those of any
+	// role.
+	var common, restricted map[string]bool
+	held := make(map[string]bool)
+	for _, role := range roles {
+		observedPermissions, exists, err := c.getObservedPermissions(ctx, role, res.name)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		resourceExists = resourceExists || exists
+
+		grant, revoke := diffPermissions(observedPermissions, desiredPermissions, managedPerms, revokeUnmanaged(cr))
+		permissionsDrift(&d, role, "permissions", grant, revoke)
+
+		if cr.Spec.ForProvider.Restricted != nil {
+			observedRestricted, err := c.getObservedRestricted(ctx, role, res.name)
+			if err != nil {
+				return managed.ExternalObservation{}, err
+			}
+			restrict, unrestrict := diffPermissions(observedRestricted, desiredRestricted, nil, true)
+			permissionsDrift(&d, role, "restricted", restrict, unrestrict)
+			if restricted == nil {
+				restricted = observedRestricted
+			}
+			for p := range restricted {
+				restricted[p] = restricted[p] && observedRestricted[p]
+			}
+		}
+
+		if common == nil {
+			common = observedPermissions
+		}
+		for p := range common {
+			common[p] = common[p] && observedPermissions[p]
+		}
+		for p := range observedPermissions {
+			held[p] = true
+		}
+	}
 
-	desiredPermissions := c.getDesiredPermissions(cr.Spec.ForProvider.Privileges)
-	upToDate := c.comparePermissions(observedPermissions, desiredPermissions, &cr.Status.AtProvider)
+	// Track the observed permissions that this Grant is responsible for, so
+	// that they are revoked once they are removed from the spec.
+	tracked := make(map[string]bool)
+	for p := range managedPerms {
+		tracked[p] = held[p]
+	}
+	for p := range desiredPermissions {
+		tracked[p] = held[p]
+	}
+	cr.Status.AtProvider.Privileges = sortedKeys(common)
+	cr.Status.AtProvider.ManagedPrivileges = sortedKeys(tracked)
+	if cr.Spec.ForProvider.Restricted != nil {
+		cr.Status.AtProvider.Restricted = sortedKeys(restricted)
+	}
 
-	if upToDate {
-		cr.Status.AtProvider.Privileges = replaceUnderscoreWithSpace(cr.Spec.ForProvider.Privileges)
+	// Roles that were removed from the spec are tracked until the permissions
+	// and restrictions this Grant gave them are revoked.
+	granted := slices.Clone(roles)
+	for _, role := range removedRoles(cr, roles) {
+		revoke, unrestrict, err := c.leftover(ctx, cr, role)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if len(revoke)+len(unrestrict) == 0 {
+			continue
+		}
+		permissionsDrift(&d, role, "permissions", nil, revoke)
+		permissionsDrift(&d, role, "restricted", nil, unrestrict)
+		granted = append(granted, role)
 	}
+	cr.Status.AtProvider.Roles = granted
 
 	if resourceExists {
 		cr.SetConditions(xpv1.Available())
 	}
 
+	// Permissions that were granted before the Grant are adopted rather than
+	// granted again.
 	return managed.ExternalObservation{
 		ResourceExists:          resourceExists,
-		ResourceLateInitialized: false,
-		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: resourceExists && cassandra.Adopt(cr),
+		ResourceUpToDate:        len(d) == 0,
+		Diff:                    d.String(),
 	}, nil
 }
 
-func (c *external) getObservedPermissions(ctx context.Context, role, keyspace string) (map[string]bool, bool, error) {
-	query := fmt.Sprintf("SELECT permissions FROM system_auth.role_permissions WHERE role = ? AND resource = 'data/%s'", keyspace)
-	iter, err := c.db.Query(ctx, query, role)
+// permissionsDrift records the permissions of a role that are missing and
+// those that are extra.
+func permissionsDrift(d *cassandra.Drift, role, name string, missing, extra []string) {
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing %v", missing))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, fmt.Sprintf("extra %v", extra))
+	}
+	if len(parts) > 0 {
+		*d = append(*d, name+" of "+role+": "+strings.Join(parts, ", "))
+	}
+}
+
+func (c *external) getObservedPermissions(ctx context.Context, role, resource string) (map[string]bool, bool, error) {
+	iter, err := c.db.Query(ctx, "SELECT permissions FROM system_auth.role_permissions WHERE role = ? AND resource = ?", role, resource)
 	if err != nil {
 		return nil, false, errors.Wrap(err, errGrantObserve)
 	}
@@ -181,7 +348,7 @@ func (c *external) getObservedPermissions(ctx context.Context, role, keyspace st
 	observedPermissions := make(map[string]bool)
 	resourceExists := false
 	var permissions []string
-	for c.db.Scan(iter, &permissions) {
+	for iter.Scan(&permissions) {
 		for _, p := range permissions {
 			observedPermissions[p] = true
 		}
@@ -191,31 +358,123 @@ func (c *external) getObservedPermissions(ctx context.Context, role, keyspace st
 	return observedPermissions, resourceExists, nil
 }
 
-func (c *external) getDesiredPermissions(privileges []v1alpha1.GrantPrivilege) map[string]bool {
+// waitForPermissions waits until the role holds the granted permissions and
+// none of the revoked ones on the resource.
+func (c *external) waitForPermissions(ctx context.Context, role, resource string, granted, revoked []string) error {
+	if c.propagation == nil || len(granted)+len(revoked) == 0 {
+		return nil
+	}
+	err := wait.ExponentialBackoffWithContext(ctx, *c.propagation, func(ctx context.Context) (bool, error) {
+		observed, _, err := c.getObservedPermissions(ctx, role, resource)
+		if err != nil {
+			return false, err
+		}
+		for _, p := range granted {
+			if !observed[p] {
+				return false, nil
+			}
+		}
+		for _, p := range revoked {
+			if observed[p] {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	return errors.Wrap(err, errPropagation)
+}
+
+// getObservedRestricted returns the permissions restricted for the role on the
+// resource. The restricted column only exists on DataStax Enterprise.
+func (c *external) getObservedRestricted(ctx context.Context, role, resource string) (map[string]bool, error) {
+	iter, err := c.db.Query(ctx, "SELECT restricted FROM system_auth.role_permissions WHERE role = ? AND resource = ?", role, resource)
+	if err != nil {
+		return nil, errors.Wrap(err, errGrantObserve)
+	}
+
+	observed := make(map[string]bool)
+	var restricted []string
+	for iter.Scan(&restricted) {
+		for _, p := range restricted {
+			observed[p] = true
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, errGrantObserve)
+	}
+	return observed, nil
+}
+
+// syncRestricted restricts the listed permissions for the role on the
+// resource and lifts any other restriction.
+func (c *external) syncRestricted(ctx context.Context, cr *v1alpha1.Grant, role string) error {
+	if cr.Spec.ForProvider.Restricted == nil {
+		return nil
+	}
+	res := c.resourceOf(cr)
+	observed, err := c.getObservedRestricted(ctx, role, res.name)
+	if err != nil {
+		return err
+	}
+	restrict, unrestrict := diffPermissions(observed, c.getDesiredPermissions(cr.Spec.ForProvider.Restricted, res.permissions), nil, true)
+	for _, p := range restrict {
+		query := fmt.Sprintf("RESTRICT %s ON %s TO %s", p, res.cql, cassandra.QuoteIdentifier(role))
+		if err := c.db.Exec(ctx, query); err != nil {
+			return errors.Wrap(err, errRestrict)
+		}
+	}
+	for _, p := range unrestrict {
+		query := fmt.Sprintf("UNRESTRICT %s ON %s FROM %s", p, res.cql, cassandra.QuoteIdentifier(role))
+		if err := c.db.Exec(ctx, query); err != nil {
+			return errors.Wrap(err, errUnrestrict)
+		}
+	}
+	return nil
+}
+
+// getDesiredPermissions returns the permissions Cassandra stores for the
+// privileges. ALL PERMISSIONS is stored as the individual permissions that
+// apply to the resource, so it is expanded to be comparable with observations.
+func (c *external) getDesiredPermissions(privileges []v1alpha1.GrantPrivilege, applicable []string) map[string]bool {
 	desiredPermissions := make(map[string]bool)
 	for _, p := range replaceUnderscoreWithSpace(privileges) {
+		if p == allPermissions {
+			for _, kp := range applicable {
+				desiredPermissions[kp] = true
+			}
+			continue
+		}
 		desiredPermissions[p] = true
 	}
 	return desiredPermissions
 }
 
-func (c *external) comparePermissions(observed, desired map[string]bool, atProvider *v1alpha1.GrantObservation) bool {
-	upToDate := true
-
+// diffPermissions returns the desired permissions that are missing, and the
+// observed permissions that must be revoked because they are no longer
+// desired. Only managed permissions are revoked unless revokeUnmanaged is set.
+func diffPermissions(observed, desired, managed map[string]bool, revokeUnmanaged bool) (grant, revoke []string) {
 	for p := range desired {
 		if !observed[p] {
-			upToDate = false
-			break
+			grant = append(grant, p)
 		}
 	}
-
-	for _, p := range atProvider.Privileges {
-		if !desired[p] {
-			upToDate = false
+	for p := range observed {
+		if !desired[p] && (managed[p] || revokeUnmanaged) {
+			revoke = append(revoke, p)
 		}
 	}
+	sort.Strings(grant)
+	sort.Strings(revoke)
+	return grant, revoke
+}
 
-	return upToDate
+// managedPermissions returns the permissions that were granted by the Grant.
+func managedPermissions(cr *v1alpha1.Grant) map[string]bool {
+	managed := make(map[string]bool)
+	for _, p := range cr.Status.AtProvider.ManagedPrivileges {
+		managed[p] = true
+	}
+	return managed
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -224,15 +483,14 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotGrant)
 	}
 
-	role := *cr.Spec.ForProvider.Role
-	keyspace := *cr.Spec.ForProvider.Keyspace
-	privileges := replaceUnderscoreWithSpace(cr.Spec.ForProvider.Privileges)
+	roles, err := grantRoles(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
-	for _, privilege := range privileges {
-		// we make multiple grants to support yugabyteDB dialect that doesn't allow multiple grants like GRANT SELECT, MODIFY ...
-		query := fmt.Sprintf("GRANT %s ON KEYSPACE %s TO %s", privilege, cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(role))
-		if err := c.db.Exec(ctx, query); err != nil {
-			return managed.ExternalCreation{}, errors.Wrap(err, errGrantCreate)
+	for _, role := range roles {
+		if err := c.syncPermissions(ctx, cr, role); err != nil {
+			return managed.ExternalCreation{}, err
 		}
 	}
 
@@ -245,32 +503,115 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotGrant)
 	}
 
-	role := *cr.Spec.ForProvider.Role
-	keyspace := *cr.Spec.ForProvider.Keyspace
-	privileges := replaceUnderscoreWithSpace(cr.Spec.ForProvider.Privileges)
-	desiredPermissions := make(map[string]bool)
+	roles, err := grantRoles(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
-	for _, privilege := range privileges {
-		query := fmt.Sprintf("GRANT %s ON KEYSPACE %s TO %s", privilege, cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(role))
-		if err := c.db.Exec(ctx, query); err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errGrantCreate)
+	for _, role := range roles {
+		if err := c.syncPermissions(ctx, cr, role); err != nil {
+			return managed.ExternalUpdate{}, err
 		}
-		desiredPermissions[privilege] = true
 	}
+	for _, role := range removedRoles(cr, roles) {
+		if err := c.revokeRemoved(ctx, cr, role); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	cr.Status.AtProvider.Roles = roles
 
-	atProviderPrivileges := cr.Status.AtProvider.Privileges
-	for _, p := range atProviderPrivileges {
-		if !desiredPermissions[p] {
-			query := fmt.Sprintf("REVOKE %s ON KEYSPACE %s FROM %s", p, cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(role))
-			if err := c.db.Exec(ctx, query); err != nil {
-				return managed.ExternalUpdate{}, errors.Wrap(err, errGrantDelete)
-			}
+	return managed.ExternalUpdate{}, nil
+}
+
+// removedRoles returns the roles the Grant gave permissions to that are no
+// longer listed in its spec.
+func removedRoles(cr *v1alpha1.Grant, roles []string) []string {
+	var removed []string
+	for _, r := range cr.Status.AtProvider.Roles {
+		if !slices.Contains(roles, r) {
+			removed = append(removed, r)
 		}
 	}
+	return removed
+}
 
-	cr.Status.AtProvider.Privileges = privileges
+// leftover returns the permissions and restrictions that a role removed from
+// the Grant still holds on the resource because of the Grant.
+func (c *external) leftover(ctx context.Context, cr *v1alpha1.Grant, role string) (revoke, unrestrict []string, err error) {
+	res := c.resourceOf(cr)
+	observed, _, err := c.getObservedPermissions(ctx, role, res.name)
+	if err != nil {
+		return nil, nil, err
+	}
+	granted := managedPermissions(cr)
+	for p := range c.getDesiredPermissions(cr.Spec.ForProvider.Privileges, res.permissions) {
+		granted[p] = true
+	}
+	_, revoke = diffPermissions(observed, nil, granted, false)
 
-	return managed.ExternalUpdate{}, nil
+	if cr.Spec.ForProvider.Restricted == nil {
+		return revoke, nil, nil
+	}
+	observedRestricted, err := c.getObservedRestricted(ctx, role, res.name)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, unrestrict = diffPermissions(observedRestricted, nil, c.getDesiredPermissions(cr.Spec.ForProvider.Restricted, res.permissions), false)
+	return revoke, unrestrict, nil
+}
+
+// revokeRemoved revokes the permissions and lifts the restrictions a role
+// that was removed from the Grant holds because of it.
+func (c *external) revokeRemoved(ctx context.Context, cr *v1alpha1.Grant, role string) error {
+	res := c.resourceOf(cr)
+	revoke, unrestrict, err := c.leftover(ctx, cr, role)
+	if err != nil {
+		return err
+	}
+	if err := c.revokeAll(ctx, res, role, revoke, unrestrict); err != nil {
+		return err
+	}
+	return c.waitForPermissions(ctx, role, res.name, nil, revoke)
+}
+
+// alreadyRevoked reports whether a REVOKE or UNRESTRICT statement failed
+// because its role or resource no longer exists, as when the role was dropped
+// before the Grant revoked its permissions. Nothing is left to revoke then.
+func alreadyRevoked(err error) bool {
+	var reqErr gocql.RequestError
+	return errors.As(err, &reqErr) && reqErr.Code() == gocql.ErrCodeInvalid && strings.Contains(reqErr.Message(), "doesn't exist")
+}
+
+// syncPermissions brings the permissions of the role on the resource in line
+// with the Grant. Only the permissions the role is missing are granted, and
+// only those that must be revoked are revoked, so that permissions that are
+// already up to date do not invalidate the permissions cache.
+func (c *external) syncPermissions(ctx context.Context, cr *v1alpha1.Grant, role string) error {
+	res := c.resourceOf(cr)
+	observed, _, err := c.getObservedPermissions(ctx, role, res.name)
+	if err != nil {
+		return err
+	}
+	desired := c.getDesiredPermissions(cr.Spec.ForProvider.Privileges, res.permissions)
+	grant, revoke := diffPermissions(observed, desired, managedPermissions(cr), revokeUnmanaged(cr))
+
+	for _, p := range grant {
+		// we make multiple grants to support yugabyteDB dialect that doesn't allow multiple grants like GRANT SELECT, MODIFY ...
+		query := fmt.Sprintf("GRANT %s ON %s TO %s", p, res.cql, cassandra.QuoteIdentifier(role))
+		if err := c.db.Exec(ctx, query); err != nil {
+			return errors.Wrap(err, errGrantCreate)
+		}
+	}
+	for _, p := range revoke {
+		query := fmt.Sprintf("REVOKE %s ON %s FROM %s", p, res.cql, cassandra.QuoteIdentifier(role))
+		if err := c.db.Exec(ctx, query); err != nil {
+			return errors.Wrap(err, errGrantDelete)
+		}
+	}
+	if err := c.syncRestricted(ctx, cr, role); err != nil {
+		return err
+	}
+	return c.waitForPermissions(ctx, role, res.name, grant, revoke)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -279,20 +620,121 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotGrant)
 	}
 
-	role := *cr.Spec.ForProvider.Role
-	keyspace := *cr.Spec.ForProvider.Keyspace
+	roles, err := grantRoles(cr)
+	if err != nil {
+		return err
+	}
+	res := c.resourceOf(cr)
+
+	// Permissions the Grant gave that were since removed from its spec are
+	// revoked as well.
 	privileges := replaceUnderscoreWithSpace(cr.Spec.ForProvider.Privileges)
+	desired := c.getDesiredPermissions(cr.Spec.ForProvider.Privileges, res.permissions)
+	for _, p := range cr.Status.AtProvider.ManagedPrivileges {
+		if !desired[p] {
+			privileges = append(privileges, p)
+		}
+	}
+
+	// Roles that were removed from the spec but still hold permissions of
+	// the Grant have them revoked as well.
+	for _, role := range append(roles, removedRoles(cr, roles)...) {
+		if err := c.revokeAll(ctx, res, role, privileges, replaceUnderscoreWithSpace(cr.Spec.ForProvider.Restricted)); err != nil {
+			return err
+		}
+	}
 
+	return nil
+}
+
+// revokeAll revokes the privileges and lifts the restrictions of a role on the
+// resource. Roles that no longer exist hold neither.
+func (c *external) revokeAll(ctx context.Context, res grantResource, role string, privileges, restricted []string) error {
 	for _, privilege := range privileges {
-		query := fmt.Sprintf("REVOKE %s ON KEYSPACE %s FROM %s", privilege, cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(role))
+		query := fmt.Sprintf("REVOKE %s ON %s FROM %s", privilege, res.cql, cassandra.QuoteIdentifier(role))
 		if err := c.db.Exec(ctx, query); err != nil {
+			if alreadyRevoked(err) {
+				return nil
+			}
 			return errors.Wrap(err, errGrantDelete)
 		}
 	}
-
+	for _, p := range restricted {
+		query := fmt.Sprintf("UNRESTRICT %s ON %s FROM %s", p, res.cql, cassandra.QuoteIdentifier(role))
+		if err := c.db.Exec(ctx, query); err != nil {
+			if alreadyRevoked(err) {
+				return nil
+			}
+			return errors.Wrap(err, errUnrestrict)
+		}
+	}
 	return nil
 }
 
+// A grantResource is the resource a Grant is on.
+type grantResource struct {
+	// cql names the resource in GRANT and REVOKE statements.
+	cql string
+	// name is the resource as stored in system_auth.role_permissions.
+	name string
+	// permissions are the permissions that apply to the resource.
+	permissions []string
+}
+
+// resourceOf returns the resource the Grant is on, which is its table if one
+// is set and its keyspace otherwise. Their names are those Cassandra stores.
+func (c *external) resourceOf(cr *v1alpha1.Grant) grantResource {
+	keyspace := cassandra.NormalizeIdentifier(c.identifiers, *cr.Spec.ForProvider.Keyspace)
+	if t := cr.Spec.ForProvider.Table; t != nil {
+		table := cassandra.NormalizeIdentifier(c.identifiers, *t)
+		return grantResource{
+			cql:         "TABLE " + cassandra.QuoteIdentifier(keyspace) + "." + cassandra.QuoteIdentifier(table),
+			name:        "data/" + keyspace + "/" + table,
+			permissions: tablePermissions,
+		}
+	}
+	return grantResource{
+		cql:         "KEYSPACE " + cassandra.QuoteIdentifier(keyspace),
+		name:        "data/" + keyspace,
+		permissions: keyspacePermissions,
+	}
+}
+
+// grantRoles returns the roles the Grant is for, in the order they are listed.
+func grantRoles(cr *v1alpha1.Grant) ([]string, error) {
+	var roles []string
+	if cr.Spec.ForProvider.Role != nil {
+		roles = append(roles, *cr.Spec.ForProvider.Role)
+	}
+	for _, r := range cr.Spec.ForProvider.Roles {
+		if !slices.Contains(roles, r) {
+			roles = append(roles, r)
+		}
+	}
+	if len(roles) == 0 {
+		return nil, errors.New(errNoRoles)
+	}
+	return roles, nil
+}
+
+// revokeUnmanaged reports whether permissions that are not listed in the
+// Grant are revoked.
+func revokeUnmanaged(cr *v1alpha1.Grant) bool {
+	return cr.Spec.ForProvider.RevokeUnmanaged != nil && *cr.Spec.ForProvider.RevokeUnmanaged
+}
+
+// sortedKeys returns the keys of a set in sorted order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k, ok := range m {
+		if ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func replaceUnderscoreWithSpace(privileges []v1alpha1.GrantPrivilege) []string {
 	replaced := make([]string, len(privileges))
 	for i, privilege := range privileges {
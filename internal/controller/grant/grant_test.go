@@ -3,17 +3,23 @@ package grant
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/gocql/gocql"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/wait"
 
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/gocql/gocql"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
 	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
 )
 
@@ -21,6 +27,56 @@ func pointerToString(s string) *string {
 	return &s
 }
 
+func pointerToBool(b bool) *bool {
+	return &b
+}
+
+// observedGrant returns a database holding a single row of the supplied
+// permissions, recording every executed statement.
+func observedGrant(executed *[]string, permissions ...string) *cassandra.MockDB {
+	return &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			return permissionRows(permissions), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+			*executed = append(*executed, query)
+			return nil
+		},
+	}
+}
+
+// permissionRows returns the row of a role_permissions query holding the
+// supplied permissions, or no rows if there are none.
+func permissionRows(permissions []string) *cassandra.MockIterator {
+	if len(permissions) == 0 {
+		return &cassandra.MockIterator{}
+	}
+	return &cassandra.MockIterator{Rows: [][]interface{}{{permissions}}}
+}
+
+func grant(revokeUnmanaged *bool, privileges ...v1alpha1.GrantPrivilege) *v1alpha1.Grant {
+	cr := &v1alpha1.Grant{
+		Spec: v1alpha1.GrantSpec{
+			ForProvider: v1alpha1.GrantParameters{
+				Role:            pointerToString("example_role"),
+				Keyspace:        pointerToString("example_keyspace"),
+				Privileges:      privileges,
+				RevokeUnmanaged: revokeUnmanaged,
+			},
+		},
+	}
+	created(cr)
+	return cr
+}
+
+// created marks a managed resource as having created its object, rather than
+// having adopted one that existed before it.
+func created(mg resource.Managed) {
+	if mg != nil {
+		meta.SetExternalCreateSucceeded(mg, time.Now())
+	}
+}
+
 func TestObserve(t *testing.T) {
 	type fields struct {
 		db cassandra.DB
@@ -36,7 +92,6 @@ func TestObserve(t *testing.T) {
 		err error
 	}
 
-	called := false
 	cases := map[string]struct {
 		reason string
 		fields fields
@@ -55,12 +110,7 @@ func TestObserve(t *testing.T) {
 		"GrantNotFound": {
 			reason: "Should return ResourceExists: false when the grant does not exist",
 			fields: fields{
-				db: &cassandra.MockDB{
-					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error) {
-						return &gocql.Iter{}, nil
-					},
-					ScanFunc: func(iter *gocql.Iter, dest ...interface{}) bool { return false },
-				},
+				db: &cassandra.MockDB{},
 			},
 			args: args{
 				mg: &v1alpha1.Grant{
@@ -79,24 +129,77 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"UnmanagedPermissionIgnored": {
+			reason: "Should ignore permissions granted outside of the Grant by default",
+			fields: fields{
+				db: observedGrant(&[]string{}, "SELECT", "MODIFY"),
+			},
+			args: args{
+				mg: grant(nil, "SELECT"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"UnmanagedPermissionRevoked": {
+			reason: "Should return ResourceUpToDate: false for unmanaged permissions when revokeUnmanaged is set",
+			fields: fields{
+				db: observedGrant(&[]string{}, "SELECT", "MODIFY"),
+			},
+			args: args{
+				mg: grant(pointerToBool(true), "SELECT"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					Diff:             "permissions of example_role: extra [MODIFY]",
+				},
+			},
+		},
+		"AllPermissionsExpanded": {
+			reason: "Should compare ALL_PERMISSIONS with the individual permissions stored for a keyspace",
+			fields: fields{
+				db: observedGrant(&[]string{}, "ALTER", "AUTHORIZE", "CREATE", "DROP", "MODIFY", "SELECT"),
+			},
+			args: args{
+				mg: grant(pointerToBool(true), "ALL_PERMISSIONS"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"AllPermissionsIncomplete": {
+			reason: "Should return ResourceUpToDate: false when a permission of ALL_PERMISSIONS is missing",
+			fields: fields{
+				db: observedGrant(&[]string{}, "ALTER", "CREATE", "DROP", "MODIFY", "SELECT"),
+			},
+			args: args{
+				mg: grant(nil, "ALL_PERMISSIONS"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					Diff:             "permissions of example_role: missing [AUTHORIZE]",
+				},
+			},
+		},
 		"GrantExists": {
 			reason: "Should return ResourceExists: true when the grant exists",
 			fields: fields{
 				db: &cassandra.MockDB{
-					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error) {
-						return &gocql.Iter{}, nil
-					},
-					ScanFunc: func(iter *gocql.Iter, dest ...interface{}) bool {
-						if called {
-							return false // Stop after the first iteration
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						if !strings.HasPrefix(query, "SELECT permissions ") {
+							return &cassandra.MockIterator{}, nil
 						}
-						called = true
-						if len(dest) > 0 {
-							if permissions, ok := dest[0].(*[]string); ok {
-								*permissions = []string{"SELECT", "MODIFY"}
-							}
-						}
-						return true
+						return permissionRows([]string{"SELECT", "MODIFY"}), nil
 					},
 				},
 			},
@@ -123,6 +226,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			created(tc.args.mg)
 			e := external{db: tc.fields.db}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -343,3 +447,549 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestRevokeUnmanaged(t *testing.T) {
+	var executed []string
+	e := external{db: observedGrant(&executed, "SELECT", "MODIFY", "DROP")}
+	if _, err := e.Update(context.Background(), grant(pointerToBool(true), "SELECT")); err != nil {
+		t.Fatalf("Update(...): unexpected error: %v", err)
+	}
+
+	want := []string{
+		"REVOKE DROP ON KEYSPACE \"example_keyspace\" FROM \"example_role\"",
+		"REVOKE MODIFY ON KEYSPACE \"example_keyspace\" FROM \"example_role\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestUpdateFromObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed []string
+		mg       *v1alpha1.Grant
+		want     []string
+	}{
+		"GrantMissing": {
+			reason:   "Should only grant the desired permissions that are not observed",
+			observed: []string{"SELECT"},
+			mg:       grant(nil, "SELECT", "MODIFY"),
+			want: []string{
+				"GRANT MODIFY ON KEYSPACE \"example_keyspace\" TO \"example_role\"",
+			},
+		},
+		"RevokeRemoved": {
+			reason:   "Should revoke managed permissions that were removed from the spec",
+			observed: []string{"SELECT", "MODIFY", "DROP"},
+			mg: func() *v1alpha1.Grant {
+				cr := grant(nil, "SELECT")
+				cr.Status.AtProvider.ManagedPrivileges = []string{"SELECT", "MODIFY"}
+				return cr
+			}(),
+			want: []string{
+				"REVOKE MODIFY ON KEYSPACE \"example_keyspace\" FROM \"example_role\"",
+			},
+		},
+		"RegrantRevokedManually": {
+			reason:   "Should grant a permission again after it was revoked outside of Crossplane",
+			observed: []string{"MODIFY"},
+			mg: func() *v1alpha1.Grant {
+				cr := grant(nil, "SELECT", "MODIFY")
+				cr.Status.AtProvider.Privileges = []string{"SELECT", "MODIFY"}
+				return cr
+			}(),
+			want: []string{
+				"GRANT SELECT ON KEYSPACE \"example_keyspace\" TO \"example_role\"",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var executed []string
+			e := external{db: observedGrant(&executed, tc.observed...)}
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Fatalf("\n%s\nUpdate(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, executed); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveManagedPrivileges(t *testing.T) {
+	cr := grant(nil, "SELECT", "MODIFY")
+	cr.Status.AtProvider.ManagedPrivileges = []string{"DROP", "ALTER"}
+
+	e := external{db: observedGrant(&[]string{}, "SELECT", "DROP", "CREATE")}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+
+	want := v1alpha1.GrantObservation{
+		Privileges:        []string{"CREATE", "DROP", "SELECT"},
+		ManagedPrivileges: []string{"DROP", "SELECT"},
+		Roles:             []string{"example_role"},
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider); diff != "" {
+		t.Errorf("Observe(...): -want status, +got status:\n%s\n", diff)
+	}
+}
+
+func TestMultipleRoles(t *testing.T) {
+	observed := map[string][]string{
+		"reader":  {"SELECT"},
+		"analyst": {"SELECT", "MODIFY"},
+	}
+	var executed []string
+	db := &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			return permissionRows(observed[args[0].(string)]), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+			executed = append(executed, query)
+			return nil
+		},
+	}
+
+	cr := grant(nil, "SELECT", "MODIFY")
+	cr.Spec.ForProvider.Role = pointerToString("reader")
+	cr.Spec.ForProvider.Roles = []string{"analyst", "reader", "auditor"}
+
+	e := external{db: db}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "permissions of reader: missing [MODIFY]; permissions of auditor: missing [MODIFY SELECT]"}, o); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{}, cr.Status.AtProvider.Privileges); diff != "" {
+		t.Errorf("Observe(...): -want privileges, +got privileges:\n%s\n", diff)
+	}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): unexpected error: %v", err)
+	}
+	want := []string{
+		"GRANT MODIFY ON KEYSPACE \"example_keyspace\" TO \"reader\"",
+		"GRANT MODIFY ON KEYSPACE \"example_keyspace\" TO \"auditor\"",
+		"GRANT SELECT ON KEYSPACE \"example_keyspace\" TO \"auditor\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestRemovedRole(t *testing.T) {
+	observed := map[string][]string{
+		"reader":  {"SELECT"},
+		"analyst": {"SELECT", "CREATE"},
+	}
+	var executed []string
+	db := &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			return permissionRows(observed[args[0].(string)]), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+			executed = append(executed, query)
+			return nil
+		},
+	}
+
+	cr := grant(nil, "SELECT")
+	cr.Spec.ForProvider.Role = pointerToString("reader")
+	cr.Status.AtProvider.Roles = []string{"reader", "analyst"}
+
+	e := external{db: db}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "permissions of analyst: extra [SELECT]"}, o); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"reader", "analyst"}, cr.Status.AtProvider.Roles); diff != "" {
+		t.Errorf("Observe(...): -want roles, +got roles:\n%s\n", diff)
+	}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): unexpected error: %v", err)
+	}
+	want := []string{
+		"REVOKE SELECT ON KEYSPACE \"example_keyspace\" FROM \"analyst\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"reader"}, cr.Status.AtProvider.Roles); diff != "" {
+		t.Errorf("Update(...): -want roles, +got roles:\n%s\n", diff)
+	}
+
+	// A removed role that no longer holds permissions of the Grant is no
+	// longer tracked.
+	observed["analyst"] = []string{"CREATE"}
+	cr.Status.AtProvider.Roles = []string{"reader", "analyst"}
+	o, err = e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"reader"}, cr.Status.AtProvider.Roles); diff != "" {
+		t.Errorf("Observe(...): -want roles, +got roles:\n%s\n", diff)
+	}
+
+	executed = nil
+	cr.Status.AtProvider.Roles = []string{"reader", "analyst"}
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("Delete(...): unexpected error: %v", err)
+	}
+	want = []string{
+		"REVOKE SELECT ON KEYSPACE \"example_keyspace\" FROM \"reader\"",
+		"REVOKE SELECT ON KEYSPACE \"example_keyspace\" FROM \"analyst\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Delete(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+// missingRole is the error a cluster returns for statements on a role that
+// does not exist.
+type missingRole struct {
+	gocql.RequestError
+	role string
+}
+
+func (e missingRole) Code() int { return gocql.ErrCodeInvalid }
+
+func (e missingRole) Message() string { return e.role + " doesn't exist" }
+
+func (e missingRole) Error() string { return e.Message() }
+
+func TestDroppedRole(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Dropped": {
+			reason: "Permissions of roles that were dropped should be considered revoked.",
+			err:    missingRole{role: "analyst"},
+		},
+		"Failed": {
+			reason: "Other errors revoking the permissions of a role should be returned.",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errGrantDelete),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &cassandra.MockDB{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+					return permissionRows([]string{"SELECT"}), nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					if strings.HasSuffix(query, `FROM "analyst"`) {
+						return tc.err
+					}
+					return nil
+				},
+			}
+
+			cr := grant(nil, "SELECT")
+			cr.Spec.ForProvider.Role = pointerToString("reader")
+			cr.Status.AtProvider.Roles = []string{"reader", "analyst"}
+
+			e := external{db: db}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+
+			cr.Status.AtProvider.Roles = []string{"reader", "analyst"}
+			err = e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeleteManagedPrivileges(t *testing.T) {
+	var executed []string
+	cr := grant(nil, "SELECT")
+	cr.Status.AtProvider.ManagedPrivileges = []string{"MODIFY", "SELECT"}
+
+	e := external{db: observedGrant(&executed)}
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("Delete(...): unexpected error: %v", err)
+	}
+	want := []string{
+		"REVOKE SELECT ON KEYSPACE \"example_keyspace\" FROM \"example_role\"",
+		"REVOKE MODIFY ON KEYSPACE \"example_keyspace\" FROM \"example_role\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Delete(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestRestricted(t *testing.T) {
+	var executed []string
+	db := &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			if strings.HasPrefix(query, "SELECT restricted") {
+				return permissionRows([]string{"MODIFY"}), nil
+			}
+			return permissionRows([]string{"SELECT"}), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+			executed = append(executed, query)
+			return nil
+		},
+	}
+
+	cr := grant(nil, "SELECT")
+	cr.Spec.ForProvider.Restricted = []v1alpha1.GrantPrivilege{"DROP"}
+
+	e := external{db: db}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "restricted of example_role: missing [DROP], extra [MODIFY]"}, o); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"MODIFY"}, cr.Status.AtProvider.Restricted); diff != "" {
+		t.Errorf("Observe(...): -want restricted, +got restricted:\n%s\n", diff)
+	}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): unexpected error: %v", err)
+	}
+	want := []string{
+		"RESTRICT DROP ON KEYSPACE \"example_keyspace\" TO \"example_role\"",
+		"UNRESTRICT MODIFY ON KEYSPACE \"example_keyspace\" FROM \"example_role\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestWaitForPermissions(t *testing.T) {
+	type want struct {
+		err     error
+		queries int
+	}
+
+	cases := map[string]struct {
+		reason string
+		stale  int
+		want   want
+	}{
+		"Visible": {
+			reason: "We should stop waiting once the granted permissions are observed.",
+			stale:  2,
+			want:   want{queries: 3},
+		},
+		"NeverVisible": {
+			reason: "We should return an error if the granted permissions are never observed.",
+			stale:  5,
+			want: want{
+				err:     errors.Wrap(wait.ErrWaitTimeout, errPropagation),
+				queries: 4,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// The first stale queries are served from a cache that does not
+			// yet hold the granted permission.
+			queries := 0
+			db := &cassandra.MockDB{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+					queries++
+					if queries > tc.stale {
+						return permissionRows([]string{"SELECT"}), nil
+					}
+					return permissionRows(nil), nil
+				},
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					return nil
+				},
+			}
+
+			e := external{db: db, propagation: &wait.Backoff{Duration: time.Millisecond, Steps: 3}}
+			_, err := e.Create(context.Background(), grant(nil, "SELECT"))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.queries, queries); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want queries, +got queries:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	var executed []string
+	var resources []string
+	db := &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			resources = append(resources, args[1].(string))
+			return permissionRows([]string{"ALTER", "AUTHORIZE", "DROP", "MODIFY", "SELECT"}), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+			executed = append(executed, query)
+			return nil
+		},
+	}
+
+	cr := grant(nil, "ALL_PERMISSIONS")
+	cr.Spec.ForProvider.Table = pointerToString("example_table")
+
+	e := external{db: db}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"data/example_keyspace/example_table"}, resources); diff != "" {
+		t.Errorf("Observe(...): -want resources, +got resources:\n%s\n", diff)
+	}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("Delete(...): unexpected error: %v", err)
+	}
+	want := []string{
+		"REVOKE ALL PERMISSIONS ON TABLE \"example_keyspace\".\"example_table\" FROM \"example_role\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Delete(...): -want, +got:\n%s\n", diff)
+	}
+
+	executed = nil
+	e = external{db: observedGrant(&executed)}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create(...): unexpected error: %v", err)
+	}
+	want = []string{
+		"GRANT ALTER ON TABLE \"example_keyspace\".\"example_table\" TO \"example_role\"",
+		"GRANT AUTHORIZE ON TABLE \"example_keyspace\".\"example_table\" TO \"example_role\"",
+		"GRANT DROP ON TABLE \"example_keyspace\".\"example_table\" TO \"example_role\"",
+		"GRANT MODIFY ON TABLE \"example_keyspace\".\"example_table\" TO \"example_role\"",
+		"GRANT SELECT ON TABLE \"example_keyspace\".\"example_table\" TO \"example_role\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Create(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestIdentifierCase(t *testing.T) {
+	var executed []string
+	var resources []string
+	db := &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			resources = append(resources, args[1].(string))
+			return permissionRows([]string{"SELECT"}), nil
+		},
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+			executed = append(executed, query)
+			return nil
+		},
+	}
+
+	cr := grant(nil, "SELECT")
+	cr.Spec.ForProvider.Keyspace = pointerToString("Example_Keyspace")
+	cr.Spec.ForProvider.Table = pointerToString("Example_Table")
+
+	e := external{db: db, identifiers: apisv1alpha1.IdentifierCaseLower}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"data/example_keyspace/example_table"}, resources); diff != "" {
+		t.Errorf("Observe(...): -want resources, +got resources:\n%s\n", diff)
+	}
+
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("Delete(...): unexpected error: %v", err)
+	}
+	want := []string{
+		"REVOKE SELECT ON TABLE \"example_keyspace\".\"example_table\" FROM \"example_role\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Delete(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestCreateDelta(t *testing.T) {
+	var executed []string
+	e := external{db: observedGrant(&executed, "SELECT")}
+	if _, err := e.Create(context.Background(), grant(nil, "SELECT", "MODIFY")); err != nil {
+		t.Fatalf("Create(...): unexpected error: %v", err)
+	}
+	want := []string{
+		"GRANT MODIFY ON KEYSPACE \"example_keyspace\" TO \"example_role\"",
+	}
+	if diff := cmp.Diff(want, executed); diff != "" {
+		t.Errorf("Create(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	path := field.NewPath("spec", "forProvider")
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.GrantParameters
+		want   field.ErrorList
+	}{
+		"Valid": {
+			reason: "A grant on a table of a keyspace to roles with valid names is valid.",
+			params: v1alpha1.GrantParameters{
+				Role:     pointerToString("app-user"),
+				Roles:    []string{"ops"},
+				Keyspace: pointerToString("data"),
+				Table:    pointerToString("events"),
+			},
+			want: field.ErrorList{},
+		},
+		"Unresolved": {
+			reason: "A grant whose roles and keyspace are yet to be resolved from references is valid.",
+			want:   field.ErrorList{},
+		},
+		"Invalid": {
+			reason: "Every invalid identifier of a grant should be reported.",
+			params: v1alpha1.GrantParameters{
+				Roles:    []string{"ops", "select"},
+				Keyspace: pointerToString("my-keyspace"),
+			},
+			want: field.ErrorList{
+				field.Invalid(path.Child("roles").Index(1), "select", "must not be a reserved CQL keyword"),
+				field.Invalid(path.Child("keyspace"), "my-keyspace", "must only contain alphanumeric characters and underscores"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := validate(&v1alpha1.Grant{Spec: v1alpha1.GrantSpec{ForProvider: tc.params}})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nvalidate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+	"github.com/crossplane/provider-cassandra/internal/features"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+const (
+	errNotIndex           = "managed resource is not an Index custom resource"
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+	errGetPC              = "cannot get ProviderConfig"
+	errGetCreds           = "cannot get credentials"
+	errUnsupportedDialect = "Amazon Keyspaces does not support secondary indexes"
+	errNewClient          = "cannot create new Service"
+
+	errNoKeyspace  = "keyspace is not set"
+	errSelectIndex = "cannot select index"
+	errCreateIndex = "cannot create index"
+	errDropIndex   = "cannot drop index"
+
+	kindCustom = "CUSTOM"
+)
+
+// indexClasses maps short names of well known index implementations to the
+// class names accepted by CREATE CUSTOM INDEX ... USING.
+var indexClasses = map[string]string{
+	"sai":                  "StorageAttachedIndex",
+	"storageattachedindex": "StorageAttachedIndex",
+	"sasi":                 "org.apache.cassandra.index.sasi.SASIIndex",
+	"sasiindex":            "org.apache.cassandra.index.sasi.SASIIndex",
+}
+
+// Setup adds a controller that reconciles Index managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.IndexGroupKind)
+
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newClient: cassandra.Sessions.New,
+			recorder:  recorder}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(cassandra.PollInterval),
+		managed.WithRecorder(recorder),
+	}
+
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.IndexGroupVersionKind), opts...)
+
+	// Indexes whose identifiers cannot be used in CQL are rejected on
+	// admission.
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Index{}).
+		WithValidator(cassandra.IdentifierValidator[*v1alpha1.Index](validate)).
+		Complete(); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Index{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// validate returns the errors of the name of an Index and of the keyspace and
+// table it is created on.
+func validate(cr *v1alpha1.Index) field.ErrorList {
+	path := field.NewPath("spec", "forProvider")
+	errs := cassandra.ValidateExternalName(cr, cassandra.ValidateName)
+	if ks := cr.Spec.ForProvider.Keyspace; ks != nil {
+		errs = append(errs, cassandra.ValidateName(path.Child("keyspace"), *ks)...)
+	}
+	return append(errs, cassandra.ValidateName(path.Child("table"), cr.Spec.ForProvider.Table)...)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+	recorder  event.Recorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Index)
+	if !ok {
+		return nil, errors.New(errNotIndex)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	if pc.Spec.Dialect == apisv1alpha1.DialectAmazonKeyspaces {
+		return nil, errors.New(errUnsupportedDialect)
+	}
+
+	creds, err := cassandra.ProviderCredentials(ctx, c.kube, pc.Spec.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	opts, err := cassandra.ProviderConfigOptions(ctx, c.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	ropts, err := cassandra.ResourceOptions(cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	opts = append(opts, ropts...)
+	opts = append(opts, cassandra.WithSharedSession(ctx, cassandra.SessionKey(pc, creds, cr)))
+	opts = append(opts, cassandra.RateLimits.For(pc))
+	db := cassandra.RecordStatements(c.newClient(creds, "", opts...), c.recorder, cr)
+
+	return cassandra.ReportErrors(&external{db: db, async: cassandra.AwaitSchemaSettled(pc.Spec), conflicts: pc.Spec.ConflictPolicy, identifiers: pc.Spec.IdentifierCase}), nil
+}
+
+type external struct {
+	db          cassandra.DB
+	async       bool
+	conflicts   apisv1alpha1.ConflictPolicy
+	identifiers apisv1alpha1.IdentifierCase
+}
+
+// normalize returns the name of the index in the cluster, and its parameters
+// with the names of its keyspace and table in the cluster.
+func (c *external) normalize(cr *v1alpha1.Index) (string, v1alpha1.IndexParameters) {
+	params := cr.Spec.ForProvider
+	if params.Keyspace != nil {
+		ks := cassandra.NormalizeIdentifier(c.identifiers, *params.Keyspace)
+		params.Keyspace = &ks
+	}
+	params.Table = cassandra.NormalizeIdentifier(c.identifiers, params.Table)
+	return cassandra.NormalizeIdentifier(c.identifiers, meta.GetExternalName(cr)), params
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Index)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotIndex)
+	}
+
+	name, params := c.normalize(cr)
+	if params.Keyspace == nil {
+		return managed.ExternalObservation{}, errors.New(errNoKeyspace)
+	}
+
+	query := "SELECT kind, options FROM system_schema.indexes WHERE keyspace_name = ? AND table_name = ? AND index_name = ?"
+	iter, err := c.db.Query(ctx, query, *params.Keyspace, params.Table, name)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectIndex)
+	}
+
+	var kind string
+	options := map[string]string{}
+	found := iter.Scan(&kind, &options)
+	if err := iter.Close(); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectIndex)
+	}
+	if !found {
+		return managed.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: false,
+		}, nil
+	}
+
+	// An object that already exists is only adopted if the conflict policy
+	// of the ProviderConfig allows it.
+	if err := cassandra.Conflict(cr, c.conflicts); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// The schema change that created the index may not have reached every
+	// node of a cluster whose schema changes are asynchronous yet. The index
+	// is not ready until it has, so that the resources that depend on it are
+	// not created through nodes that do not know it.
+	if c.async {
+		settled, err := cassandra.Settled(ctx, c.db, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if !settled {
+			cr.SetConditions(xpv1.Creating())
+			return managed.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+			}, nil
+		}
+	}
+
+	cr.Status.AtProvider = v1alpha1.IndexObservation{
+		Kind:      kind,
+		ClassName: options["class_name"],
+	}
+	cr.SetConditions(xpv1.Available())
+
+	d := drift(kind, options, params)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(d) == 0,
+		Diff:             d.String(),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Index)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotIndex)
+	}
+
+	name, params := c.normalize(cr)
+	if params.Keyspace == nil {
+		return managed.ExternalCreation{}, errors.New(errNoKeyspace)
+	}
+
+	if err := c.db.Exec(ctx, createIndexStatement(name, params, c.conflicts)); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateIndex)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Index)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotIndex)
+	}
+
+	name, params := c.normalize(cr)
+	if params.Keyspace == nil {
+		return managed.ExternalUpdate{}, errors.New(errNoKeyspace)
+	}
+
+	// Indexes cannot be altered, so a changed index is dropped and rebuilt.
+	if err := c.db.Exec(ctx, dropIndexStatement(name, params)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDropIndex)
+	}
+	if err := c.db.Exec(ctx, createIndexStatement(name, params, c.conflicts)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCreateIndex)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Index)
+	if !ok {
+		return errors.New(errNotIndex)
+	}
+
+	name, params := c.normalize(cr)
+	if params.Keyspace == nil {
+		return errors.New(errNoKeyspace)
+	}
+
+	if err := c.db.Exec(ctx, dropIndexStatement(name, params)); err != nil {
+		return errors.Wrap(err, errDropIndex)
+	}
+
+	return nil
+}
+
+func createIndexStatement(name string, params v1alpha1.IndexParameters, p apisv1alpha1.ConflictPolicy) string {
+	table := cassandra.QuoteIdentifier(*params.Keyspace) + "." + cassandra.QuoteIdentifier(params.Table)
+	if params.Using == nil {
+		return fmt.Sprintf("CREATE INDEX %s%s ON %s (%s)", cassandra.IfNotExists(p),
+			cassandra.QuoteIdentifier(name), table, cassandra.QuoteIdentifier(params.Column))
+	}
+
+	query := fmt.Sprintf("CREATE CUSTOM INDEX %s%s ON %s (%s) USING %s", cassandra.IfNotExists(p),
+		cassandra.QuoteIdentifier(name), table, cassandra.QuoteIdentifier(params.Column), cassandra.QuoteString(indexClass(*params.Using)))
+	if len(params.Options) == 0 {
+		return query
+	}
+
+	return query + " WITH OPTIONS = " + cassandra.QuoteMap(params.Options)
+}
+
+func dropIndexStatement(name string, params v1alpha1.IndexParameters) string {
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s.%s", cassandra.QuoteIdentifier(*params.Keyspace), cassandra.QuoteIdentifier(name))
+}
+
+// indexClass resolves well known short names to the class name used in the
+// USING clause.
+func indexClass(using string) string {
+	if class, ok := indexClasses[strings.ToLower(using)]; ok {
+		return class
+	}
+	return using
+}
+
+// simpleClassName strips the package of a fully qualified class name.
+func simpleClassName(class string) string {
+	return class[strings.LastIndex(class, ".")+1:]
+}
+
+func upToDate(kind string, options map[string]string, desired v1alpha1.IndexParameters) bool {
+	return len(drift(kind, options, desired)) == 0
+}
+
+// drift returns how the observed kind and options of an index differ from the
+// desired ones.
+func drift(kind string, options map[string]string, desired v1alpha1.IndexParameters) cassandra.Drift {
+	d := cassandra.Drift{}
+	if target := strings.Trim(options["target"], `"`); target != desired.Column {
+		d.Field("target", desired.Column, target)
+	}
+	if desired.Using == nil {
+		if kind == kindCustom {
+			d.Field("class_name", "unset", simpleClassName(options["class_name"]))
+		}
+		return d
+	}
+	if kind != kindCustom {
+		d.Field("kind", kindCustom, kind)
+	}
+	if want, got := simpleClassName(indexClass(*desired.Using)), simpleClassName(options["class_name"]); want != got {
+		d.Field("class_name", want, got)
+	}
+	keys := make([]string, 0, len(desired.Options))
+	for k := range desired.Options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if options[k] != desired.Options[k] {
+			d.Field("options."+k, desired.Options[k], options[k])
+		}
+	}
+	return d
+}
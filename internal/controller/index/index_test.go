@@ -0,0 +1,256 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+)
+
+func pointerToString(s string) *string {
+	return &s
+}
+
+func index(using *string, options map[string]string) *v1alpha1.Index {
+	return &v1alpha1.Index{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"crossplane.io/external-name": "users_email_idx",
+			},
+		},
+		Spec: v1alpha1.IndexSpec{
+			ForProvider: v1alpha1.IndexParameters{
+				Keyspace: pointerToString("example_keyspace"),
+				Table:    "users",
+				Column:   "email",
+				Using:    using,
+				Options:  options,
+			},
+		},
+	}
+}
+
+func observedIndex(kind string, options map[string]string) *cassandra.MockDB {
+	return &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			if options == nil {
+				return &cassandra.MockIterator{}, nil
+			}
+			return &cassandra.MockIterator{Rows: [][]interface{}{{kind, options}}}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type fields struct {
+		db cassandra.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotIndex": {
+			reason: "Should return an error if the managed resource is not an *Index",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotIndex),
+			},
+		},
+		"IndexNotFound": {
+			reason: "Should return ResourceExists: false when the index does not exist",
+			fields: fields{
+				db: observedIndex("", nil),
+			},
+			args: args{
+				mg: index(nil, nil),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"SecondaryIndexUpToDate": {
+			reason: "Should return ResourceUpToDate: true for a matching secondary index",
+			fields: fields{
+				db: observedIndex("COMPOSITES", map[string]string{"target": "email"}),
+			},
+			args: args{
+				mg: index(nil, nil),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"SAIUpToDate": {
+			reason: "Should match a StorageAttachedIndex by its simple class name",
+			fields: fields{
+				db: observedIndex("CUSTOM", map[string]string{
+					"target":         "email",
+					"class_name":     "org.apache.cassandra.index.sai.StorageAttachedIndex",
+					"case_sensitive": "false",
+					"normalize":      "true",
+				}),
+			},
+			args: args{
+				mg: index(pointerToString("sai"), map[string]string{"case_sensitive": "false"}),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"OptionsDrifted": {
+			reason: "Should return ResourceUpToDate: false when custom index options differ",
+			fields: fields{
+				db: observedIndex("CUSTOM", map[string]string{
+					"target":     "email",
+					"class_name": "org.apache.cassandra.index.sasi.SASIIndex",
+					"mode":       "PREFIX",
+				}),
+			},
+			args: args{
+				mg: index(pointerToString("SASIIndex"), map[string]string{"mode": "CONTAINS"}),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "options.mode: want CONTAINS, got PREFIX"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		query  string
+		err    error
+		want   error
+	}{
+		"ErrNotIndex": {
+			reason: "Should return an error if the managed resource is not an *Index",
+			mg:     nil,
+			want:   errors.New(errNotIndex),
+		},
+		"SecondaryIndex": {
+			reason: "Should create a regular secondary index when no implementation is set",
+			mg:     index(nil, nil),
+			query:  "CREATE INDEX IF NOT EXISTS \"users_email_idx\" ON \"example_keyspace\".\"users\" (\"email\")",
+		},
+		"StorageAttachedIndex": {
+			reason: "Should create a custom index with options",
+			mg:     index(pointerToString("StorageAttachedIndex"), map[string]string{"normalize": "true", "case_sensitive": "false"}),
+			query:  "CREATE CUSTOM INDEX IF NOT EXISTS \"users_email_idx\" ON \"example_keyspace\".\"users\" (\"email\") USING 'StorageAttachedIndex' WITH OPTIONS = {'case_sensitive': 'false', 'normalize': 'true'}",
+		},
+		"SASIIndex": {
+			reason: "Should expand the SASI short name to its class name",
+			mg:     index(pointerToString("SASIIndex"), nil),
+			query:  "CREATE CUSTOM INDEX IF NOT EXISTS \"users_email_idx\" ON \"example_keyspace\".\"users\" (\"email\") USING 'org.apache.cassandra.index.sasi.SASIIndex'",
+		},
+		"ErrCreate": {
+			reason: "Should return an error if the index cannot be created",
+			mg:     index(nil, nil),
+			query:  "CREATE INDEX IF NOT EXISTS \"users_email_idx\" ON \"example_keyspace\".\"users\" (\"email\")",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errCreateIndex),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					if query != tc.query {
+						return errors.New("unexpected query: " + query)
+					}
+					return tc.err
+				},
+			}}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		err    error
+		want   error
+	}{
+		"ErrNotIndex": {
+			reason: "Should return an error if the managed resource is not an *Index",
+			mg:     nil,
+			want:   errors.New(errNotIndex),
+		},
+		"DropIndex": {
+			reason: "Should drop the index",
+			mg:     index(nil, nil),
+		},
+		"ErrDrop": {
+			reason: "Should return an error if the index cannot be dropped",
+			mg:     index(nil, nil),
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errDropIndex),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					if query != "DROP INDEX IF EXISTS \"example_keyspace\".\"users_email_idx\"" {
+						return errors.New("unexpected query: " + query)
+					}
+					return tc.err
+				},
+			}}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
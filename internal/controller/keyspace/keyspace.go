@@ -18,12 +18,16 @@ package keyspace
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
@@ -39,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	"github.com/crossplane/provider-cassandra/apis/cql/v1beta1"
 	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
 	"github.com/crossplane/provider-cassandra/internal/features"
 )
@@ -53,30 +58,94 @@ const (
 	errCreateKeyspace = "cannot create keyspace"
 	errUpdateKeyspace = "cannot update keyspace"
 	errDropKeyspace   = "cannot drop keyspace"
+	errSelectTables   = "cannot select keyspace tables"
+	errNotEmpty       = "refusing to drop keyspace because it still contains tables"
+	errListDependents = "cannot list the managed resources that depend on the keyspace"
+	errDependents     = "refusing to drop keyspace until the managed resources that depend on it are deleted: %s"
+	errGraphEngine    = "cannot determine graph engine support"
+	errSystemKeyspace = "manageSystemKeyspace must be set to manage a system keyspace"
+	errLocalKeyspace  = "local system keyspaces cannot be managed"
+	errOverReplicated = "replication factor exceeds the number of nodes, which makes QUORUM reads and writes fail: %s"
 	maxConcurrency    = 5
 	defaultStrategy   = "SimpleStrategy"
+	ntsStrategy       = "NetworkTopologyStrategy"
 	defaultReplicas   = 1
 )
 
+const (
+	reasonOrphaned       event.Reason = "OrphanedKeyspace"
+	reasonOverReplicated event.Reason = "ReplicationFactorExceedsNodes"
+)
+
+// systemKeyspaces are the replicated system keyspaces. Their replication may
+// be managed, but they are never dropped.
+var systemKeyspaces = map[string]bool{
+	"system_auth":        true,
+	"system_distributed": true,
+	"system_traces":      true,
+}
+
+// localKeyspaces are the system keyspaces that use LocalStrategy and cannot be
+// altered at all.
+var localKeyspaces = map[string]bool{
+	"system":                true,
+	"system_schema":         true,
+	"system_views":          true,
+	"system_virtual_schema": true,
+}
+
 // Setup adds a controller that reconciles Keyspace managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.KeyspaceGroupKind)
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
-		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind, connection.WithTLSConfig(o.ESSOptions.TLSConfig)))
 	}
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.KeyspaceGroupVersionKind),
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			kube:      mgr.GetClient(),
 			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newClient: cassandra.New}),
+			newClient: cassandra.Sessions.New,
+			recorder:  recorder}),
+		managed.WithFinalizer(&orphanFinalizer{
+			Finalizer: resource.NewAPIFinalizer(mgr.GetClient(), managed.FinalizerName),
+			recorder:  recorder}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithConnectionPublishers(cps...))
+		managed.WithPollIntervalHook(cassandra.PollInterval),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...),
+	}
+
+	// An existing keyspace can be imported for observation only by setting
+	// its management policies to Observe. It is then never created, altered
+	// or dropped, and its live settings are reported in the status.
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.KeyspaceGroupVersionKind), opts...)
+
+	// Keyspaces of other API versions are converted to and from v1alpha1.
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&v1beta1.Keyspace{}).Complete(); err != nil {
+		return err
+	}
+
+	// Keyspaces whose identifiers cannot be used in CQL are rejected on
+	// admission, and those that are created are given the defaults they do
+	// not set.
+	d := &defaulter{kube: mgr.GetClient()}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Keyspace{}).
+		WithValidator(cassandra.IdentifierValidator[*v1alpha1.Keyspace](validate)).
+		WithDefaulter(cassandra.Defaulter[*v1alpha1.Keyspace](d.Default)).
+		Complete(); err != nil {
+		return err
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -86,10 +155,120 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
+// validate returns the errors of the name of a Keyspace.
+func validate(cr *v1alpha1.Keyspace) field.ErrorList {
+	return cassandra.ValidateExternalName(cr, cassandra.ValidateName)
+}
+
+// A defaulter fills the replication and durable writes a Keyspace is created
+// with when it does not set them. The defaults are set in initProvider, so
+// that a keyspace that already exists is adopted as it is.
+type defaulter struct {
+	kube client.Client
+}
+
+// Default fills the defaults of a Keyspace. A keyspace of a cluster whose
+// datacenters are known, from the health checks of its ProviderConfig or its
+// local datacenter, is replicated to each of them with NetworkTopologyStrategy
+// rather than with SimpleStrategy.
+func (d *defaulter) Default(ctx context.Context, cr *v1alpha1.Keyspace) error {
+	p, i := cr.Spec.ForProvider, &cr.Spec.InitProvider
+	if p.ReplicationClass == nil && p.ReplicationFactor == nil && len(p.Datacenters) == 0 &&
+		i.ReplicationClass == nil && i.ReplicationFactor == nil && len(i.Datacenters) == 0 {
+		dcs, err := d.datacenters(ctx, cr)
+		if err != nil {
+			return err
+		}
+		strategy, rf := defaultStrategy, defaultReplicas
+		if len(dcs) > 0 {
+			strategy = ntsStrategy
+			i.Datacenters = map[string]int{}
+			for _, dc := range dcs {
+				i.Datacenters[dc] = rf
+			}
+		} else {
+			i.ReplicationFactor = &rf
+		}
+		i.ReplicationClass = &strategy
+	}
+	if p.DurableWrites == nil && i.DurableWrites == nil {
+		durable := true
+		i.DurableWrites = &durable
+	}
+	return nil
+}
+
+// datacenters returns the datacenters of the cluster of the ProviderConfig
+// of a Keyspace, if they are known.
+func (d *defaulter) datacenters(ctx context.Context, cr *v1alpha1.Keyspace) ([]string, error) {
+	ref := cr.GetProviderConfigReference()
+	if ref == nil {
+		return nil, nil
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := d.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return nil, errors.Wrap(client.IgnoreNotFound(err), errGetPC)
+	}
+	if c := pc.Status.Cluster; c != nil && len(c.Datacenters) > 0 {
+		return c.Datacenters, nil
+	}
+	if dc := pc.Spec.LocalDatacenter; dc != nil && *dc != "" {
+		return []string{*dc}, nil
+	}
+	return nil, nil
+}
+
+// An orphanFinalizer emits an event when a keyspace is released from
+// management without being dropped, so that data left behind on purpose is
+// visible to operators.
+type orphanFinalizer struct {
+	resource.Finalizer
+	recorder event.Recorder
+}
+
+func (f *orphanFinalizer) RemoveFinalizer(ctx context.Context, obj resource.Object) error {
+	if err := f.Finalizer.RemoveFinalizer(ctx, obj); err != nil {
+		return err
+	}
+	if cr, ok := obj.(*v1alpha1.Keyspace); ok && meta.WasDeleted(cr) && orphaned(cr) {
+		f.recorder.Event(cr, event.Normal(reasonOrphaned, "Keyspace "+meta.GetExternalName(cr)+" was released from management and its data was left in place"))
+	}
+	return nil
+}
+
+// orphaned reports whether the keyspace should be kept when the Keyspace is
+// deleted.
+func orphaned(cr *v1alpha1.Keyspace) bool {
+	if cr.GetDeletionPolicy() == xpv1.DeletionOrphan {
+		return true
+	}
+	if p := cr.GetManagementPolicies(); len(p) > 0 && !slices.Contains(p, xpv1.ManagementActionAll) && !slices.Contains(p, xpv1.ManagementActionDelete) {
+		return true
+	}
+	if name := meta.GetExternalName(cr); systemKeyspaces[name] || localKeyspaces[name] {
+		return true
+	}
+	return cr.Spec.ForProvider.SkipDrop != nil && *cr.Spec.ForProvider.SkipDrop
+}
+
+// checkSystemKeyspace returns an error if the keyspace is a system keyspace
+// that may not be managed.
+func checkSystemKeyspace(cr *v1alpha1.Keyspace) error {
+	name := meta.GetExternalName(cr)
+	if localKeyspaces[name] {
+		return errors.New(errLocalKeyspace)
+	}
+	if systemKeyspaces[name] && (cr.Spec.ForProvider.ManageSystemKeyspace == nil || !*cr.Spec.ForProvider.ManageSystemKeyspace) {
+		return errors.New(errSystemKeyspace)
+	}
+	return nil
+}
+
 type connector struct {
 	kube      client.Client
 	usage     resource.Tracker
-	newClient func(creds map[string][]byte, keyspace string) cassandra.DB
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+	recorder  event.Recorder
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -107,32 +286,39 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	credsData, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	creds, err := cassandra.ProviderCredentials(ctx, c.kube, pc.Spec.Credentials)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	// Convert the byte array to a string and parse the JSON
-	credsJSON := string(credsData)
-	var credsMap map[string]string
-	if err := json.Unmarshal([]byte(credsJSON), &credsMap); err != nil {
-		return nil, errors.Wrap(err, "failed to parse credentials JSON")
+	opts, err := cassandra.ProviderConfigOptions(ctx, c.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
 	}
-
-	// Convert map[string]string to map[string][]byte
-	creds := make(map[string][]byte)
-	for k, v := range credsMap {
-		creds[k] = []byte(v)
+	ropts, err := cassandra.ResourceOptions(cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
 	}
+	opts = append(opts, ropts...)
+	opts = append(opts, cassandra.WithSharedSession(ctx, cassandra.SessionKey(pc, creds, cr)))
+	opts = append(opts, cassandra.RateLimits.For(pc))
+	db := cassandra.RecordStatements(c.newClient(creds, "", opts...), c.recorder, cr)
 
-	db := c.newClient(creds, "")
-
-	return &external{db: db}, nil
+	return cassandra.ReportErrors(&external{db: db, kube: c.kube, async: cassandra.AwaitSchemaSettled(pc.Spec), conflicts: pc.Spec.ConflictPolicy, identifiers: pc.Spec.IdentifierCase, recorder: c.recorder}), nil
 }
 
 type external struct {
-	db cassandra.DB
+	db          cassandra.DB
+	kube        client.Client
+	async       bool
+	conflicts   apisv1alpha1.ConflictPolicy
+	identifiers apisv1alpha1.IdentifierCase
+	recorder    event.Recorder
+}
+
+// name returns the name of the keyspace in the cluster.
+func (c *external) name(cr *v1alpha1.Keyspace) string {
+	return cassandra.NormalizeIdentifier(c.identifiers, meta.GetExternalName(cr))
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -141,6 +327,16 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotKeyspace)
 	}
 
+	// Report a keyspace that is kept on deletion as gone, so that the managed
+	// resource is released without issuing DROP KEYSPACE.
+	if meta.WasDeleted(cr) && orphaned(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if err := checkSystemKeyspace(cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	exists, err := c.keyspaceExists(ctx, cr)
 	if err != nil {
 		return managed.ExternalObservation{}, err
@@ -152,24 +348,87 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
+	// An object that already exists is only adopted if the conflict policy
+	// of the ProviderConfig allows it.
+	if err := cassandra.Conflict(cr, c.conflicts); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// The schema change that created the keyspace may not have reached every
+	// node of a cluster whose schema changes are asynchronous yet. The keyspace
+	// is not ready until it has, so that the resources that depend on it are
+	// not created through nodes that do not know it.
+	if c.async {
+		settled, err := cassandra.Settled(ctx, c.db, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if !settled {
+			cr.SetConditions(xpv1.Creating())
+			return managed.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+			}, nil
+		}
+	}
+
 	observed, err := c.getKeyspaceDetails(ctx, cr)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
 
+	if cr.Spec.ForProvider.GraphEngine != nil {
+		if observed.GraphEngine, err = c.getGraphEngine(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if observed.GraphEngine != nil {
+			cr.Status.AtProvider.GraphEngine = *observed.GraphEngine
+		}
+	}
+
+	if cr.Status.AtProvider.TableCount, err = c.countTables(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	cr.SetConditions(xpv1.Available())
 
+	// A keyspace that existed before the Keyspace is adopted as it is.
+	adopted := cassandra.Adopt(cr)
+
+	// Settings that are only set in initProvider are neither late initialized
+	// nor compared, so that they may drift once the keyspace is created.
+	li := lateInit(withoutInitOnly(observed, cr.Spec), &cr.Spec.ForProvider) || adopted
+	desired := withInitOnly(cr.Spec, observed)
+
+	d := drift(observed, &desired)
+
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceLateInitialized: lateInit(observed, &cr.Spec.ForProvider),
-		ResourceUpToDate:        upToDate(observed, &cr.Spec.ForProvider),
+		ResourceLateInitialized: li,
+		ResourceUpToDate:        len(d) == 0,
+		ConnectionDetails:       connectionDetails(c.db, cr, c.name(cr)),
+		Diff:                    d.String(),
 	}, nil
 }
 
+// connectionDetails returns the connection details of a keyspace, which tell
+// workloads which keyspace to use and how to reach its cluster, but not how to
+// authenticate to it. They are only returned if they are published.
+func connectionDetails(db cassandra.DB, cr *v1alpha1.Keyspace, name string) managed.ConnectionDetails {
+	if cr.GetWriteConnectionSecretToReference() == nil && cr.GetPublishConnectionDetailsTo() == nil {
+		return nil
+	}
+	cd := db.GetConnectionDetails("", "")
+	delete(cd, xpv1.ResourceCredentialsSecretUserKey)
+	delete(cd, xpv1.ResourceCredentialsSecretPasswordKey)
+	cd[cassandra.ConnectionSecretKeyspaceKey] = []byte(name)
+	return cd
+}
+
 func (c *external) keyspaceExists(ctx context.Context, cr *v1alpha1.Keyspace) (bool, error) {
 	query := "SELECT keyspace_name FROM system_schema.keyspaces WHERE keyspace_name = ?"
 	var keyspaceName string
-	iter, err := c.db.Query(ctx, query, meta.GetExternalName(cr))
+	iter, err := c.db.Query(ctx, query, c.name(cr))
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check keyspace existence")
 	}
@@ -179,7 +438,7 @@ func (c *external) keyspaceExists(ctx context.Context, cr *v1alpha1.Keyspace) (b
 		}
 	}()
 
-	if !c.db.Scan(iter, &keyspaceName) {
+	if !iter.Scan(&keyspaceName) {
 		return false, nil
 	}
 	return true, nil
@@ -187,7 +446,7 @@ func (c *external) keyspaceExists(ctx context.Context, cr *v1alpha1.Keyspace) (b
 
 func (c *external) getKeyspaceDetails(ctx context.Context, cr *v1alpha1.Keyspace) (*v1alpha1.KeyspaceParameters, error) {
 	query := "SELECT replication, durable_writes FROM system_schema.keyspaces WHERE keyspace_name = ?"
-	iter, err := c.db.Query(ctx, query, meta.GetExternalName(cr))
+	iter, err := c.db.Query(ctx, query, c.name(cr))
 	if err != nil {
 		return nil, errors.Wrap(err, errSelectKeyspace)
 	}
@@ -204,7 +463,7 @@ func (c *external) getKeyspaceDetails(ctx context.Context, cr *v1alpha1.Keyspace
 	}
 
 	replicationMap := map[string]string{}
-	if !c.db.Scan(iter, &replicationMap, &observed.DurableWrites) {
+	if !iter.Scan(&replicationMap, &observed.DurableWrites) {
 		return nil, errors.New("failed to scan keyspace attributes")
 	}
 
@@ -216,37 +475,124 @@ func (c *external) getKeyspaceDetails(ctx context.Context, cr *v1alpha1.Keyspace
 		*observed.ReplicationFactor = rfInt
 	}
 
+	cr.Status.AtProvider = observation(replicationMap, *observed.DurableWrites)
+	observed.Datacenters = cr.Status.AtProvider.Datacenters
+
 	return observed, nil
 }
 
-func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*v1alpha1.Keyspace)
-	if !ok {
-		return managed.ExternalCreation{}, errors.New(errNotKeyspace)
+// observation builds the observed state of a keyspace from its replication
+// map. Every key other than class and replication_factor is a datacenter of
+// a NetworkTopologyStrategy keyspace.
+func observation(replication map[string]string, durableWrites bool) v1alpha1.KeyspaceObservation {
+	o := v1alpha1.KeyspaceObservation{
+		ReplicationClass: strings.TrimPrefix(replication["class"], "org.apache.cassandra.locator."),
+		DurableWrites:    durableWrites,
+	}
+	for k, v := range replication {
+		rf, _ := strconv.Atoi(v)
+		switch k {
+		case "class":
+		case "replication_factor":
+			o.ReplicationFactor = rf
+		default:
+			if o.Datacenters == nil {
+				o.Datacenters = map[string]int{}
+			}
+			o.Datacenters[k] = rf
+		}
 	}
+	return o
+}
 
-	params := cr.Spec.ForProvider
-	strategy := defaultStrategy
-	if params.ReplicationClass != nil {
-		strategy = *params.ReplicationClass
+// countTables returns the number of tables in the keyspace.
+func (c *external) countTables(ctx context.Context, cr *v1alpha1.Keyspace) (int, error) {
+	iter, err := c.db.Query(ctx, "SELECT COUNT(*) FROM system_schema.tables WHERE keyspace_name = ?", c.name(cr))
+	if err != nil {
+		return 0, errors.Wrap(err, errSelectTables)
 	}
+	var count int64
+	iter.Scan(&count)
+	if err := iter.Close(); err != nil {
+		return 0, errors.Wrap(err, errSelectTables)
+	}
+	return int(count), nil
+}
 
-	replicationFactor := defaultReplicas
-	if params.ReplicationFactor != nil {
-		replicationFactor = *params.ReplicationFactor
+// supportsGraphEngine reports whether the cluster is running DataStax
+// Enterprise with DSE Graph, which exposes a graph_engine keyspace option.
+func (c *external) supportsGraphEngine(ctx context.Context) (bool, error) {
+	query := "SELECT column_name FROM system_schema.columns WHERE keyspace_name = 'system_schema' AND table_name = 'keyspaces' AND column_name = 'graph_engine'"
+	iter, err := c.db.Query(ctx, query)
+	if err != nil {
+		return false, errors.Wrap(err, errGraphEngine)
+	}
+	var column string
+	supported := iter.Scan(&column)
+	if err := iter.Close(); err != nil {
+		return false, errors.Wrap(err, errGraphEngine)
+	}
+	return supported, nil
+}
+
+// getGraphEngine returns the graph engine of the keyspace, or nil when the
+// cluster does not support DSE Graph.
+func (c *external) getGraphEngine(ctx context.Context, cr *v1alpha1.Keyspace) (*string, error) {
+	supported, err := c.supportsGraphEngine(ctx)
+	if err != nil || !supported {
+		return nil, err
+	}
+
+	iter, err := c.db.Query(ctx, "SELECT graph_engine FROM system_schema.keyspaces WHERE keyspace_name = ?", c.name(cr))
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectKeyspace)
+	}
+	engine := new(string)
+	iter.Scan(engine)
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, errSelectKeyspace)
+	}
+	return engine, nil
+}
+
+// graphEngineClause returns the graph_engine option to append to CREATE and
+// ALTER KEYSPACE statements. It is empty when no graph engine is requested or
+// the cluster does not support DSE Graph.
+func (c *external) graphEngineClause(ctx context.Context, params v1alpha1.KeyspaceParameters) (string, error) {
+	if params.GraphEngine == nil {
+		return "", nil
+	}
+	supported, err := c.supportsGraphEngine(ctx)
+	if err != nil || !supported {
+		return "", err
+	}
+	return " AND graph_engine = " + cassandra.QuoteString(*params.GraphEngine), nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Keyspace)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotKeyspace)
 	}
 
+	params := createParameters(cr.Spec)
 	durableWrites := true
 	if params.DurableWrites != nil {
 		durableWrites = *params.DurableWrites
 	}
 
-	query := "CREATE KEYSPACE IF NOT EXISTS " + cassandra.QuoteIdentifier(meta.GetExternalName(cr)) +
-		" WITH replication = {'class': '" + strategy + "', 'replication_factor': " + strconv.Itoa(replicationFactor) + "} AND durable_writes = " + strconv.FormatBool(durableWrites)
+	graphEngine, err := c.graphEngineClause(ctx, params)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	query := "CREATE KEYSPACE " + cassandra.IfNotExists(c.conflicts) + cassandra.QuoteIdentifier(c.name(cr)) +
+		" WITH replication = " + replication(params) + " AND durable_writes = " + strconv.FormatBool(durableWrites) + graphEngine
 
 	if err := c.db.Exec(ctx, query); err != nil {
-		return managed.ExternalCreation{}, errors.New(errCreateKeyspace + ": " + err.Error())
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateKeyspace)
 	}
+	c.warnOverReplicated(ctx, cr, params)
 
 	return managed.ExternalCreation{}, nil
 }
@@ -257,28 +603,42 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotKeyspace)
 	}
 
-	params := cr.Spec.ForProvider
-	strategy := defaultStrategy
-	if params.ReplicationClass != nil {
-		strategy = *params.ReplicationClass
+	observed, err := c.getKeyspaceDetails(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if cr.Spec.ForProvider.GraphEngine != nil {
+		if observed.GraphEngine, err = c.getGraphEngine(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
 	}
 
-	replicationFactor := defaultReplicas
-	if params.ReplicationFactor != nil {
-		replicationFactor = *params.ReplicationFactor
+	// Keep the settings that are only set in initProvider as they are.
+	params := withInitOnly(cr.Spec, observed)
+	if partialReplication(&params) {
+		// Keep the replication of datacenters that are not listed in the
+		// spec.
+		dcs := maps.Clone(observed.Datacenters)
+		if dcs == nil {
+			dcs = map[string]int{}
+		}
+		maps.Copy(dcs, params.Datacenters)
+		params.Datacenters = dcs
 	}
 
-	durableWrites := true
-	if params.DurableWrites != nil {
-		durableWrites = *params.DurableWrites
+	// Only the settings that drifted are altered, as altering the
+	// replication of a keyspace is a schema change that requires a repair.
+	clauses := alterClauses(observed, &params)
+	if len(clauses) == 0 {
+		return managed.ExternalUpdate{}, nil
 	}
 
-	query := "ALTER KEYSPACE " + cassandra.QuoteIdentifier(meta.GetExternalName(cr)) +
-		" WITH replication = {'class': '" + strategy + "', 'replication_factor': " + strconv.Itoa(replicationFactor) + "} AND durable_writes = " + strconv.FormatBool(durableWrites)
+	query := "ALTER KEYSPACE " + cassandra.QuoteIdentifier(c.name(cr)) + " WITH " + strings.Join(clauses, " AND ")
 
 	if err := c.db.Exec(ctx, query); err != nil {
-		return managed.ExternalUpdate{}, errors.New(errUpdateKeyspace + ": " + err.Error())
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateKeyspace)
 	}
+	c.warnOverReplicated(ctx, cr, params)
 
 	return managed.ExternalUpdate{}, nil
 }
@@ -289,39 +649,356 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotKeyspace)
 	}
 
-	query := "DROP KEYSPACE IF EXISTS " + cassandra.QuoteIdentifier(meta.GetExternalName(cr))
+	if orphaned(cr) {
+		return nil
+	}
+
+	// Managed resources left behind would fail against the dropped keyspace
+	// until they are deleted, so it is dropped once they are gone.
+	dependents, err := c.dependents(ctx, cr)
+	if err != nil {
+		return errors.Wrap(err, errListDependents)
+	}
+	if len(dependents) > 0 {
+		return errors.Errorf(errDependents, strings.Join(dependents, ", "))
+	}
+
+	if r := cr.Spec.ForProvider.RequireEmptyOnDelete; r != nil && *r {
+		empty, err := c.keyspaceEmpty(ctx, cr)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return errors.New(errNotEmpty)
+		}
+	}
+
+	query := "DROP KEYSPACE IF EXISTS " + cassandra.QuoteIdentifier(c.name(cr))
 	if err := c.db.Exec(ctx, query); err != nil {
-		return errors.New(errDropKeyspace + ": " + err.Error())
+		return errors.Wrap(err, errDropKeyspace)
 	}
 
 	return nil
 }
 
-func upToDate(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) bool {
+// dependents returns the kinds and names of the managed resources in the
+// keyspace, which are those of the same ProviderConfig that are for the
+// keyspace.
+func (c *external) dependents(ctx context.Context, cr *v1alpha1.Keyspace) ([]string, error) {
+	lists := []resource.ManagedList{
+		&v1alpha1.TableList{},
+		&v1alpha1.IndexList{},
+		&v1alpha1.GrantList{},
+		&v1alpha1.SeedDataList{},
+		&v1alpha1.KeyspaceSchemaExportList{},
+	}
+	var dependents []string
+	for _, l := range lists {
+		if err := c.kube.List(ctx, l); err != nil {
+			return nil, err
+		}
+		for _, mg := range l.GetItems() {
+			kind, ks := keyspaceOf(mg)
+			if ks == nil || cassandra.NormalizeIdentifier(c.identifiers, *ks) != c.name(cr) || !sameProviderConfig(mg, cr) {
+				continue
+			}
+			dependents = append(dependents, kind+"/"+mg.GetName())
+		}
+	}
+	return dependents, nil
+}
+
+// keyspaceOf returns the kind of a managed resource and the keyspace it is
+// for, if any.
+func keyspaceOf(mg resource.Managed) (string, *string) {
+	switch cr := mg.(type) {
+	case *v1alpha1.Table:
+		return v1alpha1.TableKind, cr.Spec.ForProvider.Keyspace
+	case *v1alpha1.Index:
+		return v1alpha1.IndexKind, cr.Spec.ForProvider.Keyspace
+	case *v1alpha1.Grant:
+		return v1alpha1.GrantKind, cr.Spec.ForProvider.Keyspace
+	case *v1alpha1.SeedData:
+		return v1alpha1.SeedDataKind, cr.Spec.ForProvider.Keyspace
+	case *v1alpha1.KeyspaceSchemaExport:
+		return v1alpha1.KeyspaceSchemaExportKind, cr.Spec.ForProvider.Keyspace
+	}
+	return "", nil
+}
+
+// sameProviderConfig reports whether two managed resources are of the same
+// ProviderConfig.
+func sameProviderConfig(a, b resource.Managed) bool {
+	ra, rb := a.GetProviderConfigReference(), b.GetProviderConfigReference()
+	if ra == nil || rb == nil {
+		return ra == rb
+	}
+	return ra.Name == rb.Name
+}
+
+// keyspaceEmpty reports whether the keyspace contains no tables.
+func (c *external) keyspaceEmpty(ctx context.Context, cr *v1alpha1.Keyspace) (bool, error) {
+	iter, err := c.db.Query(ctx, "SELECT table_name FROM system_schema.tables WHERE keyspace_name = ? LIMIT 1", c.name(cr))
+	if err != nil {
+		return false, errors.Wrap(err, errSelectTables)
+	}
+	var table string
+	found := iter.Scan(&table)
+	if err := iter.Close(); err != nil {
+		return false, errors.Wrap(err, errSelectTables)
+	}
+	return !found, nil
+}
+
+// warnOverReplicated emits a warning event if the keyspace is replicated to
+// more replicas than a datacenter has nodes, as QUORUM reads and writes then
+// fail once a node of the datacenter is down, or always when the replication
+// factor exceeds twice the number of nodes. The warning is best effort: it is
+// not emitted when the nodes of the cluster cannot be counted.
+func (c *external) warnOverReplicated(ctx context.Context, cr *v1alpha1.Keyspace, params v1alpha1.KeyspaceParameters) {
+	nodes, err := c.nodes(ctx)
+	if err != nil || len(nodes) == 0 {
+		return
+	}
+	if over := overReplicated(params, nodes); len(over) > 0 {
+		c.recorder.Event(cr, event.Warning(reasonOverReplicated, errors.Errorf(errOverReplicated, strings.Join(over, ", "))))
+	}
+}
+
+// nodes returns the number of nodes in each datacenter of the cluster.
+func (c *external) nodes(ctx context.Context) (map[string]int, error) {
+	nodes := map[string]int{}
+	for _, query := range []string{"SELECT data_center FROM system.local", "SELECT data_center FROM system.peers"} {
+		iter, err := c.db.Query(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		var dc string
+		for iter.Scan(&dc) {
+			nodes[dc]++
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// overReplicated describes the datacenters whose replication factor exceeds
+// their number of nodes, or the cluster if the keyspace is replicated with a
+// single replication factor that exceeds its number of nodes.
+func overReplicated(params v1alpha1.KeyspaceParameters, nodes map[string]int) []string {
+	if len(params.Datacenters) == 0 {
+		rf := defaultReplicas
+		if params.ReplicationFactor != nil {
+			rf = *params.ReplicationFactor
+		}
+		total := 0
+		for _, n := range nodes {
+			total += n
+		}
+		if rf <= total {
+			return nil
+		}
+		return []string{fmt.Sprintf("cluster (replication factor %d, %d nodes)", rf, total)}
+	}
+
+	var over []string
+	for dc, rf := range params.Datacenters {
+		if rf > nodes[dc] {
+			over = append(over, fmt.Sprintf("%s (replication factor %d, %d nodes)", dc, rf, nodes[dc]))
+		}
+	}
+	sort.Strings(over)
+	return over
+}
+
+// replication returns the replication map of the keyspace in CQL syntax.
+func replication(params v1alpha1.KeyspaceParameters) string {
+	strategy := defaultStrategy
+	if params.ReplicationClass != nil {
+		strategy = *params.ReplicationClass
+	}
+
+	if len(params.Datacenters) > 0 {
+		dcs := make([]string, 0, len(params.Datacenters))
+		for dc := range params.Datacenters {
+			dcs = append(dcs, dc)
+		}
+		sort.Strings(dcs)
+
+		r := "{'class': " + cassandra.QuoteString(strategy)
+		for _, dc := range dcs {
+			r += ", " + cassandra.QuoteString(dc) + ": " + strconv.Itoa(params.Datacenters[dc])
+		}
+		return r + "}"
+	}
+
+	replicationFactor := defaultReplicas
+	if params.ReplicationFactor != nil {
+		replicationFactor = *params.ReplicationFactor
+	}
+	return "{'class': " + cassandra.QuoteString(strategy) + ", 'replication_factor': " + strconv.Itoa(replicationFactor) + "}"
+}
+
+// createParameters returns the parameters a keyspace is created with: those of
+// forProvider, and those of initProvider that forProvider does not set.
+func createParameters(spec v1alpha1.KeyspaceSpec) v1alpha1.KeyspaceParameters {
+	params := spec.ForProvider
+	if initReplication(spec) {
+		params.ReplicationClass = spec.InitProvider.ReplicationClass
+		params.ReplicationFactor = spec.InitProvider.ReplicationFactor
+		params.Datacenters = spec.InitProvider.Datacenters
+	}
+	if initDurableWrites(spec) {
+		params.DurableWrites = spec.InitProvider.DurableWrites
+	}
+	return params
+}
+
+// initReplication reports whether the replication of the keyspace is only set
+// in initProvider.
+func initReplication(spec v1alpha1.KeyspaceSpec) bool {
+	p, i := spec.ForProvider, spec.InitProvider
+	return p.ReplicationClass == nil && p.ReplicationFactor == nil && len(p.Datacenters) == 0 &&
+		(i.ReplicationClass != nil || i.ReplicationFactor != nil || len(i.Datacenters) > 0)
+}
+
+// initDurableWrites reports whether durable writes are only set in
+// initProvider.
+func initDurableWrites(spec v1alpha1.KeyspaceSpec) bool {
+	return spec.ForProvider.DurableWrites == nil && spec.InitProvider.DurableWrites != nil
+}
+
+// withInitOnly returns the parameters of forProvider, with the settings that
+// are only set in initProvider set to their observed values.
+func withInitOnly(spec v1alpha1.KeyspaceSpec, observed *v1alpha1.KeyspaceParameters) v1alpha1.KeyspaceParameters {
+	params := spec.ForProvider
+	if initReplication(spec) {
+		params.ReplicationClass = observed.ReplicationClass
+		params.ReplicationFactor = observed.ReplicationFactor
+		params.Datacenters = observed.Datacenters
+	}
+	if initDurableWrites(spec) {
+		params.DurableWrites = observed.DurableWrites
+	}
+	return params
+}
+
+// withoutInitOnly returns the observed parameters without the settings that
+// are only set in initProvider.
+func withoutInitOnly(observed *v1alpha1.KeyspaceParameters, spec v1alpha1.KeyspaceSpec) *v1alpha1.KeyspaceParameters {
+	o := *observed
+	if initReplication(spec) {
+		o.ReplicationClass, o.ReplicationFactor, o.Datacenters = nil, nil, nil
+	}
+	if initDurableWrites(spec) {
+		o.DurableWrites = nil
+	}
+	return &o
+}
+
+// partialReplication reports whether only the listed datacenters of the
+// keyspace are managed.
+func partialReplication(params *v1alpha1.KeyspaceParameters) bool {
+	return params.PartialReplication != nil && *params.PartialReplication && len(params.Datacenters) > 0
+}
+
+// alterClauses returns the options of an ALTER KEYSPACE statement that change
+// the settings that drifted, and no others.
+func alterClauses(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) []string {
+	var clauses []string
+	if len(replicationDrift(observed, desired)) > 0 {
+		clauses = append(clauses, "replication = "+replication(*desired))
+	}
+	if len(durableWritesDrift(observed, desired)) > 0 {
+		durableWrites := true
+		if desired.DurableWrites != nil {
+			durableWrites = *desired.DurableWrites
+		}
+		clauses = append(clauses, "durable_writes = "+strconv.FormatBool(durableWrites))
+	}
+	if len(graphEngineDrift(observed, desired)) > 0 {
+		clauses = append(clauses, "graph_engine = "+cassandra.QuoteString(*desired.GraphEngine))
+	}
+	return clauses
+}
+
+// drift returns how the observed settings of a keyspace differ from the
+// desired ones.
+func drift(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) cassandra.Drift {
+	d := replicationDrift(observed, desired)
+	d = append(d, durableWritesDrift(observed, desired)...)
+	return append(d, graphEngineDrift(observed, desired)...)
+}
+
+// replicationDrift returns how the observed replication of a keyspace differs
+// from the desired one.
+func replicationDrift(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) cassandra.Drift {
+	d := cassandra.Drift{}
 	if observed.ReplicationClass == nil || desired.ReplicationClass == nil || *observed.ReplicationClass != *desired.ReplicationClass {
-		return false
+		d.Field("class", cassandra.Value(desired.ReplicationClass), cassandra.Value(observed.ReplicationClass))
 	}
-	if observed.ReplicationFactor == nil || desired.ReplicationFactor == nil || *observed.ReplicationFactor != *desired.ReplicationFactor {
-		return false
+	if partialReplication(desired) {
+		dcs := make([]string, 0, len(desired.Datacenters))
+		for dc := range desired.Datacenters {
+			dcs = append(dcs, dc)
+		}
+		sort.Strings(dcs)
+		for _, dc := range dcs {
+			if observed.Datacenters[dc] != desired.Datacenters[dc] {
+				d.Field(dc, desired.Datacenters[dc], observed.Datacenters[dc])
+			}
+		}
+	} else if len(desired.Datacenters) > 0 {
+		if !maps.Equal(observed.Datacenters, desired.Datacenters) {
+			d.Field("datacenters", desired.Datacenters, observed.Datacenters)
+		}
+	} else if observed.ReplicationFactor == nil || desired.ReplicationFactor == nil || *observed.ReplicationFactor != *desired.ReplicationFactor {
+		d.Field("replication_factor", cassandra.Value(desired.ReplicationFactor), cassandra.Value(observed.ReplicationFactor))
 	}
+	return d
+}
+
+// durableWritesDrift returns how the observed durable_writes of a keyspace
+// differs from the desired one.
+func durableWritesDrift(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) cassandra.Drift {
+	d := cassandra.Drift{}
 	if observed.DurableWrites == nil || desired.DurableWrites == nil || *observed.DurableWrites != *desired.DurableWrites {
-		return false
+		d.Field("durable_writes", cassandra.Value(desired.DurableWrites), cassandra.Value(observed.DurableWrites))
 	}
-	return true
+	return d
+}
+
+// graphEngineDrift returns how the observed graph engine of a keyspace
+// differs from the desired one.
+func graphEngineDrift(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) cassandra.Drift {
+	d := cassandra.Drift{}
+	// The graph engine is only observed on clusters that support it.
+	if observed.GraphEngine != nil && desired.GraphEngine != nil && !strings.EqualFold(*observed.GraphEngine, *desired.GraphEngine) {
+		d.Field("graph_engine", *desired.GraphEngine, *observed.GraphEngine)
+	}
+	return d
 }
 
 func lateInit(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) bool {
 	li := false
 
-	if desired.ReplicationClass == nil {
+	if desired.ReplicationClass == nil && observed.ReplicationClass != nil {
 		desired.ReplicationClass = observed.ReplicationClass
 		li = true
 	}
-	if desired.ReplicationFactor == nil {
-		desired.ReplicationFactor = observed.ReplicationFactor
-		li = true
+	if desired.ReplicationFactor == nil && len(desired.Datacenters) == 0 {
+		switch {
+		case len(observed.Datacenters) > 0 && desired.ReplicationClass != nil && *desired.ReplicationClass == ntsStrategy:
+			desired.Datacenters = observed.Datacenters
+			li = true
+		case observed.ReplicationFactor != nil && *observed.ReplicationFactor > 0:
+			desired.ReplicationFactor = observed.ReplicationFactor
+			li = true
+		}
 	}
-	if desired.DurableWrites == nil {
+	if desired.DurableWrites == nil && observed.DurableWrites != nil {
 		desired.DurableWrites = observed.DurableWrites
 		li = true
 	}
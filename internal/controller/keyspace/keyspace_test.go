@@ -3,21 +3,31 @@ package keyspace
 import (
 	"context"
 	"testing"
+	"time"
 
-	"github.com/gocql/gocql"
 	"github.com/pkg/errors"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/gocql/gocql"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
 	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
 )
 
+var now = metav1.Now()
+
 func pointerToString(s string) *string {
 	return &s
 }
@@ -30,13 +40,35 @@ func pointerToBool(b bool) *bool {
 	return &b
 }
 
+// observedKeyspace returns a QueryFunc serving an existing keyspace of the
+// supplied replication with durable writes, on a cluster without DSE Graph.
+func observedKeyspace(replication map[string]string) func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+	return func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+		switch query {
+		case "SELECT keyspace_name FROM system_schema.keyspaces WHERE keyspace_name = ?":
+			return &cassandra.MockIterator{Rows: [][]interface{}{{"example_keyspace"}}}, nil
+		case "SELECT replication, durable_writes FROM system_schema.keyspaces WHERE keyspace_name = ?":
+			return &cassandra.MockIterator{Rows: [][]interface{}{{replication, pointerToBool(true)}}}, nil
+		}
+		return &cassandra.MockIterator{}, nil
+	}
+}
+
+// created marks a managed resource as having created its object, rather than
+// having adopted one that existed before it.
+func created(mg resource.Managed) {
+	if mg != nil {
+		meta.SetExternalCreateSucceeded(mg, time.Now())
+	}
+}
+
 func TestConnect(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	type fields struct {
 		kube      resource.ClientApplicator
 		usage     resource.Tracker
-		newClient func(creds map[string][]byte, keyspace string) cassandra.DB
+		newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
 	}
 
 	type args struct {
@@ -75,6 +107,41 @@ func TestConnect(t *testing.T) {
 				err: errors.Wrap(errBoom, errTrackPCUsage),
 			},
 		},
+		"ErrConsistencyAnnotation": {
+			reason: "Should return an error when the consistency a resource overrides its ProviderConfig with is invalid",
+			fields: fields{
+				kube: resource.ClientApplicator{Client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						switch o := obj.(type) {
+						case *apisv1alpha1.ProviderConfig:
+							o.Spec.Credentials = apisv1alpha1.ProviderCredentials{
+								Source: xpv1.CredentialsSourceSecret,
+								CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+									SecretRef: &xpv1.SecretKeySelector{Key: "credentials"},
+								},
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{"credentials": []byte(`{"username":"cassandra","password":"cassandra"}`)}
+						}
+						return nil
+					},
+				}},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{cassandra.AnnotationKeyConsistency: "SOME"},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errors.New(`invalid consistency "SOME"`), "cannot parse consistency"), errNewClient),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -123,16 +190,74 @@ func TestObserve(t *testing.T) {
 				err: errors.New(errNotKeyspace),
 			},
 		},
-		"KeyspaceNotFound": {
-			reason: "Should return ResourceExists: false when the keyspace does not exist",
+		"ReleaseSkippedKeyspace": {
+			reason: "Should return ResourceExists: false without querying when a keyspace with skipDrop is deleted",
 			fields: fields{
 				db: &cassandra.MockDB{
-					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error) {
-						return &gocql.Iter{}, nil
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						return nil, errors.New("unexpected query: " + query)
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+						DeletionTimestamp: &now,
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							SkipDrop: pointerToBool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrSystemKeyspaceNotAllowed": {
+			reason: "Should return an error when a system keyspace is managed without manageSystemKeyspace",
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "system_auth",
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errSystemKeyspace),
+			},
+		},
+		"ErrLocalKeyspace": {
+			reason: "Should return an error when a local system keyspace is managed",
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "system_schema",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ManageSystemKeyspace: pointerToBool(true),
+						},
 					},
-					ScanFunc: func(iter *gocql.Iter, dest ...interface{}) bool { return false },
 				},
 			},
+			want: want{
+				err: errors.New(errLocalKeyspace),
+			},
+		},
+		"KeyspaceNotFound": {
+			reason: "Should return ResourceExists: false when the keyspace does not exist",
+			fields: fields{
+				db: &cassandra.MockDB{},
+			},
 			args: args{
 				mg: &v1alpha1.Keyspace{},
 			},
@@ -146,27 +271,7 @@ func TestObserve(t *testing.T) {
 			reason: "Should return ResourceExists: true when the keyspace exists",
 			fields: fields{
 				db: &cassandra.MockDB{
-					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error) {
-						return &gocql.Iter{}, nil
-					},
-					ScanFunc: func(iter *gocql.Iter, dest ...interface{}) bool {
-						if len(dest) == 1 {
-							if name, ok := dest[0].(*string); ok {
-								*name = "example_keyspace"
-							}
-							return true
-						} else if len(dest) == 2 {
-							if replicationMap, ok := dest[0].(*map[string]string); ok {
-								(*replicationMap)["class"] = "SimpleStrategy"
-								(*replicationMap)["replication_factor"] = "2"
-							}
-							if durableWrites, ok := dest[1].(**bool); ok && durableWrites != nil {
-								*durableWrites = pointerToBool(true)
-							}
-							return true
-						}
-						return false
-					},
+					QueryFunc: observedKeyspace(map[string]string{"class": "SimpleStrategy", "replication_factor": "2"}),
 				},
 			},
 			args: args{
@@ -192,27 +297,7 @@ func TestObserve(t *testing.T) {
 			reason: "Should return LateInit if some params need be backfield",
 			fields: fields{
 				db: &cassandra.MockDB{
-					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error) {
-						return &gocql.Iter{}, nil
-					},
-					ScanFunc: func(iter *gocql.Iter, dest ...interface{}) bool {
-						if len(dest) == 1 {
-							if name, ok := dest[0].(*string); ok {
-								*name = "example_keyspace"
-							}
-							return true
-						} else if len(dest) == 2 {
-							if replicationMap, ok := dest[0].(*map[string]string); ok {
-								(*replicationMap)["class"] = "SimpleStrategy"
-								(*replicationMap)["replication_factor"] = "2"
-							}
-							if durableWrites, ok := dest[1].(**bool); ok && durableWrites != nil {
-								*durableWrites = pointerToBool(true)
-							}
-							return true
-						}
-						return false
-					},
+					QueryFunc: observedKeyspace(map[string]string{"class": "SimpleStrategy", "replication_factor": "2"}),
 				},
 			},
 			args: args{
@@ -233,33 +318,68 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
-		"ResourceOutdated": {
-			reason: "Should return ResourceUpToDate: false if out of date",
+		"DatacentersOutdated": {
+			reason: "Should return ResourceUpToDate: false if the replication factor of a datacenter differs",
 			fields: fields{
 				db: &cassandra.MockDB{
-					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error) {
-						return &gocql.Iter{}, nil
+					QueryFunc: observedKeyspace(map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "3", "dc2": "1"}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass: pointerToString("NetworkTopologyStrategy"),
+							Datacenters:      map[string]int{"dc1": 3, "dc2": 2},
+							DurableWrites:    pointerToBool(true),
+						},
 					},
-					ScanFunc: func(iter *gocql.Iter, dest ...interface{}) bool {
-						if len(dest) == 1 {
-							if name, ok := dest[0].(*string); ok {
-								*name = "example_keyspace"
-							}
-							return true
-						} else if len(dest) == 2 {
-							if replicationMap, ok := dest[0].(*map[string]string); ok {
-								(*replicationMap)["class"] = "SimpleStrategy"
-								(*replicationMap)["replication_factor"] = "3"
-							}
-							if durableWrites, ok := dest[1].(**bool); ok && durableWrites != nil {
-								*durableWrites = pointerToBool(true)
-							}
-							return true
-						}
-						return false
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					Diff:                    "datacenters: want map[dc1:3 dc2:2], got map[dc1:3 dc2:1]",
+					ResourceLateInitialized: false,
+				},
+			},
+		},
+		"InitialReplicationDrifted": {
+			reason: "Should neither compare nor late initialize a replication that is only set in initProvider",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: observedKeyspace(map[string]string{"class": "SimpleStrategy", "replication_factor": "3"}),
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							DurableWrites: pointerToBool(true),
+						},
+						InitProvider: v1alpha1.KeyspaceInitParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(2),
+						},
 					},
 				},
 			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+			},
+		},
+		"ResourceOutdated": {
+			reason: "Should return ResourceUpToDate: false if out of date",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: observedKeyspace(map[string]string{"class": "SimpleStrategy", "replication_factor": "3"}),
+				},
+			},
 			args: args{
 				mg: &v1alpha1.Keyspace{
 					Spec: v1alpha1.KeyspaceSpec{
@@ -275,6 +395,7 @@ func TestObserve(t *testing.T) {
 				o: managed.ExternalObservation{
 					ResourceExists:          true,
 					ResourceUpToDate:        false,
+					Diff:                    "replication_factor: want 2, got 3",
 					ResourceLateInitialized: false,
 				},
 			},
@@ -283,6 +404,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			created(tc.args.mg)
 			e := external{db: tc.fields.db}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -327,14 +449,14 @@ func TestUpdate(t *testing.T) {
 				err: errors.New(errNotKeyspace),
 			},
 		},
-		"UpdateKeyspaceSuccess": {
-			reason: "Should successfully update the keyspace if the update query succeeds",
+		"UpdatePartialReplication": {
+			reason: "Should keep the replication of datacenters that are not listed when partialReplication is set",
 			fields: fields{
 				db: &cassandra.MockDB{
+					QueryFunc: observedKeyspace(map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "3", "dc3": "1"}),
 					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
-						expectedQuery := "ALTER KEYSPACE \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 2} AND durable_writes = true"
+						expectedQuery := "ALTER KEYSPACE \"example_keyspace\" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3, 'dc2': 2, 'dc3': 1}"
 						if query != expectedQuery {
-
 							return errors.New("unexpected query: " + query)
 						}
 						return nil
@@ -350,29 +472,40 @@ func TestUpdate(t *testing.T) {
 					},
 					Spec: v1alpha1.KeyspaceSpec{
 						ForProvider: v1alpha1.KeyspaceParameters{
-							ReplicationClass:  pointerToString("SimpleStrategy"),
-							ReplicationFactor: pointerToInt(2),
-							DurableWrites:     pointerToBool(true),
+							ReplicationClass:   pointerToString("NetworkTopologyStrategy"),
+							Datacenters:        map[string]int{"dc1": 3, "dc2": 2},
+							PartialReplication: pointerToBool(true),
+							DurableWrites:      pointerToBool(true),
 						},
 					},
 				},
 			},
 			want: want{
-				u:   managed.ExternalUpdate{},
 				err: nil,
 			},
 		},
-		"UpdateKeyspaceFailure": {
-			reason: "Should return an error if the update query fails",
+		"UpdateKeyspaceSuccess": {
+			reason: "Should successfully update the keyspace if the update query succeeds",
 			fields: fields{
 				db: &cassandra.MockDB{
+					QueryFunc: observedKeyspace(map[string]string{"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "1"}),
 					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
-						return errBoom
+						expectedQuery := "ALTER KEYSPACE \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 2}"
+						if query != expectedQuery {
+
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
 					},
 				},
 			},
 			args: args{
 				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
 					Spec: v1alpha1.KeyspaceSpec{
 						ForProvider: v1alpha1.KeyspaceParameters{
 							ReplicationClass:  pointerToString("SimpleStrategy"),
@@ -384,63 +517,16 @@ func TestUpdate(t *testing.T) {
 			},
 			want: want{
 				u:   managed.ExternalUpdate{},
-				err: errors.New(errUpdateKeyspace + ": " + errBoom.Error()),
-			},
-		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := external{db: tc.fields.db}
-			got, err := e.Update(tc.args.ctx, tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
-			}
-			if diff := cmp.Diff(tc.want.u, got); diff != "" {
-				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s\n", tc.reason, diff)
-			}
-		})
-	}
-}
-
-func TestCreate(t *testing.T) {
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		db cassandra.DB
-	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
-	}
-
-	type want struct {
-		c   managed.ExternalCreation
-		err error
-	}
-
-	cases := map[string]struct {
-		reason string
-		fields fields
-		args   args
-		want   want
-	}{
-		"ErrNotKeyspace": {
-			reason: "Should return an error if the managed resource is not a *Keyspace",
-			args: args{
-				mg: nil,
-			},
-			want: want{
-				err: errors.New(errNotKeyspace),
+				err: nil,
 			},
 		},
-		"CreateKeyspaceSuccess": {
-			reason: "Should successfully create the keyspace if the create query succeeds",
+		"UpdateDurableWritesOnly": {
+			reason: "Should only alter durable_writes if the replication is up to date",
 			fields: fields{
 				db: &cassandra.MockDB{
+					QueryFunc: observedKeyspace(map[string]string{"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "2"}),
 					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
-						expectedQuery := "CREATE KEYSPACE IF NOT EXISTS \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 2} AND durable_writes = true"
+						expectedQuery := "ALTER KEYSPACE \"example_keyspace\" WITH durable_writes = false"
 						if query != expectedQuery {
 							return errors.New("unexpected query: " + query)
 						}
@@ -459,22 +545,22 @@ func TestCreate(t *testing.T) {
 						ForProvider: v1alpha1.KeyspaceParameters{
 							ReplicationClass:  pointerToString("SimpleStrategy"),
 							ReplicationFactor: pointerToInt(2),
-							DurableWrites:     pointerToBool(true),
+							DurableWrites:     pointerToBool(false),
 						},
 					},
 				},
 			},
 			want: want{
-				c:   managed.ExternalCreation{},
-				err: nil,
+				u: managed.ExternalUpdate{},
 			},
 		},
-		"CreateKeyspaceFailure": {
-			reason: "Should return an error if the create query fails",
+		"UpToDate": {
+			reason: "Should not alter the keyspace if none of its settings drifted",
 			fields: fields{
 				db: &cassandra.MockDB{
+					QueryFunc: observedKeyspace(map[string]string{"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "2"}),
 					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
-						return errBoom
+						return errors.New("unexpected query: " + query)
 					},
 				},
 			},
@@ -495,8 +581,33 @@ func TestCreate(t *testing.T) {
 				},
 			},
 			want: want{
-				c:   managed.ExternalCreation{},
-				err: errors.New(errCreateKeyspace + ": " + errBoom.Error()),
+				u: managed.ExternalUpdate{},
+			},
+		},
+		"UpdateKeyspaceFailure": {
+			reason: "Should return an error if the update query fails",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: observedKeyspace(map[string]string{"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "1"}),
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(2),
+							DurableWrites:     pointerToBool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				u:   managed.ExternalUpdate{},
+				err: errors.Wrap(errBoom, errUpdateKeyspace),
 			},
 		},
 	}
@@ -504,22 +615,24 @@ func TestCreate(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			e := external{db: tc.fields.db}
-			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.c, got); diff != "" {
-				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.u, got); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestDelete(t *testing.T) {
+func TestCreate(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	type fields struct {
-		db cassandra.DB
+		db          cassandra.DB
+		conflicts   apisv1alpha1.ConflictPolicy
+		identifiers apisv1alpha1.IdentifierCase
 	}
 
 	type args struct {
@@ -528,6 +641,7 @@ func TestDelete(t *testing.T) {
 	}
 
 	type want struct {
+		c   managed.ExternalCreation
 		err error
 	}
 
@@ -546,18 +660,19 @@ func TestDelete(t *testing.T) {
 				err: errors.New(errNotKeyspace),
 			},
 		},
-		"DeleteKeyspaceSuccess": {
-			reason: "Should successfully delete the keyspace if the delete query succeeds",
+		"CreateStrict": {
+			reason: "Should create the keyspace without IF NOT EXISTS when the conflict policy fails on conflicts",
 			fields: fields{
 				db: &cassandra.MockDB{
 					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
-						expectedQuery := "DROP KEYSPACE IF EXISTS \"example_keyspace\""
+						expectedQuery := "CREATE KEYSPACE \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1} AND durable_writes = true"
 						if query != expectedQuery {
 							return errors.New("unexpected query: " + query)
 						}
 						return nil
 					},
 				},
+				conflicts: apisv1alpha1.ConflictPolicyFail,
 			},
 			args: args{
 				mg: &v1alpha1.Keyspace{
@@ -566,12 +681,521 @@ func TestDelete(t *testing.T) {
 							"crossplane.io/external-name": "example_keyspace",
 						},
 					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(1),
+							DurableWrites:     pointerToBool(true),
+						},
+					},
+				},
+			},
+		},
+		"CreateLowercase": {
+			reason: "Should create the keyspace with a lowercased name when identifiers are lowercased",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						expectedQuery := "CREATE KEYSPACE IF NOT EXISTS \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1} AND durable_writes = true"
+						if query != expectedQuery {
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
+					},
+				},
+				identifiers: apisv1alpha1.IdentifierCaseLower,
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "Example_Keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(1),
+							DurableWrites:     pointerToBool(true),
+						},
+					},
+				},
+			},
+		},
+		"CreateNetworkTopologyKeyspace": {
+			reason: "Should create the keyspace with a replication factor per datacenter",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						expectedQuery := "CREATE KEYSPACE IF NOT EXISTS \"example_keyspace\" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': 3, 'dc2': 2} AND durable_writes = true"
+						if query != expectedQuery {
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass: pointerToString("NetworkTopologyStrategy"),
+							Datacenters:      map[string]int{"dc2": 2, "dc1": 3},
+							DurableWrites:    pointerToBool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"CreateWithInitProvider": {
+			reason: "Should create the keyspace with the replication of initProvider when forProvider does not set it",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						expectedQuery := "CREATE KEYSPACE IF NOT EXISTS \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 3} AND durable_writes = false"
+						if query != expectedQuery {
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						InitProvider: v1alpha1.KeyspaceInitParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(3),
+							DurableWrites:     pointerToBool(false),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"CreateKeyspaceSuccess": {
+			reason: "Should successfully create the keyspace if the create query succeeds",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						expectedQuery := "CREATE KEYSPACE IF NOT EXISTS \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 2} AND durable_writes = true"
+						if query != expectedQuery {
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(2),
+							DurableWrites:     pointerToBool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				c:   managed.ExternalCreation{},
+				err: nil,
+			},
+		},
+		"CreateGraphKeyspace": {
+			reason: "Should set the graph engine when the cluster supports DSE Graph",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						return &cassandra.MockIterator{Rows: [][]interface{}{{"graph_engine"}}}, nil
+					},
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						expectedQuery := "CREATE KEYSPACE IF NOT EXISTS \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 2} AND durable_writes = true AND graph_engine = 'Core'"
+						if query != expectedQuery {
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(2),
+							DurableWrites:     pointerToBool(true),
+							GraphEngine:       pointerToString("Core"),
+						},
+					},
+				},
+			},
+			want: want{
+				c:   managed.ExternalCreation{},
+				err: nil,
+			},
+		},
+		"CreateGraphKeyspaceWithoutDSE": {
+			reason: "Should skip the graph engine when the cluster does not support DSE Graph",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						expectedQuery := "CREATE KEYSPACE IF NOT EXISTS \"example_keyspace\" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 2} AND durable_writes = true"
+						if query != expectedQuery {
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(2),
+							DurableWrites:     pointerToBool(true),
+							GraphEngine:       pointerToString("Core"),
+						},
+					},
+				},
+			},
+			want: want{
+				c:   managed.ExternalCreation{},
+				err: nil,
+			},
+		},
+		"CreateKeyspaceFailure": {
+			reason: "Should return an error if the create query fails",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ReplicationClass:  pointerToString("SimpleStrategy"),
+							ReplicationFactor: pointerToInt(2),
+							DurableWrites:     pointerToBool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				c:   managed.ExternalCreation{},
+				err: errors.Wrap(errBoom, errCreateKeyspace),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db, conflicts: tc.fields.conflicts, identifiers: tc.fields.identifiers}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db   cassandra.DB
+		kube client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotKeyspace": {
+			reason: "Should return an error if the managed resource is not a *Keyspace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotKeyspace),
+			},
+		},
+		"DeleteKeyspaceSuccess": {
+			reason: "Should successfully delete the keyspace if the delete query succeeds",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						expectedQuery := "DROP KEYSPACE IF EXISTS \"example_keyspace\""
+						if query != expectedQuery {
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"RefuseNonEmptyKeyspace": {
+			reason: "Should refuse to drop a keyspace that still contains tables when requireEmptyOnDelete is set",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						return &cassandra.MockIterator{Rows: [][]interface{}{{"users"}}}, nil
+					},
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						return errors.New("unexpected query: " + query)
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							RequireEmptyOnDelete: pointerToBool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errNotEmpty),
+			},
+		},
+		"DropEmptyKeyspace": {
+			reason: "Should drop an empty keyspace when requireEmptyOnDelete is set",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						if query != "DROP KEYSPACE IF EXISTS \"example_keyspace\"" {
+							return errors.New("unexpected query: " + query)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							RequireEmptyOnDelete: pointerToBool(true),
+						},
+					},
 				},
 			},
 			want: want{
 				err: nil,
 			},
 		},
+		"SkipDrop": {
+			reason: "Should not drop the keyspace when skipDrop is set",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						return errors.New("unexpected query: " + query)
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							SkipDrop: pointerToBool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"NeverDropSystemKeyspace": {
+			reason: "Should never drop a system keyspace",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						return errors.New("unexpected query: " + query)
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "system_auth",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ForProvider: v1alpha1.KeyspaceParameters{
+							ManageSystemKeyspace: pointerToBool(true),
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"RefuseWithDependents": {
+			reason: "Should refuse to drop a keyspace while managed resources of its ProviderConfig are for it",
+			fields: fields{
+				db: &cassandra.MockDB{
+					ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+						return errors.New("unexpected query: " + query)
+					},
+				},
+				kube: &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						switch l := obj.(type) {
+						case *v1alpha1.TableList:
+							l.Items = []v1alpha1.Table{
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "users"},
+									Spec: v1alpha1.TableSpec{
+										ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+										ForProvider:  v1alpha1.TableParameters{Keyspace: pointerToString("example_keyspace")},
+									},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "elsewhere"},
+									Spec: v1alpha1.TableSpec{
+										ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "other"}},
+										ForProvider:  v1alpha1.TableParameters{Keyspace: pointerToString("example_keyspace")},
+									},
+								},
+							}
+						case *v1alpha1.GrantList:
+							l.Items = []v1alpha1.Grant{
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "reader"},
+									Spec: v1alpha1.GrantSpec{
+										ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+										ForProvider:  v1alpha1.GrantParameters{Keyspace: pointerToString("example_keyspace")},
+									},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "all"},
+									Spec: v1alpha1.GrantSpec{
+										ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+									},
+								},
+							}
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+					Spec: v1alpha1.KeyspaceSpec{
+						ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errDependents, "Table/users, Grant/reader"),
+			},
+		},
+		"ListDependentsFailure": {
+			reason: "Should return an error if the managed resources that may depend on the keyspace cannot be listed",
+			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			},
+			args: args{
+				mg: &v1alpha1.Keyspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"crossplane.io/external-name": "example_keyspace",
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListDependents),
+			},
+		},
 		"DeleteKeyspaceFailure": {
 			reason: "Should return an error if the delete query fails",
 			fields: fields{
@@ -591,14 +1215,18 @@ func TestDelete(t *testing.T) {
 				},
 			},
 			want: want{
-				err: errors.New(errDropKeyspace + ": " + errBoom.Error()),
+				err: errors.Wrap(errBoom, errDropKeyspace),
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{db: tc.fields.db}
+			kube := tc.fields.kube
+			if kube == nil {
+				kube = &test.MockClient{MockList: test.NewMockListFn(nil)}
+			}
+			e := external{db: tc.fields.db, kube: kube}
 			err := e.Delete(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -606,3 +1234,428 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestOrphanFinalizer(t *testing.T) {
+	keyspace := func(policy xpv1.DeletionPolicy, skipDrop *bool) *v1alpha1.Keyspace {
+		cr := &v1alpha1.Keyspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"crossplane.io/external-name": "example_keyspace",
+				},
+				DeletionTimestamp: &now,
+			},
+			Spec: v1alpha1.KeyspaceSpec{
+				ForProvider: v1alpha1.KeyspaceParameters{
+					SkipDrop: skipDrop,
+				},
+			},
+		}
+		cr.SetDeletionPolicy(policy)
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1alpha1.Keyspace
+		want   []event.Reason
+	}{
+		"Dropped": {
+			reason: "Should not emit an event when the keyspace was dropped",
+			mg:     keyspace(xpv1.DeletionDelete, nil),
+		},
+		"DeletionPolicyOrphan": {
+			reason: "Should emit an event when the keyspace is orphaned by its deletion policy",
+			mg:     keyspace(xpv1.DeletionOrphan, nil),
+			want:   []event.Reason{reasonOrphaned},
+		},
+		"ObserveOnly": {
+			reason: "Should emit an event when an observed keyspace is released",
+			mg: func() *v1alpha1.Keyspace {
+				cr := keyspace(xpv1.DeletionDelete, nil)
+				cr.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+				return cr
+			}(),
+			want: []event.Reason{reasonOrphaned},
+		},
+		"SkipDrop": {
+			reason: "Should emit an event when the keyspace is kept because of skipDrop",
+			mg:     keyspace(xpv1.DeletionDelete, pointerToBool(true)),
+			want:   []event.Reason{reasonOrphaned},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{}
+			f := &orphanFinalizer{
+				Finalizer: resource.FinalizerFns{
+					RemoveFinalizerFn: func(ctx context.Context, obj resource.Object) error { return nil },
+				},
+				recorder: r,
+			}
+			if err := f.RemoveFinalizer(context.Background(), tc.mg); err != nil {
+				t.Fatalf("\n%s\nRemoveFinalizer(...): unexpected error: %v", tc.reason, err)
+			}
+			var got []event.Reason
+			for _, e := range r.events {
+				got = append(got, e.Reason)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nRemoveFinalizer(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		replication map[string]string
+		durable     bool
+		want        v1alpha1.KeyspaceObservation
+	}{
+		"SimpleStrategy": {
+			reason:      "Should observe the replication factor of a SimpleStrategy keyspace",
+			replication: map[string]string{"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "3"},
+			durable:     true,
+			want: v1alpha1.KeyspaceObservation{
+				ReplicationClass:  "SimpleStrategy",
+				ReplicationFactor: 3,
+				DurableWrites:     true,
+			},
+		},
+		"NetworkTopologyStrategy": {
+			reason:      "Should observe the replication factor of every datacenter",
+			replication: map[string]string{"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "3", "dc2": "2"},
+			want: v1alpha1.KeyspaceObservation{
+				ReplicationClass: "NetworkTopologyStrategy",
+				Datacenters:      map[string]int{"dc1": 3, "dc2": 2},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := observation(tc.replication, tc.durable)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nobservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDriftPartialReplication(t *testing.T) {
+	observed := &v1alpha1.KeyspaceParameters{
+		ReplicationClass: pointerToString("NetworkTopologyStrategy"),
+		Datacenters:      map[string]int{"dc1": 3, "dc2": 2, "dc3": 1},
+		DurableWrites:    pointerToBool(true),
+	}
+
+	cases := map[string]struct {
+		reason      string
+		datacenters map[string]int
+		want        cassandra.Drift
+	}{
+		"ListedUpToDate": {
+			reason:      "Should ignore datacenters that are not listed",
+			datacenters: map[string]int{"dc1": 3, "dc2": 2},
+			want:        cassandra.Drift{},
+		},
+		"ListedDrifted": {
+			reason:      "Should detect drift of a listed datacenter",
+			datacenters: map[string]int{"dc1": 3, "dc2": 3},
+			want:        cassandra.Drift{"dc2: want 3, got 2"},
+		},
+		"ListedMissing": {
+			reason:      "Should detect a listed datacenter that is not replicated",
+			datacenters: map[string]int{"dc4": 3},
+			want:        cassandra.Drift{"dc4: want 3, got 0"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			desired := &v1alpha1.KeyspaceParameters{
+				ReplicationClass:   pointerToString("NetworkTopologyStrategy"),
+				Datacenters:        tc.datacenters,
+				PartialReplication: pointerToBool(true),
+				DurableWrites:      pointerToBool(true),
+			}
+			got := drift(observed, desired)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ndrift(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOverReplicated(t *testing.T) {
+	nodes := map[string]int{"dc1": 3, "dc2": 1}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha1.KeyspaceParameters
+		want   []string
+	}{
+		"SimpleWithinCapacity": {
+			reason: "Should not report a replication factor the cluster has enough nodes for",
+			params: v1alpha1.KeyspaceParameters{ReplicationFactor: pointerToInt(4)},
+		},
+		"SimpleOverCapacity": {
+			reason: "Should report a replication factor that exceeds the nodes of the cluster",
+			params: v1alpha1.KeyspaceParameters{ReplicationFactor: pointerToInt(5)},
+			want:   []string{"cluster (replication factor 5, 4 nodes)"},
+		},
+		"DatacentersOverCapacity": {
+			reason: "Should report every datacenter whose replication factor exceeds its nodes",
+			params: v1alpha1.KeyspaceParameters{
+				ReplicationClass: pointerToString("NetworkTopologyStrategy"),
+				Datacenters:      map[string]int{"dc1": 3, "dc2": 3, "dc3": 1},
+			},
+			want: []string{"dc2 (replication factor 3, 1 nodes)", "dc3 (replication factor 1, 0 nodes)"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := overReplicated(tc.params, nodes)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\noverReplicated(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := &xpv1.Reference{Name: "default"}
+
+	type want struct {
+		init v1alpha1.KeyspaceInitParameters
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		pc     *apisv1alpha1.ProviderConfig
+		getErr error
+		spec   v1alpha1.KeyspaceSpec
+		want   want
+	}{
+		"KnownDatacenters": {
+			reason: "A keyspace of a cluster whose datacenters are known should be replicated to each of them.",
+			pc: &apisv1alpha1.ProviderConfig{
+				Spec:   apisv1alpha1.ProviderConfigSpec{LocalDatacenter: pointerToString("dc1")},
+				Status: apisv1alpha1.ProviderConfigStatus{Cluster: &apisv1alpha1.ClusterObservation{Datacenters: []string{"dc1", "dc2"}}},
+			},
+			spec: v1alpha1.KeyspaceSpec{ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: ref}},
+			want: want{
+				init: v1alpha1.KeyspaceInitParameters{
+					ReplicationClass: pointerToString(ntsStrategy),
+					Datacenters:      map[string]int{"dc1": 1, "dc2": 1},
+					DurableWrites:    pointerToBool(true),
+				},
+			},
+		},
+		"LocalDatacenter": {
+			reason: "A keyspace of a cluster whose datacenters are not known yet should be replicated to the local datacenter.",
+			pc: &apisv1alpha1.ProviderConfig{
+				Spec: apisv1alpha1.ProviderConfigSpec{LocalDatacenter: pointerToString("dc1")},
+			},
+			spec: v1alpha1.KeyspaceSpec{ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: ref}},
+			want: want{
+				init: v1alpha1.KeyspaceInitParameters{
+					ReplicationClass: pointerToString(ntsStrategy),
+					Datacenters:      map[string]int{"dc1": 1},
+					DurableWrites:    pointerToBool(true),
+				},
+			},
+		},
+		"NoHints": {
+			reason: "A keyspace of a cluster without datacenter hints should use SimpleStrategy.",
+			pc:     &apisv1alpha1.ProviderConfig{},
+			spec:   v1alpha1.KeyspaceSpec{ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: ref}},
+			want: want{
+				init: v1alpha1.KeyspaceInitParameters{
+					ReplicationClass:  pointerToString(defaultStrategy),
+					ReplicationFactor: pointerToInt(1),
+					DurableWrites:     pointerToBool(true),
+				},
+			},
+		},
+		"ExplicitReplication": {
+			reason: "The replication and durable writes of a keyspace that sets them should not be defaulted.",
+			spec: v1alpha1.KeyspaceSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: ref},
+				ForProvider: v1alpha1.KeyspaceParameters{
+					ReplicationClass:  pointerToString(defaultStrategy),
+					ReplicationFactor: pointerToInt(3),
+					DurableWrites:     pointerToBool(false),
+				},
+			},
+		},
+		"ErrGetPC": {
+			reason: "Should return an error when the ProviderConfig cannot be read.",
+			getErr: errBoom,
+			spec:   v1alpha1.KeyspaceSpec{ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: ref}},
+			want: want{
+				err: errors.Wrap(errBoom, errGetPC),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := &defaulter{kube: &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					if tc.pc != nil {
+						tc.pc.DeepCopyInto(obj.(*apisv1alpha1.ProviderConfig))
+					}
+					return tc.getErr
+				},
+			}}
+			cr := &v1alpha1.Keyspace{Spec: tc.spec}
+			err := d.Default(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDefault(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.init, cr.Spec.InitProvider); diff != "" {
+				t.Errorf("\n%s\nDefault(...): -want initProvider, +got initProvider:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateRejected(t *testing.T) {
+	e := cassandra.ReportErrors(&external{db: &cassandra.MockDB{
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+			return &gocql.RequestErrAlreadyExists{Keyspace: "example_keyspace"}
+		},
+	}, conflicts: apisv1alpha1.ConflictPolicyFail})
+	cr := &v1alpha1.Keyspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"crossplane.io/external-name": "example_keyspace"},
+		},
+	}
+
+	if _, err := e.Create(context.Background(), cr); err == nil {
+		t.Fatal("Create(...): want error, got nil")
+	}
+
+	// The error of the cluster is wrapped rather than flattened, so that it
+	// is classified and reported in the Accepted condition.
+	got := cr.GetCondition(apisv1alpha1.TypeAccepted)
+	if got.Status != corev1.ConditionFalse || got.Reason != apisv1alpha1.ReasonAlreadyExists {
+		t.Errorf("Create(...): want Accepted condition False with reason %s, got %s with reason %s", apisv1alpha1.ReasonAlreadyExists, got.Status, got.Reason)
+	}
+}
+
+func TestObserveAdopted(t *testing.T) {
+	cr := &v1alpha1.Keyspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"crossplane.io/external-name": "example_keyspace",
+			},
+		},
+	}
+	e := external{db: &cassandra.MockDB{
+		QueryFunc: observedKeyspace(map[string]string{"class": "SimpleStrategy", "replication_factor": "3"}),
+		ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+			return errors.New("unexpected query: " + query)
+		},
+	}}
+
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	want := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s\n", diff)
+	}
+	wantSpec := v1alpha1.KeyspaceParameters{
+		ReplicationClass:  pointerToString("SimpleStrategy"),
+		ReplicationFactor: pointerToInt(3),
+		DurableWrites:     pointerToBool(true),
+	}
+	if diff := cmp.Diff(wantSpec, cr.Spec.ForProvider); diff != "" {
+		t.Errorf("Observe(...): -want spec, +got spec:\n%s\n", diff)
+	}
+	if !cassandra.Adopted(cr) {
+		t.Errorf("Observe(...): want the keyspace to be marked as adopted")
+	}
+
+	// The keyspace is only adopted once.
+	got, err = e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if got.ResourceLateInitialized {
+		t.Errorf("Observe(...): want an adopted keyspace not to be late initialized again")
+	}
+}
+
+func TestConnectionDetails(t *testing.T) {
+	db := &cassandra.MockDB{
+		GetConnectionDetailsFunc: func(username, password string) managed.ConnectionDetails {
+			return managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretUserKey:      []byte(username),
+				xpv1.ResourceCredentialsSecretPasswordKey:  []byte(password),
+				xpv1.ResourceCredentialsSecretEndpointKey:  []byte("cassandra.example.org"),
+				xpv1.ResourceCredentialsSecretPortKey:      []byte("9042"),
+				cassandra.ConnectionSecretContactPointsKey: []byte("10.0.0.1,10.0.0.2"),
+			}
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Keyspace
+		want   managed.ConnectionDetails
+	}{
+		"NotPublished": {
+			reason: "Should return no connection details if the keyspace does not publish them",
+			cr:     &v1alpha1.Keyspace{},
+		},
+		"Published": {
+			reason: "Should return the keyspace name and how to reach its cluster, but no credentials",
+			cr: &v1alpha1.Keyspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "example_keyspace"},
+				},
+				Spec: v1alpha1.KeyspaceSpec{
+					ResourceSpec: xpv1.ResourceSpec{
+						WriteConnectionSecretToReference: &xpv1.SecretReference{Name: "example", Namespace: "default"},
+					},
+				},
+			},
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey:  []byte("cassandra.example.org"),
+				xpv1.ResourceCredentialsSecretPortKey:      []byte("9042"),
+				cassandra.ConnectionSecretContactPointsKey: []byte("10.0.0.1,10.0.0.2"),
+				cassandra.ConnectionSecretKeyspaceKey:      []byte("example_keyspace"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := connectionDetails(db, tc.cr, meta.GetExternalName(tc.cr))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nconnectionDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
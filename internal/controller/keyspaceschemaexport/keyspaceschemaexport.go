@@ -0,0 +1,434 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyspaceschemaexport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+	"github.com/crossplane/provider-cassandra/internal/features"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+const (
+	errNotSchemaExport = "managed resource is not a KeyspaceSchemaExport custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errNewClient       = "cannot create new Service"
+
+	errNoKeyspace         = "keyspace is not set"
+	errKeyspaceNotFound   = "keyspace does not exist"
+	errDescribeKeyspace   = "cannot describe keyspace"
+	errGetTarget          = "cannot get export target"
+	errWriteTarget        = "cannot write export target"
+	errDeleteTarget       = "cannot delete export target"
+	errNotControlled      = "refusing to overwrite export target that was not created by this KeyspaceSchemaExport"
+	defaultKey            = "schema.cql"
+	defaultExportInterval = 24 * time.Hour
+)
+
+// Setup adds a controller that reconciles KeyspaceSchemaExport managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.KeyspaceSchemaExportGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newClient: cassandra.Sessions.New}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(cassandra.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+	}
+
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.KeyspaceSchemaExportGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.KeyspaceSchemaExport{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.KeyspaceSchemaExport)
+	if !ok {
+		return nil, errors.New(errNotSchemaExport)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	creds, err := cassandra.ProviderCredentials(ctx, c.kube, pc.Spec.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	opts, err := cassandra.ProviderConfigOptions(ctx, c.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	ropts, err := cassandra.ResourceOptions(cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	opts = append(opts, ropts...)
+	opts = append(opts, cassandra.WithSharedSession(ctx, cassandra.SessionKey(pc, creds, cr)))
+	opts = append(opts, cassandra.RateLimits.For(pc))
+	db := c.newClient(creds, "", opts...)
+
+	return cassandra.ReportErrors(&external{db: db, kube: c.kube, identifiers: pc.Spec.IdentifierCase}), nil
+}
+
+type external struct {
+	db          cassandra.DB
+	kube        client.Client
+	identifiers apisv1alpha1.IdentifierCase
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.KeyspaceSchemaExport)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSchemaExport)
+	}
+
+	last := cr.Status.AtProvider.LastExportTime
+	if last == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	exists, err := c.targetExists(ctx, cr.Spec.ForProvider.Target)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !exists {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: time.Since(last.Time) < interval(cr.Spec.ForProvider),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.KeyspaceSchemaExport)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSchemaExport)
+	}
+
+	return managed.ExternalCreation{}, c.export(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.KeyspaceSchemaExport)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSchemaExport)
+	}
+
+	return managed.ExternalUpdate{}, c.export(ctx, cr)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.KeyspaceSchemaExport)
+	if !ok {
+		return errors.New(errNotSchemaExport)
+	}
+
+	t := cr.Spec.ForProvider.Target
+	obj := targetObject(t)
+	err := c.kube.Get(ctx, types.NamespacedName{Namespace: t.Namespace, Name: t.Name}, obj)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetTarget)
+	}
+
+	// Targets that were not created by the export are left in place.
+	if !metav1.IsControlledBy(obj, cr) {
+		return nil
+	}
+	if err := c.kube.Delete(ctx, obj); resource.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, errDeleteTarget)
+	}
+
+	return nil
+}
+
+// export reads the current schema of the keyspace and writes it to the
+// configured target, recording when and what was written.
+func (c *external) export(ctx context.Context, cr *v1alpha1.KeyspaceSchemaExport) error {
+	if cr.Spec.ForProvider.Keyspace == nil {
+		return errors.New(errNoKeyspace)
+	}
+
+	schema, err := c.describeKeyspace(ctx, cassandra.NormalizeIdentifier(c.identifiers, *cr.Spec.ForProvider.Keyspace))
+	if err != nil {
+		return errors.Wrap(err, errDescribeKeyspace)
+	}
+
+	t := cr.Spec.ForProvider.Target
+	obj := targetObject(t)
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		o.Data = map[string]string{targetKey(t): schema}
+	case *corev1.Secret:
+		o.Data = map[string][]byte{targetKey(t): []byte(schema)}
+	}
+	// The target is controlled by the export, so that targets that existed
+	// before it are never overwritten, nor deleted with it.
+	if err := meta.AddControllerReference(obj, meta.AsController(meta.TypedReferenceTo(cr, v1alpha1.KeyspaceSchemaExportGroupVersionKind))); err != nil {
+		return errors.Wrap(err, errWriteTarget)
+	}
+	if err := resource.NewAPIPatchingApplicator(c.kube).Apply(ctx, obj, controlledBy(cr)); err != nil {
+		return errors.Wrap(err, errWriteTarget)
+	}
+
+	sum := sha256.Sum256([]byte(schema))
+	now := metav1.Now()
+	cr.Status.AtProvider.LastExportTime = &now
+	cr.Status.AtProvider.SchemaHash = hex.EncodeToString(sum[:])
+
+	return nil
+}
+
+// controlledBy refuses to apply a target that exists but is not controlled
+// by the export.
+func controlledBy(cr *v1alpha1.KeyspaceSchemaExport) resource.ApplyOption {
+	return func(_ context.Context, current, _ runtime.Object) error {
+		o, ok := current.(metav1.Object)
+		if !ok || !metav1.IsControlledBy(o, cr) {
+			return errors.New(errNotControlled)
+		}
+		return nil
+	}
+}
+
+func (c *external) targetExists(ctx context.Context, t v1alpha1.SchemaExportTarget) (bool, error) {
+	obj := targetObject(t)
+	err := c.kube.Get(ctx, types.NamespacedName{Namespace: t.Namespace, Name: t.Name}, obj)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errGetTarget)
+	}
+	return true, nil
+}
+
+// describeKeyspace returns the DDL of a keyspace. Server side DESCRIBE is
+// used when the cluster supports it (Cassandra 4.0+), otherwise the keyspace
+// and table definitions are reconstructed from system_schema.
+func (c *external) describeKeyspace(ctx context.Context, keyspace string) (string, error) {
+	iter, err := c.db.Query(ctx, "DESCRIBE KEYSPACE "+cassandra.QuoteIdentifier(keyspace))
+	if err != nil {
+		return "", err
+	}
+
+	var statements []string
+	var ks, typ, name, stmt string
+	for iter.Scan(&ks, &typ, &name, &stmt) {
+		statements = append(statements, stmt)
+	}
+	if err := iter.Close(); err == nil && len(statements) > 0 {
+		return strings.Join(statements, "\n\n") + "\n", nil
+	}
+
+	return c.reconstructKeyspace(ctx, keyspace)
+}
+
+type column struct {
+	name     string
+	typ      string
+	kind     string
+	position int
+	order    string
+}
+
+func (c *external) reconstructKeyspace(ctx context.Context, keyspace string) (string, error) {
+	iter, err := c.db.Query(ctx, "SELECT replication, durable_writes FROM system_schema.keyspaces WHERE keyspace_name = ?", keyspace)
+	if err != nil {
+		return "", err
+	}
+	replication := map[string]string{}
+	var durableWrites bool
+	found := iter.Scan(&replication, &durableWrites)
+	if err := iter.Close(); err != nil {
+		return "", err
+	}
+	if !found {
+		return "", errors.New(errKeyspaceNotFound)
+	}
+
+	iter, err = c.db.Query(ctx, "SELECT table_name, column_name, type, kind, position, clustering_order FROM system_schema.columns WHERE keyspace_name = ?", keyspace)
+	if err != nil {
+		return "", err
+	}
+	tables := map[string][]column{}
+	var table string
+	var col column
+	for iter.Scan(&table, &col.name, &col.typ, &col.kind, &col.position, &col.order) {
+		tables[table] = append(tables[table], col)
+		col = column{}
+	}
+	if err := iter.Close(); err != nil {
+		return "", err
+	}
+
+	statements := []string{createKeyspaceStatement(keyspace, replication, durableWrites)}
+	names := make([]string, 0, len(tables))
+	for t := range tables {
+		names = append(names, t)
+	}
+	sort.Strings(names)
+	for _, t := range names {
+		statements = append(statements, createTableStatement(keyspace, t, tables[t]))
+	}
+
+	return strings.Join(statements, "\n\n") + "\n", nil
+}
+
+func createKeyspaceStatement(keyspace string, replication map[string]string, durableWrites bool) string {
+	return fmt.Sprintf("CREATE KEYSPACE %s WITH replication = %s AND durable_writes = %t;",
+		cassandra.QuoteIdentifier(keyspace), cassandra.QuoteMap(replication), durableWrites)
+}
+
+func createTableStatement(keyspace, table string, columns []column) string {
+	sort.SliceStable(columns, func(i, j int) bool {
+		ri, rj := kindRank(columns[i].kind), kindRank(columns[j].kind)
+		if ri != rj {
+			return ri < rj
+		}
+		if columns[i].position != columns[j].position {
+			return columns[i].position < columns[j].position
+		}
+		return columns[i].name < columns[j].name
+	})
+
+	var defs, partition, clustering, order []string
+	for _, col := range columns {
+		def := "    " + cassandra.QuoteIdentifier(col.name) + " " + col.typ
+		if col.kind == "static" {
+			def += " static"
+		}
+		defs = append(defs, def)
+		switch col.kind {
+		case "partition_key":
+			partition = append(partition, cassandra.QuoteIdentifier(col.name))
+		case "clustering":
+			clustering = append(clustering, cassandra.QuoteIdentifier(col.name))
+			order = append(order, cassandra.QuoteIdentifier(col.name)+" "+strings.ToUpper(col.order))
+		}
+	}
+
+	key := strings.Join(partition, ", ")
+	if len(partition) > 1 {
+		key = "(" + key + ")"
+	}
+	if len(clustering) > 0 {
+		key += ", " + strings.Join(clustering, ", ")
+	}
+	defs = append(defs, "    PRIMARY KEY ("+key+")")
+
+	stmt := fmt.Sprintf("CREATE TABLE %s.%s (\n%s\n)", cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(table), strings.Join(defs, ",\n"))
+	if len(order) > 0 {
+		stmt += " WITH CLUSTERING ORDER BY (" + strings.Join(order, ", ") + ")"
+	}
+	return stmt + ";"
+}
+
+func kindRank(kind string) int {
+	switch kind {
+	case "partition_key":
+		return 0
+	case "clustering":
+		return 1
+	default:
+		return 2
+	}
+}
+
+func targetObject(t v1alpha1.SchemaExportTarget) client.Object {
+	om := metav1.ObjectMeta{Namespace: t.Namespace, Name: t.Name}
+	if t.Kind == v1alpha1.SchemaExportTargetSecret {
+		return &corev1.Secret{ObjectMeta: om}
+	}
+	return &corev1.ConfigMap{ObjectMeta: om}
+}
+
+func targetKey(t v1alpha1.SchemaExportTarget) string {
+	if t.Key == "" {
+		return defaultKey
+	}
+	return t.Key
+}
+
+func interval(p v1alpha1.KeyspaceSchemaExportParameters) time.Duration {
+	if p.Interval == nil {
+		return defaultExportInterval
+	}
+	return p.Interval.Duration
+}
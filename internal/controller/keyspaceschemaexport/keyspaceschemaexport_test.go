@@ -0,0 +1,406 @@
+package keyspaceschemaexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+)
+
+func pointerToString(s string) *string {
+	return &s
+}
+
+func schemaExport(lastExport *metav1.Time) *v1alpha1.KeyspaceSchemaExport {
+	return &v1alpha1.KeyspaceSchemaExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", UID: "example-uid"},
+		Spec: v1alpha1.KeyspaceSchemaExportSpec{
+			ForProvider: v1alpha1.KeyspaceSchemaExportParameters{
+				Keyspace: pointerToString("example_keyspace"),
+				Target: v1alpha1.SchemaExportTarget{
+					Kind:      v1alpha1.SchemaExportTargetConfigMap,
+					Name:      "example-schema",
+					Namespace: "default",
+				},
+				Interval: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+		Status: v1alpha1.KeyspaceSchemaExportStatus{
+			AtProvider: v1alpha1.KeyspaceSchemaExportObservation{
+				LastExportTime: lastExport,
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "example-schema")
+	recent := metav1.NewTime(time.Now().Add(-time.Minute))
+	stale := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+	type fields struct {
+		kube client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotSchemaExport": {
+			reason: "Should return an error if the managed resource is not a *KeyspaceSchemaExport",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotSchemaExport),
+			},
+		},
+		"NeverExported": {
+			reason: "Should return ResourceExists: false when the schema was never exported",
+			args: args{
+				mg: schemaExport(nil),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"TargetMissing": {
+			reason: "Should return ResourceExists: false when the target was deleted",
+			fields: fields{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errNotFound)},
+			},
+			args: args{
+				mg: schemaExport(&recent),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "Should return ResourceUpToDate: true within the export interval",
+			fields: fields{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			},
+			args: args{
+				mg: schemaExport(&recent),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Stale": {
+			reason: "Should return ResourceUpToDate: false once the export interval elapsed",
+			fields: fields{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			},
+			args: args{
+				mg: schemaExport(&stale),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{}, kube: tc.fields.kube}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// target returns a MockGetFn that finds the export target, controlled by the
+// supplied owner unless it is nil.
+func target(owner *v1alpha1.KeyspaceSchemaExport) test.MockGetFn {
+	return test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.SetOwnerReferences(nil)
+		if owner == nil {
+			return nil
+		}
+		return meta.AddControllerReference(obj, meta.AsController(meta.TypedReferenceTo(owner, v1alpha1.KeyspaceSchemaExportGroupVersionKind)))
+	})
+}
+
+func TestCreate(t *testing.T) {
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "example-schema")
+	describeUnsupported := errors.New("line 1:0 no viable alternative at input 'DESCRIBE'")
+
+	type fields struct {
+		db          cassandra.DB
+		get         test.MockGetFn
+		identifiers apisv1alpha1.IdentifierCase
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		schema string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotSchemaExport": {
+			reason: "Should return an error if the managed resource is not a *KeyspaceSchemaExport",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotSchemaExport),
+			},
+		},
+		"Describe": {
+			reason: "Should write the output of DESCRIBE KEYSPACE to the target",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						if query != "DESCRIBE KEYSPACE \"example_keyspace\"" {
+							return nil, errors.New("unexpected query: " + query)
+						}
+						return &cassandra.MockIterator{Rows: [][]interface{}{
+							{"example_keyspace", "keyspace", "example_keyspace", "CREATE KEYSPACE example_keyspace WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '1'} AND durable_writes = true;"},
+							{"example_keyspace", "table", "users", "CREATE TABLE example_keyspace.users (id uuid PRIMARY KEY);"},
+						}}, nil
+					},
+				},
+			},
+			args: args{
+				mg: schemaExport(nil),
+			},
+			want: want{
+				schema: "CREATE KEYSPACE example_keyspace WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '1'} AND durable_writes = true;\n\n" +
+					"CREATE TABLE example_keyspace.users (id uuid PRIMARY KEY);\n",
+			},
+		},
+		"LowercaseKeyspace": {
+			reason: "Should describe the keyspace as Cassandra stores its name when identifiers are lowercased",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						if query != "DESCRIBE KEYSPACE \"example_keyspace\"" {
+							return nil, errors.New("unexpected query: " + query)
+						}
+						return &cassandra.MockIterator{Rows: [][]interface{}{
+							{"example_keyspace", "keyspace", "example_keyspace", "CREATE KEYSPACE example_keyspace WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '1'} AND durable_writes = true;"},
+						}}, nil
+					},
+				},
+				identifiers: apisv1alpha1.IdentifierCaseLower,
+			},
+			args: args{
+				mg: func() resource.Managed {
+					cr := schemaExport(nil)
+					cr.Spec.ForProvider.Keyspace = pointerToString("Example_Keyspace")
+					return cr
+				}(),
+			},
+			want: want{
+				schema: "CREATE KEYSPACE example_keyspace WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '1'} AND durable_writes = true;\n",
+			},
+		},
+		"ErrNotControlled": {
+			reason: "Should refuse to overwrite a target that was not created by the export",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						return &cassandra.MockIterator{Rows: [][]interface{}{
+							{"example_keyspace", "keyspace", "example_keyspace", "CREATE KEYSPACE example_keyspace;"},
+						}}, nil
+					},
+				},
+				get: target(nil),
+			},
+			args: args{
+				mg: schemaExport(nil),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNotControlled), errWriteTarget),
+			},
+		},
+		"ErrDescribe": {
+			reason: "Should return an error if the schema cannot be read",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						return nil, describeUnsupported
+					},
+				},
+			},
+			args: args{
+				mg: schemaExport(nil),
+			},
+			want: want{
+				err: errors.Wrap(describeUnsupported, errDescribeKeyspace),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var written *corev1.ConfigMap
+			get := tc.fields.get
+			if get == nil {
+				get = test.NewMockGetFn(errNotFound)
+			}
+			kube := &test.MockClient{
+				MockGet: get,
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					written = obj.(*corev1.ConfigMap)
+					return nil
+				},
+			}
+			e := external{db: tc.fields.db, kube: kube, identifiers: tc.fields.identifiers}
+			_, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.schema == "" {
+				return
+			}
+			if diff := cmp.Diff(tc.want.schema, written.Data[defaultKey]); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want schema, +got schema:\n%s\n", tc.reason, diff)
+			}
+			if !metav1.IsControlledBy(written, tc.args.mg.(*v1alpha1.KeyspaceSchemaExport)) {
+				t.Errorf("\n%s\nCreate(...): expected the target to be controlled by the export", tc.reason)
+			}
+			if tc.args.mg.(*v1alpha1.KeyspaceSchemaExport).Status.AtProvider.LastExportTime == nil {
+				t.Errorf("\n%s\nCreate(...): expected lastExportTime to be recorded", tc.reason)
+			}
+		})
+	}
+}
+
+func TestCreateTableStatement(t *testing.T) {
+	got := createTableStatement("ks", "events", []column{
+		{name: "payload", typ: "text", kind: "regular", position: -1},
+		{name: "ts", typ: "timestamp", kind: "clustering", position: 0, order: "desc"},
+		{name: "bucket", typ: "int", kind: "partition_key", position: 1},
+		{name: "id", typ: "uuid", kind: "partition_key", position: 0},
+	})
+	want := "CREATE TABLE \"ks\".\"events\" (\n" +
+		"    \"id\" uuid,\n" +
+		"    \"bucket\" int,\n" +
+		"    \"ts\" timestamp,\n" +
+		"    \"payload\" text,\n" +
+		"    PRIMARY KEY ((\"id\", \"bucket\"), \"ts\")\n" +
+		") WITH CLUSTERING ORDER BY (\"ts\" DESC);"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("createTableStatement(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestCreateKeyspaceStatement(t *testing.T) {
+	got := createKeyspaceStatement("ks", map[string]string{
+		"class": "org.apache.cassandra.locator.NetworkTopologyStrategy",
+		"dc'1":  "3",
+	}, true)
+	want := `CREATE KEYSPACE "ks" WITH replication = {'class': 'org.apache.cassandra.locator.NetworkTopologyStrategy', 'dc''1': '3'} AND durable_writes = true;`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("createKeyspaceStatement(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		kube   client.Client
+		want   error
+	}{
+		"ErrNotSchemaExport": {
+			reason: "Should return an error if the managed resource is not a *KeyspaceSchemaExport",
+			mg:     nil,
+			want:   errors.New(errNotSchemaExport),
+		},
+		"Deleted": {
+			reason: "Should delete the export target",
+			mg:     schemaExport(nil),
+			kube: &test.MockClient{
+				MockGet:    target(schemaExport(nil)),
+				MockDelete: test.NewMockDeleteFn(nil),
+			},
+		},
+		"NotFound": {
+			reason: "Should not return an error if the export target does not exist",
+			mg:     schemaExport(nil),
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "example-schema"))},
+		},
+		"NotControlled": {
+			reason: "Should leave a target that was not created by the export in place",
+			mg:     schemaExport(nil),
+			kube: &test.MockClient{
+				MockGet:    target(nil),
+				MockDelete: test.NewMockDeleteFn(errBoom),
+			},
+		},
+		"ErrGet": {
+			reason: "Should return an error if the export target cannot be read",
+			mg:     schemaExport(nil),
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want:   errors.Wrap(errBoom, errGetTarget),
+		},
+		"ErrDelete": {
+			reason: "Should return an error if the export target cannot be deleted",
+			mg:     schemaExport(nil),
+			kube: &test.MockClient{
+				MockGet:    target(schemaExport(nil)),
+				MockDelete: test.NewMockDeleteFn(errBoom),
+			},
+			want: errors.Wrap(errBoom, errDeleteTarget),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{}, kube: tc.kube}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
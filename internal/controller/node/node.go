@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+	"github.com/crossplane/provider-cassandra/internal/features"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+const (
+	errNotNode      = "managed resource is not a Node custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errGetCreds     = "cannot get credentials"
+	errNewClient    = "cannot create new Service"
+
+	errSelectLocal = "cannot select local node"
+	errSelectPeers = "cannot select peers"
+	errObserveOnly = "node does not exist in the cluster; Node resources are observe-only and cannot be created"
+)
+
+const (
+	selectLocal   = "SELECT host_id, rpc_address, data_center, rack FROM system.local"
+	selectPeersV2 = "SELECT host_id, native_address, data_center, rack FROM system.peers_v2"
+	selectPeers   = "SELECT host_id, rpc_address, data_center, rack FROM system.peers"
+)
+
+// Setup adds a controller that reconciles Node managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.NodeGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newClient: cassandra.Sessions.New}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(cassandra.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+	}
+
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.NodeGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Node{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Node)
+	if !ok {
+		return nil, errors.New(errNotNode)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	creds, err := cassandra.ProviderCredentials(ctx, c.kube, pc.Spec.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	opts, err := cassandra.ProviderConfigOptions(ctx, c.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	ropts, err := cassandra.ResourceOptions(cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	opts = append(opts, ropts...)
+	opts = append(opts, cassandra.WithSharedSession(ctx, cassandra.SessionKey(pc, creds, cr)))
+	opts = append(opts, cassandra.RateLimits.For(pc))
+	db := c.newClient(creds, "", opts...)
+
+	return cassandra.ReportErrors(&external{db: db}), nil
+}
+
+type external struct {
+	db cassandra.DB
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Node)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNode)
+	}
+
+	observed, err := c.findNode(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if observed == nil {
+		return managed.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: false,
+		}, nil
+	}
+
+	cr.Status.AtProvider = *observed
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+// findNode looks up the node identified by name, which may either be its host
+// ID or its native transport address. The coordinator is read from
+// system.local and every other node from system.peers_v2, falling back to
+// system.peers on clusters older than Cassandra 4.0.
+func (c *external) findNode(ctx context.Context, name string) (*v1alpha1.NodeObservation, error) {
+	local, err := c.scanNodes(ctx, selectLocal)
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectLocal)
+	}
+	for i := range local {
+		if matches(local[i], name) {
+			local[i].Up = true
+			return &local[i], nil
+		}
+	}
+
+	peers, err := c.scanNodes(ctx, selectPeersV2)
+	if err != nil {
+		peers, err = c.scanNodes(ctx, selectPeers)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectPeers)
+	}
+	for i := range peers {
+		if matches(peers[i], name) {
+			peers[i].Up = c.db.IsHostUp(peers[i].HostID)
+			return &peers[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *external) scanNodes(ctx context.Context, query string) ([]v1alpha1.NodeObservation, error) {
+	iter, err := c.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []v1alpha1.NodeObservation
+	var n v1alpha1.NodeObservation
+	for iter.Scan(&n.HostID, &n.Address, &n.Datacenter, &n.Rack) {
+		nodes = append(nodes, n)
+		n = v1alpha1.NodeObservation{}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+func matches(n v1alpha1.NodeObservation, name string) bool {
+	return strings.EqualFold(n.HostID, name) || n.Address == name
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	if _, ok := mg.(*v1alpha1.Node); !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNode)
+	}
+
+	return managed.ExternalCreation{}, errors.New(errObserveOnly)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.Node); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNode)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	if _, ok := mg.(*v1alpha1.Node); !ok {
+		return errors.New(errNotNode)
+	}
+
+	// Nodes are never removed by the provider; deleting the managed resource
+	// only stops observing it.
+	return nil
+}
@@ -0,0 +1,248 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+)
+
+// topology returns a MockDB serving one local node and one peer.
+func topology(peerUp bool) *cassandra.MockDB {
+	rows := map[string][][]interface{}{
+		selectLocal:   {{"11111111-1111-1111-1111-111111111111", "10.0.0.1", "dc1", "rack1"}},
+		selectPeersV2: {{"22222222-2222-2222-2222-222222222222", "10.0.0.2", "dc2", "rack2"}},
+	}
+	return &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			return &cassandra.MockIterator{Rows: rows[query]}, nil
+		},
+		IsHostUpFunc: func(hostID string) bool { return peerUp },
+	}
+}
+
+func node(name string) *v1alpha1.Node {
+	return &v1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"crossplane.io/external-name": name,
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type fields struct {
+		db cassandra.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o          managed.ExternalObservation
+		atProvider v1alpha1.NodeObservation
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotNode": {
+			reason: "Should return an error if the managed resource is not a *Node",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotNode),
+			},
+		},
+		"NodeNotFound": {
+			reason: "Should return ResourceExists: false when no node matches the external name",
+			fields: fields{
+				db: topology(true),
+			},
+			args: args{
+				mg: node("10.0.0.9"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists: false,
+				},
+			},
+		},
+		"LocalNodeByHostID": {
+			reason: "Should observe the coordinator from system.local by host ID",
+			fields: fields{
+				db: topology(false),
+			},
+			args: args{
+				mg: node("11111111-1111-1111-1111-111111111111"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				atProvider: v1alpha1.NodeObservation{
+					HostID:     "11111111-1111-1111-1111-111111111111",
+					Address:    "10.0.0.1",
+					Datacenter: "dc1",
+					Rack:       "rack1",
+					Up:         true,
+				},
+			},
+		},
+		"PeerByAddress": {
+			reason: "Should observe a peer by address and report its driver state",
+			fields: fields{
+				db: topology(false),
+			},
+			args: args{
+				mg: node("10.0.0.2"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				atProvider: v1alpha1.NodeObservation{
+					HostID:     "22222222-2222-2222-2222-222222222222",
+					Address:    "10.0.0.2",
+					Datacenter: "dc2",
+					Rack:       "rack2",
+					Up:         false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.args.mg.(*v1alpha1.Node); ok {
+				if diff := cmp.Diff(tc.want.atProvider, cr.Status.AtProvider); diff != "" {
+					t.Errorf("\n%s\nObserve(...): -want atProvider, +got atProvider:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrNotNode": {
+			reason: "Should return an error if the managed resource is not a *Node",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotNode),
+			},
+		},
+		"ErrObserveOnly": {
+			reason: "Should refuse to create a Node",
+			args: args{
+				mg: node("10.0.0.9"),
+			},
+			want: want{
+				err: errors.New(errObserveOnly),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{}}
+			_, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrNotNode": {
+			reason: "Should return an error if the managed resource is not a *Node",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotNode),
+			},
+		},
+		"NoOp": {
+			reason: "Should never execute a statement when a Node is deleted",
+			args: args{
+				mg: node("10.0.0.2"),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					return errors.New("unexpected query: " + query)
+				},
+			}}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
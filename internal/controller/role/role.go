@@ -18,11 +18,18 @@ package role
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,15 +46,17 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
 	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	"github.com/crossplane/provider-cassandra/apis/cql/v1beta1"
 	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
 	"github.com/crossplane/provider-cassandra/internal/features"
 )
 
 const (
-	errNotRole      = "managed resource is not a Role custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
+	errNotRole            = "managed resource is not a Role custom resource"
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+	errGetPC              = "cannot get ProviderConfig"
+	errGetCreds           = "cannot get credentials"
+	errUnsupportedDialect = "Amazon Keyspaces does not support roles; manage access with IAM instead"
 
 	errNewClient   = "cannot create new Service"
 	errSelectRole  = "cannot select role"
@@ -55,29 +64,127 @@ const (
 	errUpdateRole  = "cannot update role"
 	errDropRole    = "cannot drop role"
 	maxConcurrency = 5
+
+	errGetPasswordSecret   = "cannot get password secret"
+	errGetConnectionSecret = "cannot get connection secret"
+	errPasswordCharset     = "password generation settings leave no characters to choose from"
+	errGetHashedPassword   = "cannot get hashed password secret"
+	errListRoleOptions     = "cannot list role options"
+	errSelectMemberOf      = "cannot select role memberships"
+	errGrantRole           = "cannot grant role"
+	errRevokeRole          = "cannot revoke role"
+	errSelectPermissions   = "cannot select role permissions"
+	errProtectedRole       = "refusing to drop protected role; set skipDrop to release it"
+)
+
+const (
+	reasonOrphaned event.Reason = "OrphanedRole"
+)
+
+const (
+	lowercase = "abcdefghijklmnopqrstuvwxyz"
+	uppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits    = "0123456789"
+	symbols   = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
 )
 
 var generatePassword = password.Generate
 
+// newPassword generates a password with the supplied settings, or with the
+// default settings when none are supplied.
+func newPassword(g *v1alpha1.PasswordGeneration) (string, error) {
+	if g == nil {
+		return generatePassword()
+	}
+
+	enabled := func(b *bool, def bool) bool {
+		if b == nil {
+			return def
+		}
+		return *b
+	}
+
+	var set string
+	if enabled(g.Lowercase, true) {
+		set += lowercase
+	}
+	if enabled(g.Uppercase, true) {
+		set += uppercase
+	}
+	if enabled(g.Digits, true) {
+		set += digits
+	}
+	if enabled(g.Symbols, false) {
+		set += symbols
+	}
+	if g.ExcludeCharacters != nil {
+		set = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(*g.ExcludeCharacters, r) {
+				return -1
+			}
+			return r
+		}, set)
+	}
+	if set == "" {
+		return "", errors.New(errPasswordCharset)
+	}
+
+	length := password.Default.Length
+	if g.Length != nil {
+		length = *g.Length
+	}
+
+	return password.Settings{CharacterSet: set, Length: length}.Generate()
+}
+
 // Setup adds a controller that reconciles Role managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.RoleGroupKind)
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
-		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind, connection.WithTLSConfig(o.ESSOptions.TLSConfig)))
 	}
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.RoleGroupVersionKind),
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
 			kube:      mgr.GetClient(),
 			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newClient: cassandra.New}),
+			newClient: cassandra.Sessions.New,
+			recorder:  recorder}),
+		managed.WithFinalizer(&orphanFinalizer{
+			Finalizer: resource.NewAPIFinalizer(mgr.GetClient(), managed.FinalizerName),
+			recorder:  recorder}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithConnectionPublishers(cps...))
+		managed.WithPollIntervalHook(cassandra.PollInterval),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...),
+	}
+
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.RoleGroupVersionKind), opts...)
+
+	// Roles of other API versions are converted to and from v1alpha1.
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&v1beta1.Role{}).Complete(); err != nil {
+		return err
+	}
+
+	// Roles whose identifiers cannot be used in CQL are rejected on
+	// admission, and those that are created are given the defaults they do
+	// not set.
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Role{}).
+		WithValidator(cassandra.IdentifierValidator[*v1alpha1.Role](validate)).
+		WithDefaulter(cassandra.Defaulter[*v1alpha1.Role](defaults)).
+		Complete(); err != nil {
+		return err
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -87,12 +194,68 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
+// validate returns the errors of the name of a Role and of the roles it is
+// granted.
+func validate(cr *v1alpha1.Role) field.ErrorList {
+	errs := cassandra.ValidateExternalName(cr, cassandra.ValidateRoleName)
+	path := field.NewPath("spec", "forProvider", "memberOf")
+	for i, r := range cr.Spec.ForProvider.MemberOf {
+		errs = append(errs, cassandra.ValidateRoleName(path.Index(i), r)...)
+	}
+	return errs
+}
+
+// defaults fills the privileges a Role is created with when it does not set
+// them. Roles are allowed to log in unless they say otherwise. Roles that set
+// their external name may adopt an existing role, whose privileges are late
+// initialized instead.
+func defaults(_ context.Context, cr *v1alpha1.Role) error {
+	if _, ok := cr.GetAnnotations()[meta.AnnotationKeyExternalName]; ok {
+		return nil
+	}
+	if cr.Spec.ForProvider.Privileges.Login == nil {
+		login := true
+		cr.Spec.ForProvider.Privileges.Login = &login
+	}
+	return nil
+}
+
+// An orphanFinalizer emits an event when a role is released from management
+// without being dropped, so that roles left behind on purpose are visible to
+// operators.
+type orphanFinalizer struct {
+	resource.Finalizer
+	recorder event.Recorder
+}
+
+func (f *orphanFinalizer) RemoveFinalizer(ctx context.Context, obj resource.Object) error {
+	if err := f.Finalizer.RemoveFinalizer(ctx, obj); err != nil {
+		return err
+	}
+	if cr, ok := obj.(*v1alpha1.Role); ok && meta.WasDeleted(cr) && orphaned(cr) {
+		f.recorder.Event(cr, event.Normal(reasonOrphaned, "Role "+meta.GetExternalName(cr)+" was released from management and was not dropped"))
+	}
+	return nil
+}
+
+// orphaned reports whether the role should be kept when the Role is deleted.
+func orphaned(cr *v1alpha1.Role) bool {
+	if cr.GetDeletionPolicy() == xpv1.DeletionOrphan {
+		return true
+	}
+	if p := cr.GetManagementPolicies(); len(p) > 0 && !slices.Contains(p, xpv1.ManagementActionAll) && !slices.Contains(p, xpv1.ManagementActionDelete) {
+		return true
+	}
+	return cr.Spec.ForProvider.SkipDrop != nil && *cr.Spec.ForProvider.SkipDrop
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
 	kube      client.Client
 	usage     resource.Tracker
-	newClient func(creds map[string][]byte, keyspace string) cassandra.DB
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+	recorder  event.Recorder
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -110,32 +273,40 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	credsData, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if pc.Spec.Dialect == apisv1alpha1.DialectAmazonKeyspaces {
+		return nil, errors.New(errUnsupportedDialect)
+	}
+
+	creds, err := cassandra.ProviderCredentials(ctx, c.kube, pc.Spec.Credentials)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	// Convert the byte array to a string and parse the JSON
-	credsJSON := string(credsData)
-	var credsMap map[string]string
-	if err := json.Unmarshal([]byte(credsJSON), &credsMap); err != nil {
-		return nil, errors.Wrap(err, "failed to parse credentials JSON")
+	opts, err := cassandra.ProviderConfigOptions(ctx, c.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
 	}
-
-	// Convert map[string]string to map[string][]byte
-	creds := make(map[string][]byte)
-	for k, v := range credsMap {
-		creds[k] = []byte(v)
+	ropts, err := cassandra.ResourceOptions(cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
 	}
+	opts = append(opts, ropts...)
+	opts = append(opts, cassandra.WithSharedSession(ctx, cassandra.SessionKey(pc, creds, cr)))
+	opts = append(opts, cassandra.RateLimits.For(pc))
+	db := cassandra.RecordStatements(c.newClient(creds, "", opts...), c.recorder, cr)
 
-	db := c.newClient(creds, "")
+	// The role the provider authenticates as is protected as well, so that a
+	// Role cannot lock the provider out of the cluster.
+	protected := append([]string{string(creds[xpv1.ResourceCredentialsSecretUserKey])}, pc.Spec.ProtectedRoles...)
 
-	return &external{db: db}, nil
+	return cassandra.ReportErrors(&external{db: db, kube: c.kube, protected: protected, conflicts: pc.Spec.ConflictPolicy}), nil
 }
 
 type external struct {
-	db cassandra.DB
+	db        cassandra.DB
+	kube      client.Client
+	protected []string
+	conflicts apisv1alpha1.ConflictPolicy
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -144,6 +315,12 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRole)
 	}
 
+	// Report a role that is kept on deletion as gone, so that the managed
+	// resource is released without issuing DROP ROLE.
+	if meta.WasDeleted(cr) && orphaned(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
 	query := "SELECT is_superuser, can_login FROM system_auth.roles WHERE role = ?"
 	var isSuperuser, canLogin bool
 	iter, err := c.db.Query(ctx, query, meta.GetExternalName(cr))
@@ -157,13 +334,19 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}
 	}()
 
-	if !c.db.Scan(iter, &isSuperuser, &canLogin) {
+	if !iter.Scan(&isSuperuser, &canLogin) {
 		return managed.ExternalObservation{
 			ResourceExists:   false,
 			ResourceUpToDate: false,
 		}, nil
 	}
 
+	// An object that already exists is only adopted if the conflict policy
+	// of the ProviderConfig allows it.
+	if err := cassandra.Conflict(cr, c.conflicts); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	observed := &v1alpha1.RoleParameters{
 		Privileges: v1alpha1.RolePrivilege{
 			SuperUser: &isSuperuser,
@@ -171,41 +354,429 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		},
 	}
 
+	// A role that existed before the Role is adopted as it is: its options
+	// and memberships are late initialized along with its privileges.
+	adopted := cassandra.Adopt(cr)
+
+	_, pwdChanged, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	_, pwdDrifted, err := c.passwordDrifted(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	_, hashChanged, err := c.getHashedPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	secretMissing, err := c.connectionSecretMissing(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if len(cr.Spec.ForProvider.Options) > 0 || adopted {
+		if observed.Options, err = c.getOptions(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	memberOf, err := c.getMemberOf(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	permissions, err := c.getPermissions(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.AtProvider.MemberOf = memberOf
+	cr.Status.AtProvider.Permissions = permissions
+	if cr.Spec.ForProvider.MemberOf != nil || adopted {
+		observed.MemberOf = memberOf
+	}
+
 	cr.SetConditions(xpv1.Available())
 
+	li := lateInit(observed, &cr.Spec.ForProvider) || adopted
+	d := drift(observed, &cr.Spec.ForProvider)
+	if pwdChanged || hashChanged {
+		d = append(d, "password: changed in its secret")
+	}
+	if pwdDrifted {
+		d = append(d, "password: changed in the cluster")
+	}
+	if secretMissing {
+		d = append(d, "connection secret: missing")
+	}
+	if rotationDue(cr) {
+		d = append(d, "password: due for rotation")
+	}
+
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceLateInitialized: lateInit(observed, &cr.Spec.ForProvider),
-		ResourceUpToDate:        upToDate(observed, &cr.Spec.ForProvider),
+		ResourceLateInitialized: li,
+		ResourceUpToDate:        len(d) == 0,
+		Diff:                    d.String(),
 	}, nil
 }
 
+// getPassword returns the password referenced by the role, and whether it
+// differs from the password published in the connection secret. It returns an
+// empty password when the role does not reference a password secret.
+func (c *external) getPassword(ctx context.Context, cr *v1alpha1.Role) (string, bool, error) {
+	ref := cr.Spec.ForProvider.PasswordSecretRef
+	if ref == nil {
+		return "", false, nil
+	}
+
+	pwd, err := c.secretValue(ctx, ref)
+	if err != nil {
+		return "", false, errors.Wrap(err, errGetPasswordSecret)
+	}
+
+	if cr.GetWriteConnectionSecretToReference() == nil {
+		return pwd, false, nil
+	}
+
+	// Compare with the password in the connection secret to find out whether
+	// the referenced secret changed since the password was last set.
+	published, err := c.publishedPassword(ctx, cr)
+	if err != nil {
+		return "", false, err
+	}
+	return pwd, published != pwd, nil
+}
+
+// secretValue returns the value of the referenced secret key.
+func (c *external) secretValue(ctx context.Context, ref *xpv1.SecretKeySelector) (string, error) {
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+		return "", err
+	}
+	return string(s.Data[ref.Key]), nil
+}
+
+// publishedPassword returns the password in the connection secret of the
+// role, or an empty string when there is none.
+func (c *external) publishedPassword(ctx context.Context, cr *v1alpha1.Role) (string, error) {
+	cs := cr.GetWriteConnectionSecretToReference()
+	if cs == nil {
+		return "", nil
+	}
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cs.Name, Namespace: cs.Namespace}, s); resource.IgnoreNotFound(err) != nil {
+		return "", errors.Wrap(err, errGetConnectionSecret)
+	}
+	return string(s.Data[connectionSecretKey(cr, xpv1.ResourceCredentialsSecretPasswordKey)]), nil
+}
+
+// connectionSecretMissing reports whether the generated password of the role
+// is missing from its connection secret, for example because the secret was
+// deleted. The password cannot be recovered, so a new one must be set. The
+// password of an adopted role was not generated, and is kept.
+func (c *external) connectionSecretMissing(ctx context.Context, cr *v1alpha1.Role) (bool, error) {
+	params := cr.Spec.ForProvider
+	if cr.GetWriteConnectionSecretToReference() == nil || params.PasswordSecretRef != nil || params.HashedPasswordSecretRef != nil || passwordless(cr) || initialPassword(cr) || cassandra.Adopted(cr) {
+		return false, nil
+	}
+	published, err := c.publishedPassword(ctx, cr)
+	if err != nil {
+		return false, err
+	}
+	return published == "", nil
+}
+
+// connectionSecretKey returns the key a connection detail of the role is
+// published under.
+func connectionSecretKey(cr *v1alpha1.Role, key string) string {
+	if k, ok := cr.Spec.ForProvider.ConnectionSecretKeys[key]; ok {
+		return k
+	}
+	return key
+}
+
+// connectionDetails renames the connection details of the role to the keys
+// they are published under, and adds a connection string assembled from them.
+func connectionDetails(cr *v1alpha1.Role, cd managed.ConnectionDetails) managed.ConnectionDetails {
+	out := make(managed.ConnectionDetails, len(cd)+1)
+	for k, v := range cd {
+		out[connectionSecretKey(cr, k)] = v
+	}
+	keyspace := ""
+	if cr.Spec.ForProvider.ConnectionKeyspace != nil {
+		keyspace = *cr.Spec.ForProvider.ConnectionKeyspace
+	}
+	if cs := cassandra.ConnectionString(cd, keyspace); cs != "" {
+		out[connectionSecretKey(cr, cassandra.ConnectionSecretConnectionStringKey)] = []byte(cs)
+	}
+	return out
+}
+
+// passwordDrifted reports whether the password of the role was changed
+// outside of Crossplane, by checking the salted hash stored by Cassandra
+// against the password last set by the provider. It returns the password to
+// restore.
+func (c *external) passwordDrifted(ctx context.Context, cr *v1alpha1.Role) (string, bool, error) {
+	if d := cr.Spec.ForProvider.DetectPasswordChanges; d == nil || !*d || passwordless(cr) {
+		return "", false, nil
+	}
+
+	pwd, _, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return "", false, err
+	}
+	if pwd == "" {
+		if pwd, err = c.publishedPassword(ctx, cr); err != nil {
+			return "", false, err
+		}
+	}
+	if pwd == "" {
+		return "", false, nil
+	}
+
+	hash, err := c.saltedHash(ctx, cr)
+	if err != nil {
+		return "", false, err
+	}
+
+	return pwd, bcrypt.CompareHashAndPassword([]byte(hash), []byte(pwd)) != nil, nil
+}
+
+// saltedHash returns the password hash of the role stored in system_auth.
+func (c *external) saltedHash(ctx context.Context, cr *v1alpha1.Role) (string, error) {
+	iter, err := c.db.Query(ctx, "SELECT salted_hash FROM system_auth.roles WHERE role = ?", meta.GetExternalName(cr))
+	if err != nil {
+		return "", errors.Wrap(err, errSelectRole)
+	}
+	var hash string
+	iter.Scan(&hash)
+	if err := iter.Close(); err != nil {
+		return "", errors.Wrap(err, errSelectRole)
+	}
+	return hash, nil
+}
+
+// getHashedPassword returns the password hash referenced by the role, and
+// whether it differs from the hash stored in system_auth. It returns an empty
+// hash when the role does not reference a hashed password secret.
+func (c *external) getHashedPassword(ctx context.Context, cr *v1alpha1.Role) (string, bool, error) {
+	ref := cr.Spec.ForProvider.HashedPasswordSecretRef
+	if ref == nil {
+		return "", false, nil
+	}
+
+	desired, err := c.secretValue(ctx, ref)
+	if err != nil {
+		return "", false, errors.Wrap(err, errGetHashedPassword)
+	}
+
+	observed, err := c.saltedHash(ctx, cr)
+	if err != nil {
+		return "", false, err
+	}
+	return desired, observed != desired, nil
+}
+
+// getOptions returns the custom options of the role as reported by the role
+// manager. They are not stored in system_auth.roles, so LIST ROLES is used.
+func (c *external) getOptions(ctx context.Context, cr *v1alpha1.Role) (map[string]string, error) {
+	name := meta.GetExternalName(cr)
+	iter, err := c.db.Query(ctx, "LIST ROLES OF "+cassandra.QuoteIdentifier(name)+" NORECURSIVE")
+	if err != nil {
+		return nil, errors.Wrap(err, errListRoleOptions)
+	}
+
+	var options map[string]string
+	for {
+		row := map[string]interface{}{}
+		if !iter.MapScan(row) {
+			break
+		}
+		if row["role"] == name {
+			options, _ = row["options"].(map[string]string)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, errListRoleOptions)
+	}
+	return options, nil
+}
+
+// getMemberOf returns the roles granted to the role, in sorted order.
+func (c *external) getMemberOf(ctx context.Context, cr *v1alpha1.Role) ([]string, error) {
+	iter, err := c.db.Query(ctx, "SELECT role FROM system_auth.role_members WHERE member = ? ALLOW FILTERING", meta.GetExternalName(cr))
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectMemberOf)
+	}
+
+	memberOf := []string{}
+	var role string
+	for iter.Scan(&role) {
+		memberOf = append(memberOf, role)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, errSelectMemberOf)
+	}
+	sort.Strings(memberOf)
+	return memberOf, nil
+}
+
+// getPermissions returns the permissions granted directly to the role, sorted
+// by resource.
+func (c *external) getPermissions(ctx context.Context, cr *v1alpha1.Role) ([]v1alpha1.RolePermissions, error) {
+	iter, err := c.db.Query(ctx, "SELECT resource, permissions FROM system_auth.role_permissions WHERE role = ?", meta.GetExternalName(cr))
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectPermissions)
+	}
+
+	var permissions []v1alpha1.RolePermissions
+	var res string
+	var perms []string
+	for iter.Scan(&res, &perms) {
+		sort.Strings(perms)
+		permissions = append(permissions, v1alpha1.RolePermissions{Resource: res, Permissions: perms})
+		perms = nil
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, errSelectPermissions)
+	}
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i].Resource < permissions[j].Resource
+	})
+	return permissions, nil
+}
+
+// syncMemberOf grants the roles listed in MemberOf to the role, and revokes
+// any other role it is a member of.
+func (c *external) syncMemberOf(ctx context.Context, cr *v1alpha1.Role) error {
+	desired := cr.Spec.ForProvider.MemberOf
+	if desired == nil {
+		return nil
+	}
+	observed, err := c.getMemberOf(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	name := cassandra.QuoteIdentifier(meta.GetExternalName(cr))
+	for _, r := range desired {
+		if slices.Contains(observed, r) {
+			continue
+		}
+		if err := c.db.Exec(ctx, "GRANT "+cassandra.QuoteIdentifier(r)+" TO "+name); err != nil {
+			return errors.Wrap(err, errGrantRole)
+		}
+	}
+	for _, r := range observed {
+		if slices.Contains(desired, r) {
+			continue
+		}
+		if err := c.db.Exec(ctx, "REVOKE "+cassandra.QuoteIdentifier(r)+" FROM "+name); err != nil {
+			return errors.Wrap(err, errRevokeRole)
+		}
+	}
+	return nil
+}
+
+// optionsClause returns the WITH OPTIONS clause of the role in CQL syntax.
+func optionsClause(options map[string]string) string {
+	return " AND OPTIONS = " + cassandra.QuoteMap(options)
+}
+
+// passwordless reports whether the role is created without a password.
+func passwordless(cr *v1alpha1.Role) bool {
+	return cr.Spec.ForProvider.Passwordless != nil && *cr.Spec.ForProvider.Passwordless
+}
+
+// initialPassword reports whether the password of the role is only set in
+// initProvider, in which case it is set when the role is created and left as
+// it is afterwards.
+func initialPassword(cr *v1alpha1.Role) bool {
+	p, i := cr.Spec.ForProvider, cr.Spec.InitProvider
+	return p.PasswordSecretRef == nil && p.HashedPasswordSecretRef == nil && !passwordless(cr) &&
+		(i.PasswordSecretRef != nil || i.HashedPasswordSecretRef != nil)
+}
+
+// rotationDue reports whether the generated password of the role is older
+// than its rotation period.
+func rotationDue(cr *v1alpha1.Role) bool {
+	params := cr.Spec.ForProvider
+	if params.RotateAfter == nil || params.PasswordSecretRef != nil || params.HashedPasswordSecretRef != nil || passwordless(cr) || initialPassword(cr) {
+		return false
+	}
+	last := cr.GetCreationTimestamp()
+	if cr.Status.AtProvider.PasswordLastRotated != nil {
+		last = *cr.Status.AtProvider.PasswordLastRotated
+	}
+	return time.Since(last.Time) >= params.RotateAfter.Duration
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha1.Role)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotRole)
 	}
 
-	pw, err := generatePassword()
-	if err != nil {
-		return managed.ExternalCreation{}, err
-	}
-
 	params := cr.Spec.ForProvider
-	query := fmt.Sprintf("CREATE ROLE IF NOT EXISTS %s WITH SUPERUSER = %t AND LOGIN = %t AND PASSWORD = '%s'",
+	if initialPassword(cr) {
+		params.PasswordSecretRef = cr.Spec.InitProvider.PasswordSecretRef
+		params.HashedPasswordSecretRef = cr.Spec.InitProvider.HashedPasswordSecretRef
+	}
+	query := fmt.Sprintf("CREATE ROLE %s%s WITH SUPERUSER = %t AND LOGIN = %t", cassandra.IfNotExists(c.conflicts),
 		cassandra.QuoteIdentifier(meta.GetExternalName(cr)),
 		params.Privileges.SuperUser != nil && *params.Privileges.SuperUser,
-		params.Privileges.Login != nil && *params.Privileges.Login,
-		pw)
+		params.Privileges.Login != nil && *params.Privileges.Login)
+	if len(params.Options) > 0 {
+		query += optionsClause(params.Options)
+	}
 
-	if err := c.db.Exec(ctx, query); err != nil {
-		return managed.ExternalCreation{}, errors.New(errCreateRole + ": " + err.Error())
+	// Passwords and their hashes are redacted from the errors of the
+	// statements that set them.
+	var sensitive []string
+	if ref := params.HashedPasswordSecretRef; ref != nil {
+		hash, err := c.secretValue(ctx, ref)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGetHashedPassword)
+		}
+		query += " AND HASHED PASSWORD = " + cassandra.QuoteString(hash)
+		sensitive = append(sensitive, hash)
 	}
 
-	connectionDetails := c.db.GetConnectionDetails(meta.GetExternalName(cr), pw)
+	if params.HashedPasswordSecretRef != nil || passwordless(cr) {
+		if err := c.db.ExecSensitive(ctx, query, sensitive); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateRole)
+		}
+		// The plaintext password is unknown or there is none, so only the
+		// username and the endpoint are published.
+		cd := c.db.GetConnectionDetails(meta.GetExternalName(cr), "")
+		delete(cd, xpv1.ResourceCredentialsSecretPasswordKey)
+		return managed.ExternalCreation{ConnectionDetails: connectionDetails(cr, cd)}, nil
+	}
+
+	var (
+		pw  string
+		err error
+	)
+	if ref := params.PasswordSecretRef; ref != nil {
+		if pw, err = c.secretValue(ctx, ref); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGetPasswordSecret)
+		}
+	}
+	if pw == "" {
+		if pw, err = newPassword(params.PasswordGeneration); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+	query += " AND PASSWORD = " + cassandra.QuoteString(pw)
+
+	if err := c.db.ExecSensitive(ctx, query, []string{pw}); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateRole)
+	}
+
+	now := metav1.Now()
+	cr.Status.AtProvider.PasswordLastRotated = &now
 
 	return managed.ExternalCreation{
-		ConnectionDetails: connectionDetails,
+		ConnectionDetails: connectionDetails(cr, c.db.GetConnectionDetails(meta.GetExternalName(cr), pw)),
 	}, nil
 }
 
@@ -215,14 +786,68 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotRole)
 	}
 
+	pw, pwdChanged, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if !pwdChanged {
+		restore, drifted, err := c.passwordDrifted(ctx, cr)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if drifted {
+			pw, pwdChanged = restore, true
+		}
+	}
+	secretMissing, err := c.connectionSecretMissing(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if rotationDue(cr) || secretMissing {
+		if pw, err = newPassword(cr.Spec.ForProvider.PasswordGeneration); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		pwdChanged = true
+	}
+	hash, hashChanged, err := c.getHashedPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	params := cr.Spec.ForProvider
 	query := fmt.Sprintf("ALTER ROLE %s WITH SUPERUSER = %t AND LOGIN = %t",
 		cassandra.QuoteIdentifier(meta.GetExternalName(cr)),
 		params.Privileges.SuperUser != nil && *params.Privileges.SuperUser,
 		params.Privileges.Login != nil && *params.Privileges.Login)
+	var sensitive []string
+	if pwdChanged {
+		query += " AND PASSWORD = " + cassandra.QuoteString(pw)
+		sensitive = append(sensitive, pw)
+	}
+	if hashChanged {
+		query += " AND HASHED PASSWORD = " + cassandra.QuoteString(hash)
+		sensitive = append(sensitive, hash)
+	}
+	if len(params.Options) > 0 {
+		query += optionsClause(params.Options)
+	}
 
-	if err := c.db.Exec(ctx, query); err != nil {
-		return managed.ExternalUpdate{}, errors.New(errUpdateRole + ": " + err.Error())
+	if err := c.db.ExecSensitive(ctx, query, sensitive); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRole)
+	}
+
+	// Memberships are granted here rather than on creation, so that the
+	// generated password is published even if a grant fails.
+	if err := c.syncMemberOf(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if pwdChanged {
+		now := metav1.Now()
+		cr.Status.AtProvider.PasswordLastRotated = &now
+		return managed.ExternalUpdate{
+			ConnectionDetails: connectionDetails(cr, c.db.GetConnectionDetails(meta.GetExternalName(cr), pw)),
+		}, nil
 	}
 
 	return managed.ExternalUpdate{}, nil
@@ -234,22 +859,59 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotRole)
 	}
 
+	if orphaned(cr) {
+		return nil
+	}
+
+	if slices.Contains(c.protected, meta.GetExternalName(cr)) {
+		return errors.New(errProtectedRole)
+	}
+
 	query := fmt.Sprintf("DROP ROLE IF EXISTS %s", cassandra.QuoteIdentifier(meta.GetExternalName(cr)))
 	if err := c.db.Exec(ctx, query); err != nil {
-		return errors.New(errDropRole + ": " + err.Error())
+		return errors.Wrap(err, errDropRole)
 	}
 
 	return nil
 }
 
-func upToDate(observed *v1alpha1.RoleParameters, desired *v1alpha1.RoleParameters) bool {
+// drift returns how the observed privileges, memberships and options of a
+// role differ from the desired ones.
+func drift(observed *v1alpha1.RoleParameters, desired *v1alpha1.RoleParameters) cassandra.Drift {
+	d := cassandra.Drift{}
 	if observed.Privileges.SuperUser == nil || desired.Privileges.SuperUser == nil || *observed.Privileges.SuperUser != *desired.Privileges.SuperUser {
-		return false
+		d.Field("superuser", cassandra.Value(desired.Privileges.SuperUser), cassandra.Value(observed.Privileges.SuperUser))
 	}
 	if observed.Privileges.Login == nil || desired.Privileges.Login == nil || *observed.Privileges.Login != *desired.Privileges.Login {
-		return false
+		d.Field("login", cassandra.Value(desired.Privileges.Login), cassandra.Value(observed.Privileges.Login))
+	}
+	if desired.MemberOf != nil {
+		memberOf := slices.Clone(desired.MemberOf)
+		sort.Strings(memberOf)
+		if memberOf = slices.Compact(memberOf); !slices.Equal(memberOf, observed.MemberOf) {
+			d.Field("member_of", memberOf, observed.MemberOf)
+		}
 	}
-	return true
+	// The role manager may report options that were not set by the provider.
+	keys := make([]string, 0, len(desired.Options))
+	for k := range desired.Options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if got, ok := observed.Options[k]; !ok || got != desired.Options[k] {
+			d.Field("options."+k, desired.Options[k], cassandra.Value(lookup(observed.Options, k)))
+		}
+	}
+	return d
+}
+
+// lookup returns the value of a key of a map, or nil if it is not set.
+func lookup(m map[string]string, k string) *string {
+	if v, ok := m[k]; ok {
+		return &v
+	}
+	return nil
 }
 
 func lateInit(observed *v1alpha1.RoleParameters, desired *v1alpha1.RoleParameters) bool {
@@ -263,6 +925,14 @@ func lateInit(observed *v1alpha1.RoleParameters, desired *v1alpha1.RoleParameter
 		desired.Privileges.Login = observed.Privileges.Login
 		li = true
 	}
+	if desired.MemberOf == nil && len(observed.MemberOf) > 0 {
+		desired.MemberOf = observed.MemberOf
+		li = true
+	}
+	if desired.Options == nil && len(observed.Options) > 0 {
+		desired.Options = observed.Options
+		li = true
+	}
 
 	return li
 }
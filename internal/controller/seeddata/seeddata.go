@@ -0,0 +1,581 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seeddata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+	"github.com/crossplane/provider-cassandra/internal/features"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+const (
+	errNotSeedData  = "managed resource is not a SeedData custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errGetCreds     = "cannot get credentials"
+	errNewClient    = "cannot create new Service"
+
+	errNoKeyspace = "keyspace is not set"
+	errDecodeRow  = "cannot decode row %d"
+	errMissingKey = "row %d does not set primary key column %q"
+	errColumns    = "cannot read the column types of the table"
+	errSelectRow  = "cannot select row %d"
+	errUpsertRow  = "cannot upsert row %d"
+	errDeleteRow  = "cannot delete row %d"
+)
+
+// Setup adds a controller that reconciles SeedData managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.SeedDataGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newClient: cassandra.Sessions.New}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(cassandra.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+	}
+
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.SeedDataGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.SeedData{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.SeedData)
+	if !ok {
+		return nil, errors.New(errNotSeedData)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	creds, err := cassandra.ProviderCredentials(ctx, c.kube, pc.Spec.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	opts, err := cassandra.ProviderConfigOptions(ctx, c.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	ropts, err := cassandra.ResourceOptions(cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	opts = append(opts, ropts...)
+	opts = append(opts, cassandra.WithSharedSession(ctx, cassandra.SessionKey(pc, creds, cr)))
+	opts = append(opts, cassandra.RateLimits.For(pc))
+	db := c.newClient(creds, "", opts...)
+
+	return cassandra.ReportErrors(&external{db: db, identifiers: pc.Spec.IdentifierCase}), nil
+}
+
+type external struct {
+	db          cassandra.DB
+	identifiers apisv1alpha1.IdentifierCase
+}
+
+// A row is a single desired row of a SeedData.
+type row struct {
+	index  int
+	raw    string
+	values map[string]interface{}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SeedData)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSeedData)
+	}
+
+	rows, err := desiredRows(cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	present, drifted, err := c.observeRows(ctx, cr.Spec.ForProvider, rows)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if present == 0 {
+		return managed.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: false,
+		}, nil
+	}
+
+	cr.Status.AtProvider.InSyncRows = len(rows) - len(drifted)
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(drifted) == 0,
+		Diff:             rowsDrift(drifted).String(),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SeedData)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSeedData)
+	}
+
+	rows, err := desiredRows(cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{}, c.upsertRows(ctx, cr.Spec.ForProvider, rows)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SeedData)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSeedData)
+	}
+
+	rows, err := desiredRows(cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Only rows that are missing or differ are written again.
+	_, drifted, err := c.observeRows(ctx, cr.Spec.ForProvider, rows)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{}, c.upsertRows(ctx, cr.Spec.ForProvider, drifted)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SeedData)
+	if !ok {
+		return errors.New(errNotSeedData)
+	}
+
+	p := cr.Spec.ForProvider
+	rows, err := desiredRows(p)
+	if err != nil {
+		return err
+	}
+
+	query := "DELETE FROM " + c.table(p) + " WHERE " + keyClause(p.PrimaryKey)
+	for _, r := range rows {
+		args, err := keyArgs(r, p.PrimaryKey)
+		if err != nil {
+			return err
+		}
+		if err := c.db.Exec(ctx, query, args...); err != nil {
+			return errors.Wrapf(err, errDeleteRow, r.index)
+		}
+	}
+
+	return nil
+}
+
+// rowsDrift records the indexes of the rows that are missing or differ. Their
+// values are not recorded, as seed data may be sensitive.
+func rowsDrift(drifted []row) cassandra.Drift {
+	d := cassandra.Drift{}
+	for _, r := range drifted {
+		d = append(d, fmt.Sprintf("rows[%d]: missing or different", r.index))
+	}
+	return d
+}
+
+// observeRows reads every desired row by its primary key and returns how many
+// of them exist and which of them are missing or differ from the spec.
+func (c *external) observeRows(ctx context.Context, p v1alpha1.SeedDataParameters, rows []row) (int, []row, error) {
+	types, err := c.columnTypes(ctx, p)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	present := 0
+	var drifted []row
+	for _, r := range rows {
+		args, err := keyArgs(r, p.PrimaryKey)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		columns := sortedColumns(r.values)
+		quoted := make([]string, len(columns))
+		for i, col := range columns {
+			quoted[i] = cassandra.QuoteIdentifier(col)
+		}
+		query := "SELECT JSON " + strings.Join(quoted, ", ") + " FROM " + c.table(p) + " WHERE " + keyClause(p.PrimaryKey)
+
+		iter, err := c.db.Query(ctx, query, args...)
+		if err != nil {
+			return 0, nil, errors.Wrapf(err, errSelectRow, r.index)
+		}
+		var observed string
+		found := iter.Scan(&observed)
+		if err := iter.Close(); err != nil {
+			return 0, nil, errors.Wrapf(err, errSelectRow, r.index)
+		}
+
+		if !found {
+			drifted = append(drifted, r)
+			continue
+		}
+		present++
+		if !rowMatches(r.values, observed, types) {
+			drifted = append(drifted, r)
+		}
+	}
+
+	return present, drifted, nil
+}
+
+func (c *external) upsertRows(ctx context.Context, p v1alpha1.SeedDataParameters, rows []row) error {
+	query := "INSERT INTO " + c.table(p) + " JSON ? DEFAULT UNSET"
+	for _, r := range rows {
+		if err := c.db.Exec(ctx, query, r.raw); err != nil {
+			return errors.Wrapf(err, errUpsertRow, r.index)
+		}
+	}
+	return nil
+}
+
+func desiredRows(p v1alpha1.SeedDataParameters) ([]row, error) {
+	if p.Keyspace == nil {
+		return nil, errors.New(errNoKeyspace)
+	}
+
+	rows := make([]row, len(p.Rows))
+	for i, raw := range p.Rows {
+		values := map[string]interface{}{}
+		if err := decode(raw.Raw, &values); err != nil {
+			return nil, errors.Wrapf(err, errDecodeRow, i)
+		}
+		rows[i] = row{index: i, raw: string(raw.Raw), values: values}
+	}
+	return rows, nil
+}
+
+// columnTypes returns the CQL types of the columns of the table, keyed by
+// column name.
+func (c *external) columnTypes(ctx context.Context, p v1alpha1.SeedDataParameters) (map[string]cqlType, error) {
+	ks, t := c.names(p)
+	iter, err := c.db.Query(ctx, "SELECT column_name, type FROM system_schema.columns WHERE keyspace_name = ? AND table_name = ?", ks, t)
+	if err != nil {
+		return nil, errors.Wrap(err, errColumns)
+	}
+	types := map[string]cqlType{}
+	var name, typ string
+	for iter.Scan(&name, &typ) {
+		types[name] = parseType(typ)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Wrap(err, errColumns)
+	}
+	return types, nil
+}
+
+// rowMatches reports whether every desired column has the same value in the
+// observed row, which is the output of a SELECT JSON. Values are compared by
+// the type of their column, as SELECT JSON formats them in one of the many
+// ways they can be written.
+func rowMatches(desired map[string]interface{}, observedJSON string, types map[string]cqlType) bool {
+	observed := map[string]interface{}{}
+	if err := decode([]byte(observedJSON), &observed); err != nil {
+		return false
+	}
+	for col, want := range desired {
+		got, ok := observed[col]
+		if !ok {
+			// SELECT JSON quotes the keys of case-sensitive columns.
+			got, ok = observed[`"`+col+`"`]
+		}
+		if !ok {
+			return false
+		}
+		t := types[col]
+		if !reflect.DeepEqual(normalize(t, want), normalize(t, got)) {
+			return false
+		}
+	}
+	return true
+}
+
+// decode decodes a JSON object, keeping numbers as they are written so that
+// decimals and large integers are compared without losing precision.
+func decode(data []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// A cqlType is a CQL type, such as int or map<text, frozen<list<int>>>.
+type cqlType struct {
+	name   string
+	params []cqlType
+}
+
+// parseType parses a CQL type as it is stored in system_schema.columns.
+func parseType(s string) cqlType {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '<')
+	if open < 0 || !strings.HasSuffix(s, ">") {
+		return cqlType{name: strings.ToLower(s)}
+	}
+
+	t := cqlType{name: strings.ToLower(strings.TrimSpace(s[:open]))}
+	inner := s[open+1 : len(s)-1]
+	depth, start := 0, 0
+	for i, r := range inner {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				t.params = append(t.params, parseType(inner[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	t.params = append(t.params, parseType(inner[start:]))
+	return t
+}
+
+// param returns the type of the element at the supplied index of a value of
+// a collection or tuple type.
+func (t cqlType) param(i int) cqlType {
+	switch {
+	case t.name == "tuple" && i < len(t.params):
+		return t.params[i]
+	case t.name != "tuple" && len(t.params) > 0:
+		return t.params[0]
+	}
+	return cqlType{}
+}
+
+// timestampLayouts are the layouts of the timestamps Cassandra accepts, and
+// that SELECT JSON returns. Fractional seconds are accepted by each of them.
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05Z0700",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04Z0700",
+	"2006-01-02T15:04Z0700",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02Z0700",
+	"2006-01-02",
+}
+
+// normalize returns a value of a column of the supplied type in a form that
+// is equal to that of every other way the value can be written in JSON: a
+// timestamp as a string or as milliseconds since the epoch, a number with or
+// without trailing zeros, a UUID in either case or a set in any order. Values
+// of other types are returned unchanged.
+func normalize(t cqlType, v interface{}) interface{} {
+	switch t.name {
+	case "frozen":
+		return normalize(t.param(0), v)
+	case "timestamp":
+		if ms, ok := timestamp(v); ok {
+			return ms
+		}
+	case "tinyint", "smallint", "int", "bigint", "varint", "counter", "decimal", "float", "double":
+		if n, ok := number(v); ok {
+			return n
+		}
+	case "boolean":
+		if s, ok := v.(string); ok {
+			if b, err := strconv.ParseBool(s); err == nil {
+				return b
+			}
+		}
+	case "uuid", "timeuuid", "blob":
+		if s, ok := v.(string); ok {
+			return strings.ToLower(s)
+		}
+	case "list", "set", "tuple":
+		l, ok := v.([]interface{})
+		if !ok {
+			break
+		}
+		out := make([]interface{}, len(l))
+		for i, e := range l {
+			out[i] = normalize(t.param(i), e)
+		}
+		if t.name == "set" {
+			sort.Slice(out, func(i, j int) bool { return fmt.Sprint(out[i]) < fmt.Sprint(out[j]) })
+		}
+		return out
+	case "map":
+		m, ok := v.(map[string]interface{})
+		if !ok || len(t.params) != 2 {
+			break
+		}
+		out := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			out[fmt.Sprint(normalize(t.params[0], k))] = normalize(t.params[1], e)
+		}
+		return out
+	}
+	return v
+}
+
+// timestamp returns the milliseconds since the epoch of a timestamp written
+// as a number of milliseconds or as a string. Timestamps without a timezone
+// are in UTC.
+func timestamp(v interface{}) (int64, bool) {
+	var s string
+	switch v := v.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return 0, false
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ms, true
+	}
+	for _, layout := range timestampLayouts {
+		if ts, err := time.Parse(layout, s); err == nil {
+			return ts.UnixMilli(), true
+		}
+	}
+	return 0, false
+}
+
+// number returns the exact value of a number written as a JSON number or as a
+// string, as a fraction in lowest terms.
+func number(v interface{}) (string, bool) {
+	var s string
+	switch v := v.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return "", false
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return "", false
+	}
+	return r.RatString(), true
+}
+
+func keyClause(primaryKey []string) string {
+	clauses := make([]string, len(primaryKey))
+	for i, col := range primaryKey {
+		clauses[i] = cassandra.QuoteIdentifier(col) + " = fromJson(?)"
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+func keyArgs(r row, primaryKey []string) ([]interface{}, error) {
+	args := make([]interface{}, len(primaryKey))
+	for i, col := range primaryKey {
+		v, ok := r.values[col]
+		if !ok {
+			return nil, errors.Errorf(errMissingKey, r.index, col)
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, errDecodeRow, r.index)
+		}
+		args[i] = string(b)
+	}
+	return args, nil
+}
+
+func sortedColumns(values map[string]interface{}) []string {
+	columns := make([]string, 0, len(values))
+	for col := range values {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// names returns the names of the keyspace and table the rows are seeded
+// into, as Cassandra stores them.
+func (c *external) names(p v1alpha1.SeedDataParameters) (string, string) {
+	return cassandra.NormalizeIdentifier(c.identifiers, *p.Keyspace), cassandra.NormalizeIdentifier(c.identifiers, p.Table)
+}
+
+// table returns the quoted name of the table the rows are seeded into.
+func (c *external) table(p v1alpha1.SeedDataParameters) string {
+	ks, t := c.names(p)
+	return fmt.Sprintf("%s.%s", cassandra.QuoteIdentifier(ks), cassandra.QuoteIdentifier(t))
+}
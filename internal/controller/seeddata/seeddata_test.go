@@ -0,0 +1,453 @@
+package seeddata
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+)
+
+func pointerToString(s string) *string {
+	return &s
+}
+
+func seedData(rows ...string) *v1alpha1.SeedData {
+	raw := make([]runtime.RawExtension, len(rows))
+	for i, r := range rows {
+		raw[i] = runtime.RawExtension{Raw: []byte(r)}
+	}
+	return &v1alpha1.SeedData{
+		Spec: v1alpha1.SeedDataSpec{
+			ForProvider: v1alpha1.SeedDataParameters{
+				Keyspace:   pointerToString("example_keyspace"),
+				Table:      "countries",
+				PrimaryKey: []string{"code"},
+				Rows:       raw,
+			},
+		},
+	}
+}
+
+// columns are the columns of the table rows are seeded into, and their types.
+var columns = [][]interface{}{
+	{"code", "text"},
+	{"name", "text"},
+	{"founded", "timestamp"},
+	{"area", "decimal"},
+}
+
+// stored returns a MockDB answering SELECT JSON queries from the supplied rows,
+// keyed by the JSON encoded primary key.
+func stored(rows map[string]string) *cassandra.MockDB {
+	return &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			if strings.Contains(query, "system_schema.columns") {
+				return &cassandra.MockIterator{Rows: columns}, nil
+			}
+			iter := &cassandra.MockIterator{}
+			if r, ok := rows[args[0].(string)]; ok {
+				iter.Rows = [][]interface{}{{r}}
+			}
+			return iter, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db cassandra.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotSeedData": {
+			reason: "Should return an error if the managed resource is not a *SeedData",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotSeedData),
+			},
+		},
+		"ErrMissingKey": {
+			reason: "Should return an error if a row does not set the primary key",
+			fields: fields{
+				db: stored(nil),
+			},
+			args: args{
+				mg: seedData(`{"name": "Poland"}`),
+			},
+			want: want{
+				err: errors.Errorf(errMissingKey, 0, "code"),
+			},
+		},
+		"NoRows": {
+			reason: "Should return ResourceExists: false when none of the rows exist",
+			fields: fields{
+				db: stored(nil),
+			},
+			args: args{
+				mg: seedData(`{"code": "PL", "name": "Poland"}`),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"InSync": {
+			reason: "Should return ResourceUpToDate: true when every row matches",
+			fields: fields{
+				db: stored(map[string]string{
+					`"PL"`: `{"code": "PL", "name": "Poland"}`,
+					`"DE"`: `{"code": "DE", "name": "Germany"}`,
+				}),
+			},
+			args: args{
+				mg: seedData(`{"code": "PL", "name": "Poland"}`, `{"code": "DE", "name": "Germany"}`),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"InSyncTimestamp": {
+			reason: "Should return ResourceUpToDate: true when a timestamp is written differently than SELECT JSON returns it",
+			fields: fields{
+				db: stored(map[string]string{
+					`"PL"`: `{"code": "PL", "founded": "1918-11-11 00:00:00.000Z"}`,
+					`"DE"`: `{"code": "DE", "founded": "1949-05-23 00:00:00.000Z"}`,
+				}),
+			},
+			args: args{
+				mg: seedData(`{"code": "PL", "founded": "1918-11-11T00:00:00Z"}`, `{"code": "DE", "founded": -650419200000}`),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"InSyncDecimal": {
+			reason: "Should return ResourceUpToDate: true when a decimal is written with a different scale than SELECT JSON returns it",
+			fields: fields{
+				db: stored(map[string]string{
+					`"PL"`: `{"code": "PL", "area": 312696.00}`,
+					`"DE"`: `{"code": "DE", "area": 357588.12345678901234567}`,
+				}),
+			},
+			args: args{
+				mg: seedData(`{"code": "PL", "area": "312696"}`, `{"code": "DE", "area": 357588.12345678901234567}`),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DriftedDecimal": {
+			reason: "Should return ResourceUpToDate: false when decimals differ beyond the precision of a float",
+			fields: fields{
+				db: stored(map[string]string{
+					`"DE"`: `{"code": "DE", "area": 357588.12345678901234568}`,
+				}),
+			},
+			args: args{
+				mg: seedData(`{"code": "DE", "area": 357588.12345678901234567}`),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "rows[0]: missing or different"},
+			},
+		},
+		"ErrColumns": {
+			reason: "Should return an error if the column types of the table cannot be read",
+			fields: fields{
+				db: &cassandra.MockDB{
+					QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: seedData(`{"code": "PL", "name": "Poland"}`),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errColumns),
+			},
+		},
+		"Drifted": {
+			reason: "Should return ResourceUpToDate: false when a row differs or is missing",
+			fields: fields{
+				db: stored(map[string]string{
+					`"PL"`: `{"code": "PL", "name": "Polska"}`,
+				}),
+			},
+			args: args{
+				mg: seedData(`{"code": "PL", "name": "Poland"}`, `{"code": "DE", "name": "Germany"}`),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "rows[0]: missing or different; rows[1]: missing or different"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		written []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		rows   map[string]string
+		execs  error
+		args   args
+		want   want
+	}{
+		"ErrNotSeedData": {
+			reason: "Should return an error if the managed resource is not a *SeedData",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotSeedData),
+			},
+		},
+		"OnlyDriftedRows": {
+			reason: "Should only upsert rows that are missing or differ",
+			rows: map[string]string{
+				`"PL"`: `{"code": "PL", "name": "Poland"}`,
+				`"DE"`: `{"code": "DE", "name": "Deutschland"}`,
+			},
+			args: args{
+				mg: seedData(`{"code": "PL", "name": "Poland"}`, `{"code": "DE", "name": "Germany"}`, `{"code": "CZ", "name": "Czechia"}`),
+			},
+			want: want{
+				written: []string{`{"code": "DE", "name": "Germany"}`, `{"code": "CZ", "name": "Czechia"}`},
+			},
+		},
+		"ErrUpsert": {
+			reason: "Should return an error if a row cannot be written",
+			execs:  errBoom,
+			args: args{
+				mg: seedData(`{"code": "PL", "name": "Poland"}`),
+			},
+			want: want{
+				written: []string{`{"code": "PL", "name": "Poland"}`},
+				err:     errors.Wrapf(errBoom, errUpsertRow, 0),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var written []string
+			db := stored(tc.rows)
+			db.ExecFunc = func(ctx context.Context, query string, args ...interface{}) error {
+				if query != "INSERT INTO \"example_keyspace\".\"countries\" JSON ? DEFAULT UNSET" {
+					return errors.New("unexpected query: " + query)
+				}
+				written = append(written, args[0].(string))
+				return tc.execs
+			}
+			e := external{db: db}
+			_, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.written, written); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want rows, +got rows:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason      string
+		identifiers apisv1alpha1.IdentifierCase
+		mg          resource.Managed
+		err         error
+		want        error
+	}{
+		"ErrNotSeedData": {
+			reason: "Should return an error if the managed resource is not a *SeedData",
+			mg:     nil,
+			want:   errors.New(errNotSeedData),
+		},
+		"DeleteRows": {
+			reason: "Should delete every seeded row by primary key",
+			mg:     seedData(`{"code": "PL", "name": "Poland"}`),
+		},
+		"LowercaseIdentifiers": {
+			reason:      "Should delete rows from the table as Cassandra stores its name when identifiers are lowercased",
+			identifiers: apisv1alpha1.IdentifierCaseLower,
+			mg: func() resource.Managed {
+				cr := seedData(`{"code": "PL", "name": "Poland"}`)
+				cr.Spec.ForProvider.Keyspace = pointerToString("Example_Keyspace")
+				cr.Spec.ForProvider.Table = "Countries"
+				return cr
+			}(),
+		},
+		"ErrDelete": {
+			reason: "Should return an error if a row cannot be deleted",
+			mg:     seedData(`{"code": "PL", "name": "Poland"}`),
+			err:    errBoom,
+			want:   errors.Wrapf(errBoom, errDeleteRow, 0),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					if query != "DELETE FROM \"example_keyspace\".\"countries\" WHERE \"code\" = fromJson(?)" || args[0] != `"PL"` {
+						return errors.New("unexpected query: " + query)
+					}
+					return tc.err
+				},
+			}, identifiers: tc.identifiers}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRowMatches(t *testing.T) {
+	types := map[string]cqlType{
+		"id":        parseType("uuid"),
+		"tags":      parseType("set<text>"),
+		"scores":    parseType("map<uuid, frozen<list<decimal>>>"),
+		"updated":   parseType("timestamp"),
+		"ratio":     parseType("double"),
+		"active":    parseType("boolean"),
+		"untyped":   parseType("text"),
+		"position":  parseType("tuple<int, timestamp>"),
+		"unchecked": {},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		desired  string
+		observed string
+		want     bool
+	}{
+		"UUIDCase": {
+			reason:   "UUIDs should match regardless of case.",
+			desired:  `{"id": "5A0B3B9C-4D2E-4F3A-9B1C-0D2E3F4A5B6C"}`,
+			observed: `{"id": "5a0b3b9c-4d2e-4f3a-9b1c-0d2e3f4a5b6c"}`,
+			want:     true,
+		},
+		"SetOrder": {
+			reason:   "Sets should match regardless of the order of their elements.",
+			desired:  `{"tags": ["b", "a"]}`,
+			observed: `{"tags": ["a", "b"]}`,
+			want:     true,
+		},
+		"NestedMap": {
+			reason:   "The keys and values of maps should be compared by their types.",
+			desired:  `{"scores": {"5A0B3B9C-4D2E-4F3A-9B1C-0D2E3F4A5B6C": [1.5, "2"]}}`,
+			observed: `{"scores": {"5a0b3b9c-4d2e-4f3a-9b1c-0d2e3f4a5b6c": [1.50, 2.0]}}`,
+			want:     true,
+		},
+		"TimestampZone": {
+			reason:   "Timestamps should match regardless of their timezone.",
+			desired:  `{"updated": "2024-01-01T01:00:00+01:00"}`,
+			observed: `{"updated": "2024-01-01 00:00:00.000Z"}`,
+			want:     true,
+		},
+		"TimestampDiffers": {
+			reason:   "Timestamps that are a millisecond apart should not match.",
+			desired:  `{"updated": "2024-01-01 00:00:00.001Z"}`,
+			observed: `{"updated": "2024-01-01 00:00:00.000Z"}`,
+			want:     false,
+		},
+		"Double": {
+			reason:   "Doubles written in exponent notation should match.",
+			desired:  `{"ratio": 1e-3}`,
+			observed: `{"ratio": 0.001}`,
+			want:     true,
+		},
+		"BooleanString": {
+			reason:   "Booleans written as strings should match.",
+			desired:  `{"active": "true"}`,
+			observed: `{"active": true}`,
+			want:     true,
+		},
+		"Tuple": {
+			reason:   "The elements of tuples should be compared by their own types.",
+			desired:  `{"position": [1, 0]}`,
+			observed: `{"position": [1, "1970-01-01 00:00:00.000Z"]}`,
+			want:     true,
+		},
+		"TextDiffers": {
+			reason:   "Text should be compared as written.",
+			desired:  `{"untyped": "ABC"}`,
+			observed: `{"untyped": "abc"}`,
+			want:     false,
+		},
+		"Missing": {
+			reason:   "A desired column that was not returned should not match.",
+			desired:  `{"unchecked": 1}`,
+			observed: `{}`,
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			desired := map[string]interface{}{}
+			if err := decode([]byte(tc.desired), &desired); err != nil {
+				t.Fatalf("decode(...): %v", err)
+			}
+			if got := rowMatches(desired, tc.observed, types); got != tc.want {
+				t.Errorf("\n%s\nrowMatches(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
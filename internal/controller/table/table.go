@@ -0,0 +1,567 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package table
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+	"github.com/crossplane/provider-cassandra/internal/features"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+)
+
+const (
+	errNotTable     = "managed resource is not a Table custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errGetCreds     = "cannot get credentials"
+	errNewClient    = "cannot create new Service"
+
+	errNoKeyspace   = "keyspace is not set"
+	errSelectTable  = "cannot select table"
+	errSelectCDC    = "cannot select table cdc options"
+	errSelectStatus = "cannot select table status"
+	errCreateTable  = "cannot create table"
+	errUpdateTable  = "cannot update table"
+	errDropTable    = "cannot drop table"
+)
+
+// statusActive is the status of a table in Amazon Keyspaces once it has been
+// created.
+const statusActive = "ACTIVE"
+
+// Setup adds a controller that reconciles Table managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.TableGroupKind)
+
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newClient: cassandra.Sessions.New,
+			recorder:  recorder}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(cassandra.PollInterval),
+		managed.WithRecorder(recorder),
+	}
+
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.TableGroupVersionKind), opts...)
+
+	// Tables whose identifiers cannot be used in CQL are rejected on
+	// admission.
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Table{}).
+		WithValidator(cassandra.IdentifierValidator[*v1alpha1.Table](validate)).
+		Complete(); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Table{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// validate returns the errors of the name of a Table and of its keyspace.
+func validate(cr *v1alpha1.Table) field.ErrorList {
+	errs := cassandra.ValidateExternalName(cr, cassandra.ValidateName)
+	if ks := cr.Spec.ForProvider.Keyspace; ks != nil {
+		errs = append(errs, cassandra.ValidateName(field.NewPath("spec", "forProvider", "keyspace"), *ks)...)
+	}
+	return errs
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) cassandra.DB
+	recorder  event.Recorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return nil, errors.New(errNotTable)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	creds, err := cassandra.ProviderCredentials(ctx, c.kube, pc.Spec.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	opts, err := cassandra.ProviderConfigOptions(ctx, c.kube, pc.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	ropts, err := cassandra.ResourceOptions(cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	opts = append(opts, ropts...)
+	opts = append(opts, cassandra.WithSharedSession(ctx, cassandra.SessionKey(pc, creds, cr)))
+	opts = append(opts, cassandra.RateLimits.For(pc))
+	db := cassandra.RecordStatements(c.newClient(creds, "", opts...), c.recorder, cr)
+
+	return cassandra.ReportErrors(&external{db: db, dialect: pc.Spec.Dialect, async: cassandra.AwaitSchemaSettled(pc.Spec), conflicts: pc.Spec.ConflictPolicy, identifiers: pc.Spec.IdentifierCase}), nil
+}
+
+type external struct {
+	db          cassandra.DB
+	dialect     apisv1alpha1.Dialect
+	async       bool
+	conflicts   apisv1alpha1.ConflictPolicy
+	identifiers apisv1alpha1.IdentifierCase
+}
+
+// names returns the names of the keyspace and the table in the cluster.
+func (c *external) names(cr *v1alpha1.Table) (string, string) {
+	return cassandra.NormalizeIdentifier(c.identifiers, *cr.Spec.ForProvider.Keyspace), cassandra.NormalizeIdentifier(c.identifiers, meta.GetExternalName(cr))
+}
+
+// tableName returns the quoted, keyspace qualified name of the table.
+func (c *external) tableName(cr *v1alpha1.Table) string {
+	keyspace, table := c.names(cr)
+	return cassandra.QuoteIdentifier(keyspace) + "." + cassandra.QuoteIdentifier(table)
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTable)
+	}
+
+	params := cr.Spec.ForProvider
+	if params.Keyspace == nil {
+		return managed.ExternalObservation{}, errors.New(errNoKeyspace)
+	}
+
+	keyspace, table := c.names(cr)
+	query := "SELECT compaction, compression, caching FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?"
+	iter, err := c.db.Query(ctx, query, keyspace, table)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectTable)
+	}
+
+	observed := v1alpha1.TableObservation{
+		Compaction:  map[string]string{},
+		Compression: map[string]string{},
+		Caching:     map[string]string{},
+	}
+	found := iter.Scan(&observed.Compaction, &observed.Compression, &observed.Caching)
+	if err := iter.Close(); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectTable)
+	}
+	if !found {
+		return managed.ExternalObservation{
+			ResourceExists:   false,
+			ResourceUpToDate: false,
+		}, nil
+	}
+
+	// An object that already exists is only adopted if the conflict policy
+	// of the ProviderConfig allows it.
+	if err := cassandra.Conflict(cr, c.conflicts); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Amazon Keyspaces creates tables asynchronously. A table cannot be
+	// altered until it is active.
+	if c.dialect == apisv1alpha1.DialectAmazonKeyspaces {
+		status, err := c.observeStatus(ctx, keyspace, table)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectStatus)
+		}
+		if status != statusActive {
+			cr.SetConditions(xpv1.Creating())
+			return managed.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+			}, nil
+		}
+	}
+
+	// The schema change that created the table may not have reached every
+	// node of a cluster whose schema changes are asynchronous yet. The table
+	// is not ready until it has, so that the resources that depend on it are
+	// not created through nodes that do not know it.
+	if c.async {
+		settled, err := cassandra.Settled(ctx, c.db, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if !settled {
+			cr.SetConditions(xpv1.Creating())
+			return managed.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+			}, nil
+		}
+	}
+
+	if params.CDC != nil {
+		cdc, err := c.observeCDC(ctx, keyspace, table, params.CDC)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectCDC)
+		}
+		observed.CDC = cdc
+	}
+
+	cr.Status.AtProvider = observed
+	cr.SetConditions(xpv1.Available())
+
+	d := drift(observed, params)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(d) == 0,
+		Diff:             d.String(),
+	}, nil
+}
+
+// observeStatus returns the status of a table in Amazon Keyspaces, for example
+// CREATING or ACTIVE.
+func (c *external) observeStatus(ctx context.Context, keyspace, table string) (string, error) {
+	iter, err := c.db.Query(ctx, "SELECT status FROM system_schema_mcs.tables WHERE keyspace_name = ? AND table_name = ?", keyspace, table)
+	if err != nil {
+		return "", err
+	}
+	var status string
+	iter.Scan(&status)
+	return status, iter.Close()
+}
+
+// observeCDC returns the change data capture options of a table. Scylla keeps
+// its extended options in system_schema.scylla_tables, while Apache Cassandra
+// only has a boolean cdc column in system_schema.tables.
+func (c *external) observeCDC(ctx context.Context, keyspace, table string, cdc *v1alpha1.TableCDC) (map[string]string, error) {
+	if len(cdc.Options) > 0 {
+		iter, err := c.db.Query(ctx, "SELECT cdc FROM system_schema.scylla_tables WHERE keyspace_name = ? AND table_name = ?", keyspace, table)
+		if err != nil {
+			return nil, err
+		}
+		opts := map[string]string{}
+		iter.Scan(&opts)
+		return opts, iter.Close()
+	}
+
+	iter, err := c.db.Query(ctx, "SELECT cdc FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?", keyspace, table)
+	if err != nil {
+		return nil, err
+	}
+	var enabled bool
+	iter.Scan(&enabled)
+	return map[string]string{"enabled": strconv.FormatBool(enabled)}, iter.Close()
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTable)
+	}
+
+	params := cr.Spec.ForProvider
+	if params.Keyspace == nil {
+		return managed.ExternalCreation{}, errors.New(errNoKeyspace)
+	}
+
+	columns := make([]string, 0, len(params.Columns)+1)
+	for _, col := range params.Columns {
+		columns = append(columns, cassandra.QuoteIdentifier(col.Name)+" "+col.Type)
+	}
+	columns = append(columns, "PRIMARY KEY ("+primaryKey(params)+")")
+
+	query := "CREATE TABLE " + cassandra.IfNotExists(c.conflicts) + c.tableName(cr) + " (" + strings.Join(columns, ", ") + ")"
+
+	clauses := tableOptions(params)
+	if order := clusteringOrder(params); order != "" {
+		clauses = append([]string{order}, clauses...)
+	}
+	if len(clauses) > 0 {
+		query += " WITH " + strings.Join(clauses, " AND ")
+	}
+
+	if err := c.db.Exec(ctx, query); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateTable)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTable)
+	}
+
+	params := cr.Spec.ForProvider
+	if params.Keyspace == nil {
+		return managed.ExternalUpdate{}, errors.New(errNoKeyspace)
+	}
+
+	clauses := tableOptions(params)
+	if len(clauses) == 0 {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	query := "ALTER TABLE " + c.tableName(cr) + " WITH " + strings.Join(clauses, " AND ")
+	if err := c.db.Exec(ctx, query); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTable)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return errors.New(errNotTable)
+	}
+
+	if cr.Spec.ForProvider.Keyspace == nil {
+		return errors.New(errNoKeyspace)
+	}
+
+	if err := c.db.Exec(ctx, "DROP TABLE IF EXISTS "+c.tableName(cr)); err != nil {
+		return errors.Wrap(err, errDropTable)
+	}
+
+	return nil
+}
+
+func primaryKey(params v1alpha1.TableParameters) string {
+	partition := make([]string, len(params.PartitionKey))
+	for i, col := range params.PartitionKey {
+		partition[i] = cassandra.QuoteIdentifier(col)
+	}
+	key := "(" + strings.Join(partition, ", ") + ")"
+	for _, col := range params.ClusteringKey {
+		key += ", " + cassandra.QuoteIdentifier(col.Name)
+	}
+	return key
+}
+
+func clusteringOrder(params v1alpha1.TableParameters) string {
+	if len(params.ClusteringKey) == 0 {
+		return ""
+	}
+	order := make([]string, len(params.ClusteringKey))
+	for i, col := range params.ClusteringKey {
+		dir := col.Order
+		if dir == "" {
+			dir = "ASC"
+		}
+		order[i] = cassandra.QuoteIdentifier(col.Name) + " " + dir
+	}
+	return "CLUSTERING ORDER BY (" + strings.Join(order, ", ") + ")"
+}
+
+// tableOptions renders the table options set in the spec as WITH clauses.
+func tableOptions(params v1alpha1.TableParameters) []string {
+	var clauses []string
+	if params.Compaction != nil {
+		clauses = append(clauses, "compaction = "+cassandra.QuoteMap(compactionOptions(params.Compaction)))
+	}
+	if params.Compression != nil {
+		clauses = append(clauses, "compression = "+cassandra.QuoteMap(compressionOptions(params.Compression)))
+	}
+	if params.Caching != nil {
+		clauses = append(clauses, "caching = "+cassandra.QuoteMap(cachingOptions(params.Caching)))
+	}
+	if params.CDC != nil {
+		if len(params.CDC.Options) > 0 {
+			clauses = append(clauses, "cdc = "+cassandra.QuoteMap(cdcOptions(params.CDC)))
+		} else {
+			clauses = append(clauses, "cdc = "+strconv.FormatBool(params.CDC.Enabled))
+		}
+	}
+	return clauses
+}
+
+func compactionOptions(c *v1alpha1.TableCompaction) map[string]string {
+	opts := map[string]string{"class": c.Class}
+	for k, v := range c.Options {
+		opts[k] = v
+	}
+	return opts
+}
+
+func compressionOptions(c *v1alpha1.TableCompression) map[string]string {
+	opts := map[string]string{}
+	if c.Enabled != nil {
+		opts["enabled"] = strconv.FormatBool(*c.Enabled)
+	}
+	if c.Class != nil {
+		opts["class"] = *c.Class
+	}
+	if c.ChunkLengthInKB != nil {
+		opts["chunk_length_in_kb"] = strconv.Itoa(*c.ChunkLengthInKB)
+	}
+	return opts
+}
+
+func cachingOptions(c *v1alpha1.TableCaching) map[string]string {
+	opts := map[string]string{}
+	if c.Keys != nil {
+		opts["keys"] = *c.Keys
+	}
+	if c.RowsPerPartition != nil {
+		opts["rows_per_partition"] = *c.RowsPerPartition
+	}
+	return opts
+}
+
+func cdcOptions(c *v1alpha1.TableCDC) map[string]string {
+	opts := map[string]string{"enabled": strconv.FormatBool(c.Enabled)}
+	for k, v := range c.Options {
+		opts[k] = v
+	}
+	return opts
+}
+
+// optionsMatch reports whether every desired option has the same value in the
+// observed options map. Classes are compared by their simple name because
+// Cassandra stores the fully qualified class name.
+func optionsMatch(desired, observed map[string]string) bool {
+	for k, v := range desired {
+		got, ok := observed[k]
+		if !ok {
+			return false
+		}
+		if k == "class" {
+			got, v = simpleClassName(got), simpleClassName(v)
+		}
+		if !strings.EqualFold(got, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func simpleClassName(class string) string {
+	return class[strings.LastIndex(class, ".")+1:]
+}
+
+func upToDate(observed v1alpha1.TableObservation, desired v1alpha1.TableParameters) bool {
+	return len(drift(observed, desired)) == 0
+}
+
+// drift returns how the observed options of a table differ from the desired
+// ones.
+func drift(observed v1alpha1.TableObservation, desired v1alpha1.TableParameters) cassandra.Drift {
+	d := cassandra.Drift{}
+	if desired.Compaction != nil {
+		optionsDrift(&d, "compaction", compactionOptions(desired.Compaction), observed.Compaction)
+	}
+	if desired.Compression != nil {
+		optionsDrift(&d, "compression", compressionOptions(desired.Compression), observedCompression(observed.Compression))
+	}
+	if desired.Caching != nil {
+		optionsDrift(&d, "caching", cachingOptions(desired.Caching), observed.Caching)
+	}
+	if desired.CDC != nil && !cdcMatch(desired.CDC, observed.CDC) {
+		d.Field("cdc", cdcOptions(desired.CDC), observed.CDC)
+	}
+	return d
+}
+
+// observedCompression returns the observed compression options of a table,
+// with enabled set to true unless compression is disabled. Cassandra only
+// stores enabled when it is false.
+func observedCompression(observed map[string]string) map[string]string {
+	if _, ok := observed["enabled"]; ok {
+		return observed
+	}
+	o := maps.Clone(observed)
+	if o == nil {
+		o = map[string]string{}
+	}
+	o["enabled"] = "true"
+	return o
+}
+
+// optionsDrift records the desired options of the named map that differ from
+// the observed ones.
+func optionsDrift(d *cassandra.Drift, name string, desired, observed map[string]string) {
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if optionsMatch(map[string]string{k: desired[k]}, observed) {
+			continue
+		}
+		got, ok := observed[k]
+		switch {
+		case !ok:
+			got = "unset"
+		case k == "class":
+			got = simpleClassName(got)
+		}
+		d.Field(name+"."+k, desired[k], got)
+	}
+}
+
+// cdcMatch reports whether the observed change data capture options match the
+// desired ones. Scylla may drop the remaining options once CDC is disabled, so
+// only the enabled flag is compared in that case.
+func cdcMatch(desired *v1alpha1.TableCDC, observed map[string]string) bool {
+	if !desired.Enabled {
+		return !strings.EqualFold(observed["enabled"], "true")
+	}
+	return optionsMatch(cdcOptions(desired), observed)
+}
@@ -0,0 +1,569 @@
+package table
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-cassandra/apis/cql/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-cassandra/apis/v1alpha1"
+	"github.com/crossplane/provider-cassandra/internal/clients/cassandra"
+)
+
+func pointerToString(s string) *string {
+	return &s
+}
+
+func pointerToBool(b bool) *bool {
+	return &b
+}
+
+func pointerToInt(i int) *int {
+	return &i
+}
+
+type tableModifier func(*v1alpha1.Table)
+
+func withCompaction(class string, options map[string]string) tableModifier {
+	return func(t *v1alpha1.Table) {
+		t.Spec.ForProvider.Compaction = &v1alpha1.TableCompaction{Class: class, Options: options}
+	}
+}
+
+func withCompression(c *v1alpha1.TableCompression) tableModifier {
+	return func(t *v1alpha1.Table) {
+		t.Spec.ForProvider.Compression = c
+	}
+}
+
+func withCaching(c *v1alpha1.TableCaching) tableModifier {
+	return func(t *v1alpha1.Table) {
+		t.Spec.ForProvider.Caching = c
+	}
+}
+
+func withCDC(enabled bool, options map[string]string) tableModifier {
+	return func(t *v1alpha1.Table) {
+		t.Spec.ForProvider.CDC = &v1alpha1.TableCDC{Enabled: enabled, Options: options}
+	}
+}
+
+func table(m ...tableModifier) *v1alpha1.Table {
+	t := &v1alpha1.Table{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"crossplane.io/external-name": "events",
+			},
+		},
+		Spec: v1alpha1.TableSpec{
+			ForProvider: v1alpha1.TableParameters{
+				Keyspace: pointerToString("example_keyspace"),
+				Columns: []v1alpha1.TableColumn{
+					{Name: "id", Type: "uuid"},
+					{Name: "ts", Type: "timestamp"},
+					{Name: "payload", Type: "text"},
+				},
+				PartitionKey:  []string{"id"},
+				ClusteringKey: []v1alpha1.ClusteringColumn{{Name: "ts", Order: "DESC"}},
+			},
+		},
+	}
+	for _, f := range m {
+		f(t)
+	}
+	return t
+}
+
+func observedTable(compaction, compression, caching map[string]string) *cassandra.MockDB {
+	return &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			if compaction == nil {
+				return &cassandra.MockIterator{}, nil
+			}
+			return &cassandra.MockIterator{Rows: [][]interface{}{{compaction, compression, caching}}}, nil
+		},
+	}
+}
+
+func observedCDC(cdc interface{}) *cassandra.MockDB {
+	return &cassandra.MockDB{
+		QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+			switch query {
+			case "SELECT cdc FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?",
+				"SELECT cdc FROM system_schema.scylla_tables WHERE keyspace_name = ? AND table_name = ?":
+				return &cassandra.MockIterator{Rows: [][]interface{}{{cdc}}}, nil
+			default:
+				return &cassandra.MockIterator{Rows: [][]interface{}{{map[string]string{"class": "SizeTieredCompactionStrategy"}}}}, nil
+			}
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type fields struct {
+		db cassandra.DB
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	compaction := map[string]string{
+		"class":         "org.apache.cassandra.db.compaction.TimeWindowCompactionStrategy",
+		"max_threshold": "32",
+		"min_threshold": "4",
+	}
+	compression := map[string]string{
+		"chunk_length_in_kb": "16",
+		"class":              "org.apache.cassandra.io.compress.LZ4Compressor",
+	}
+	caching := map[string]string{
+		"keys":               "ALL",
+		"rows_per_partition": "NONE",
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTable": {
+			reason: "Should return an error if the managed resource is not a *Table",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTable),
+			},
+		},
+		"TableNotFound": {
+			reason: "Should return ResourceExists: false when the table does not exist",
+			fields: fields{
+				db: observedTable(nil, nil, nil),
+			},
+			args: args{
+				mg: table(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"OptionsUpToDate": {
+			reason: "Should match classes by simple name and ignore options that are not set",
+			fields: fields{
+				db: observedTable(compaction, compression, caching),
+			},
+			args: args{
+				mg: table(
+					withCompaction("TimeWindowCompactionStrategy", map[string]string{"min_threshold": "4"}),
+					withCompression(&v1alpha1.TableCompression{
+						Class:           pointerToString("LZ4Compressor"),
+						ChunkLengthInKB: pointerToInt(16),
+					}),
+					withCaching(&v1alpha1.TableCaching{Keys: pointerToString("ALL")}),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"CompactionDrifted": {
+			reason: "Should return ResourceUpToDate: false when the compaction strategy differs",
+			fields: fields{
+				db: observedTable(compaction, compression, caching),
+			},
+			args: args{
+				mg: table(withCompaction("LeveledCompactionStrategy", nil)),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "compaction.class: want LeveledCompactionStrategy, got TimeWindowCompactionStrategy"},
+			},
+		},
+		"CompressionDrifted": {
+			reason: "Should return ResourceUpToDate: false when compression is disabled in the spec",
+			fields: fields{
+				db: observedTable(compaction, compression, caching),
+			},
+			args: args{
+				mg: table(withCompression(&v1alpha1.TableCompression{Enabled: pointerToBool(false)})),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "compression.enabled: want false, got true"},
+			},
+		},
+		"CompressionEnabledUpToDate": {
+			reason: "Should treat compression as enabled when Cassandra does not store enabled",
+			fields: fields{
+				db: observedTable(compaction, compression, caching),
+			},
+			args: args{
+				mg: table(withCompression(&v1alpha1.TableCompression{
+					Enabled:         pointerToBool(true),
+					Class:           pointerToString("LZ4Compressor"),
+					ChunkLengthInKB: pointerToInt(16),
+				})),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"CDCUpToDate": {
+			reason: "Should return ResourceUpToDate: true when CDC is enabled on Apache Cassandra",
+			fields: fields{
+				db: observedCDC(true),
+			},
+			args: args{
+				mg: table(withCDC(true, nil)),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"CDCDrifted": {
+			reason: "Should return ResourceUpToDate: false when CDC is disabled on Apache Cassandra",
+			fields: fields{
+				db: observedCDC(false),
+			},
+			args: args{
+				mg: table(withCDC(true, nil)),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "cdc: want map[enabled:true], got map[enabled:false]"},
+			},
+		},
+		"ScyllaCDCDrifted": {
+			reason: "Should return ResourceUpToDate: false when Scylla CDC options differ",
+			fields: fields{
+				db: observedCDC(map[string]string{"enabled": "true", "preimage": "false", "postimage": "false", "ttl": "86400", "delta": "full"}),
+			},
+			args: args{
+				mg: table(withCDC(true, map[string]string{"preimage": "full"})),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "cdc: want map[enabled:true preimage:full], got map[delta:full enabled:true postimage:false preimage:false ttl:86400]"},
+			},
+		},
+		"ScyllaCDCDisabled": {
+			reason: "Should ignore remaining Scylla CDC options once CDC is disabled",
+			fields: fields{
+				db: observedCDC(map[string]string{}),
+			},
+			args: args{
+				mg: table(withCDC(false, map[string]string{"preimage": "full"})),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"CachingDrifted": {
+			reason: "Should return ResourceUpToDate: false when the row cache differs",
+			fields: fields{
+				db: observedTable(compaction, compression, caching),
+			},
+			args: args{
+				mg: table(withCaching(&v1alpha1.TableCaching{RowsPerPartition: pointerToString("100")})),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "caching.rows_per_partition: want 100, got NONE"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: tc.fields.db}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		query  string
+		err    error
+		want   error
+	}{
+		"ErrNotTable": {
+			reason: "Should return an error if the managed resource is not a *Table",
+			mg:     nil,
+			want:   errors.New(errNotTable),
+		},
+		"CreateTable": {
+			reason: "Should create the table with its primary key and clustering order",
+			mg:     table(),
+			query:  "CREATE TABLE IF NOT EXISTS \"example_keyspace\".\"events\" (\"id\" uuid, \"ts\" timestamp, \"payload\" text, PRIMARY KEY ((\"id\"), \"ts\")) WITH CLUSTERING ORDER BY (\"ts\" DESC)",
+		},
+		"CreateTableWithOptions": {
+			reason: "Should create the table with compaction, compression and caching options",
+			mg: table(
+				withCompaction("TimeWindowCompactionStrategy", map[string]string{"compaction_window_unit": "DAYS", "compaction_window_size": "1"}),
+				withCompression(&v1alpha1.TableCompression{Class: pointerToString("ZstdCompressor"), ChunkLengthInKB: pointerToInt(64)}),
+				withCaching(&v1alpha1.TableCaching{Keys: pointerToString("ALL"), RowsPerPartition: pointerToString("NONE")}),
+			),
+			query: "CREATE TABLE IF NOT EXISTS \"example_keyspace\".\"events\" (\"id\" uuid, \"ts\" timestamp, \"payload\" text, PRIMARY KEY ((\"id\"), \"ts\")) WITH CLUSTERING ORDER BY (\"ts\" DESC) AND compaction = {'class': 'TimeWindowCompactionStrategy', 'compaction_window_size': '1', 'compaction_window_unit': 'DAYS'} AND compression = {'chunk_length_in_kb': '64', 'class': 'ZstdCompressor'} AND caching = {'keys': 'ALL', 'rows_per_partition': 'NONE'}",
+		},
+		"ErrCreate": {
+			reason: "Should return an error if the table cannot be created",
+			mg:     table(),
+			query:  "CREATE TABLE IF NOT EXISTS \"example_keyspace\".\"events\" (\"id\" uuid, \"ts\" timestamp, \"payload\" text, PRIMARY KEY ((\"id\"), \"ts\")) WITH CLUSTERING ORDER BY (\"ts\" DESC)",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errCreateTable),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					if query != tc.query {
+						return errors.New("unexpected query: " + query)
+					}
+					return tc.err
+				},
+			}}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		query  string
+		err    error
+		want   error
+	}{
+		"ErrNotTable": {
+			reason: "Should return an error if the managed resource is not a *Table",
+			mg:     nil,
+			want:   errors.New(errNotTable),
+		},
+		"NoOptions": {
+			reason: "Should not alter the table when no options are set",
+			mg:     table(),
+		},
+		"AlterCompaction": {
+			reason: "Should alter the compaction strategy",
+			mg:     table(withCompaction("LeveledCompactionStrategy", map[string]string{"sstable_size_in_mb": "160"})),
+			query:  "ALTER TABLE \"example_keyspace\".\"events\" WITH compaction = {'class': 'LeveledCompactionStrategy', 'sstable_size_in_mb': '160'}",
+		},
+		"DisableCompression": {
+			reason: "Should disable compression",
+			mg:     table(withCompression(&v1alpha1.TableCompression{Enabled: pointerToBool(false)})),
+			query:  "ALTER TABLE \"example_keyspace\".\"events\" WITH compression = {'enabled': 'false'}",
+		},
+		"EnableCDC": {
+			reason: "Should enable CDC with the boolean syntax",
+			mg:     table(withCDC(true, nil)),
+			query:  "ALTER TABLE \"example_keyspace\".\"events\" WITH cdc = true",
+		},
+		"EnableScyllaCDC": {
+			reason: "Should enable CDC with the Scylla options map syntax",
+			mg:     table(withCDC(true, map[string]string{"preimage": "full", "ttl": "3600"})),
+			query:  "ALTER TABLE \"example_keyspace\".\"events\" WITH cdc = {'enabled': 'true', 'preimage': 'full', 'ttl': '3600'}",
+		},
+		"ErrUpdate": {
+			reason: "Should return an error if the table cannot be altered",
+			mg:     table(withCaching(&v1alpha1.TableCaching{Keys: pointerToString("NONE")})),
+			query:  "ALTER TABLE \"example_keyspace\".\"events\" WITH caching = {'keys': 'NONE'}",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errUpdateTable),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					if query != tc.query {
+						return errors.New("unexpected query: " + query)
+					}
+					return tc.err
+				},
+			}}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		err    error
+		want   error
+	}{
+		"ErrNotTable": {
+			reason: "Should return an error if the managed resource is not a *Table",
+			mg:     nil,
+			want:   errors.New(errNotTable),
+		},
+		"DropTable": {
+			reason: "Should drop the table",
+			mg:     table(),
+		},
+		"ErrDrop": {
+			reason: "Should return an error if the table cannot be dropped",
+			mg:     table(),
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errDropTable),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{db: &cassandra.MockDB{
+				ExecFunc: func(ctx context.Context, query string, args ...interface{}) error {
+					if query != "DROP TABLE IF EXISTS \"example_keyspace\".\"events\"" {
+						return errors.New("unexpected query: " + query)
+					}
+					return tc.err
+				},
+			}}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveAmazonKeyspaces(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status string
+		want   managed.ExternalObservation
+	}{
+		"Creating": {
+			reason: "Should not update a table that Amazon Keyspaces is still creating",
+			status: "CREATING",
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"Active": {
+			reason: "Should compare the options of an active table",
+			status: "ACTIVE",
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "compaction.class: want LeveledCompactionStrategy, got SizeTieredCompactionStrategy"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &cassandra.MockDB{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+					if query == "SELECT status FROM system_schema_mcs.tables WHERE keyspace_name = ? AND table_name = ?" {
+						return &cassandra.MockIterator{Rows: [][]interface{}{{tc.status}}}, nil
+					}
+					return &cassandra.MockIterator{Rows: [][]interface{}{{map[string]string{"class": "SizeTieredCompactionStrategy"}}}}, nil
+				},
+			}
+
+			e := external{db: db, dialect: apisv1alpha1.DialectAmazonKeyspaces}
+			got, err := e.Observe(context.Background(), table(withCompaction("LeveledCompactionStrategy", nil)))
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveAsynchronous(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		available bool
+		peer      string
+		want      managed.ExternalObservation
+		ready     xpv1.ConditionReason
+	}{
+		"Settling": {
+			reason: "Should not consider a new table ready until every node agrees on the schema",
+			peer:   "b",
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			ready:  xpv1.ReasonCreating,
+		},
+		"Settled": {
+			reason: "Should consider a new table ready once every node agrees on the schema",
+			peer:   "a",
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "compaction.class: want LeveledCompactionStrategy, got SizeTieredCompactionStrategy"},
+			ready:  xpv1.ReasonAvailable,
+		},
+		"AlreadyAvailable": {
+			reason:    "Should not gate a table that was ready on later schema changes",
+			available: true,
+			peer:      "b",
+			want:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "compaction.class: want LeveledCompactionStrategy, got SizeTieredCompactionStrategy"},
+			ready:     xpv1.ReasonAvailable,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &cassandra.MockDB{
+				QueryFunc: func(ctx context.Context, query string, args ...interface{}) (cassandra.Iterator, error) {
+					switch query {
+					case "SELECT schema_version FROM system.local":
+						return &cassandra.MockIterator{Rows: [][]interface{}{{"a"}}}, nil
+					case "SELECT schema_version FROM system.peers":
+						return &cassandra.MockIterator{Rows: [][]interface{}{{"a"}, {tc.peer}}}, nil
+					}
+					return &cassandra.MockIterator{Rows: [][]interface{}{{map[string]string{"class": "SizeTieredCompactionStrategy"}}}}, nil
+				},
+			}
+
+			cr := table(withCompaction("LeveledCompactionStrategy", nil))
+			if tc.available {
+				cr.SetConditions(xpv1.Available())
+			}
+			e := external{db: db, async: true}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.ready, cr.GetCondition(xpv1.TypeReady).Reason); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want ready reason, +got ready reason:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}